@@ -0,0 +1,274 @@
+package aggregator
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const (
+	// ProofsEndpoint lists the proofs stored by the aggregator, optionally filtered by the state query param
+	ProofsEndpoint = "/proofs"
+	// CancelProofEndpoint cancels a proof being generated by a prover
+	CancelProofEndpoint = "/proofs/cancel"
+	// ReproveEndpoint deletes the proofs of a range of batches so they are proven again
+	ReproveEndpoint = "/proofs/reprove"
+	// SettlementEndpoint returns if the submission of final proofs is paused
+	SettlementEndpoint = "/settlement"
+	// PauseSettlementEndpoint pauses the submission of final proofs
+	PauseSettlementEndpoint = "/settlement/pause"
+	// ResumeSettlementEndpoint resumes the submission of final proofs
+	ResumeSettlementEndpoint = "/settlement/resume"
+
+	maxAdminRequestSize = 1 << 20
+)
+
+// ProofState is the state of a proof stored by the aggregator
+type ProofState string
+
+const (
+	// ProofStateGenerating is a proof being generated, or used as input of a proof being generated
+	ProofStateGenerating ProofState = "generating"
+	// ProofStateAggregatable is a generated proof waiting to be aggregated with the next one
+	ProofStateAggregatable ProofState = "aggregatable"
+	// ProofStateFinal is a generated proof starting at the next batch to verify, so a final proof can be built from it
+	ProofStateFinal ProofState = "final"
+)
+
+// ProofStatus is the status of a proof returned by the admin API
+type ProofStatus struct {
+	BatchNumber      uint64     `json:"batchNumber"`
+	BatchNumberFinal uint64     `json:"batchNumberFinal"`
+	State            ProofState `json:"state"`
+	ProofID          string     `json:"proofId,omitempty"`
+	Prover           string     `json:"prover,omitempty"`
+	ProverID         string     `json:"proverId,omitempty"`
+	GeneratingSince  *time.Time `json:"generatingSince,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+// CancelProofRequest is the body of the CancelProofEndpoint request
+type CancelProofRequest struct {
+	ProofID string `json:"proofId"`
+}
+
+// ReproveRequest is the body of the ReproveEndpoint request
+type ReproveRequest struct {
+	FromBatch uint64 `json:"fromBatch"`
+	ToBatch   uint64 `json:"toBatch"`
+}
+
+// ReproveResponse is the response of the ReproveEndpoint request
+type ReproveResponse struct {
+	DeletedProofs    int64    `json:"deletedProofs"`
+	CanceledProofIDs []string `json:"canceledProofIds"`
+}
+
+// SettlementStatus is the response of the settlement endpoints
+type SettlementStatus struct {
+	Paused bool `json:"paused"`
+	// LastSettlement is the status of the last final proof sent by the multi settlement backend
+	LastSettlement *MultiSettlementStatus `json:"lastSettlement,omitempty"`
+}
+
+// registerAdminHandlers adds the admin endpoints to the API, all of them requiring the admin token
+func (a *Aggregator) registerAdminHandlers(mux *http.ServeMux) {
+	mux.HandleFunc(ProofsEndpoint, a.requireAdmin(http.MethodGet, a.handleProofs))
+	mux.HandleFunc(CancelProofEndpoint, a.requireAdmin(http.MethodPost, a.handleCancelProof))
+	mux.HandleFunc(ReproveEndpoint, a.requireAdmin(http.MethodPost, a.handleReprove))
+	mux.HandleFunc(SettlementEndpoint, a.requireAdmin(http.MethodGet, a.handleSettlement))
+	mux.HandleFunc(PauseSettlementEndpoint, a.requireAdmin(http.MethodPost, a.handlePauseSettlement(true)))
+	mux.HandleFunc(ResumeSettlementEndpoint, a.requireAdmin(http.MethodPost, a.handlePauseSettlement(false)))
+}
+
+// requireAdmin only lets through the requests with the expected method carrying the admin token
+// as a bearer token. The admin endpoints are forbidden when no admin token is configured
+func (a *Aggregator) requireAdmin(method string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.cfg.API.AdminToken == "" {
+			writeAPIError(w, http.StatusForbidden, "admin API disabled")
+			return
+		}
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(a.cfg.API.AdminToken)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		if r.Method != method {
+			writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (a *Aggregator) handleProofs(w http.ResponseWriter, r *http.Request) {
+	filter := ProofState(r.URL.Query().Get("state"))
+	switch filter {
+	case "", ProofStateGenerating, ProofStateAggregatable, ProofStateFinal:
+	default:
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid proof state %s", filter))
+		return
+	}
+
+	proofs, err := a.getProofStatuses(r)
+	if err != nil {
+		log.Errorf("Failed to get proofs for the admin API: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to get proofs")
+		return
+	}
+
+	res := make([]ProofStatus, 0, len(proofs))
+	for _, proof := range proofs {
+		if filter == "" || proof.State == filter {
+			res = append(res, proof)
+		}
+	}
+	writeAPIResponse(w, res)
+}
+
+// getProofStatuses returns the stored proofs, with the prover generating them if it is connected
+func (a *Aggregator) getProofStatuses(r *http.Request) ([]ProofStatus, error) {
+	lastVerifiedBatch, err := a.State.GetLastVerifiedBatch(r.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+	proofs, err := a.State.GetProofs(r.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]ProofStatus, 0, len(proofs))
+	for _, proof := range proofs {
+		status := ProofStatus{
+			BatchNumber:      proof.BatchNumber,
+			BatchNumberFinal: proof.BatchNumberFinal,
+			GeneratingSince:  proof.GeneratingSince,
+			CreatedAt:        proof.CreatedAt,
+			UpdatedAt:        proof.UpdatedAt,
+		}
+		if proof.ProofID != nil {
+			status.ProofID = *proof.ProofID
+		}
+		if proof.Prover != nil {
+			status.Prover = *proof.Prover
+		}
+		if proof.ProverID != nil {
+			status.ProverID = *proof.ProverID
+		}
+
+		switch {
+		case proof.GeneratingSince != nil:
+			status.State = ProofStateGenerating
+			if p, found := a.proverRegistry.assignedTo(proof.BatchNumber, proof.BatchNumberFinal); found {
+				status.ProofID = p.CurrentProof.ID
+				status.Prover = p.Name
+				status.ProverID = p.ID
+			}
+		case proof.BatchNumber == lastVerifiedBatch.BatchNumber+1:
+			status.State = ProofStateFinal
+		default:
+			status.State = ProofStateAggregatable
+		}
+		res = append(res, status)
+	}
+	return res, nil
+}
+
+func (a *Aggregator) handleCancelProof(w http.ResponseWriter, r *http.Request) {
+	var req CancelProofRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminRequestSize)).Decode(&req); err != nil || req.ProofID == "" {
+		writeAPIError(w, http.StatusBadRequest, "invalid request, proofId is required")
+		return
+	}
+
+	found, err := a.proverRegistry.cancelProof(req.ProofID)
+	if !found {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("proof %s isn't being generated by a connected prover", req.ProofID))
+		return
+	}
+	if err != nil {
+		log.Errorf("Failed to cancel proof %s: %v", req.ProofID, err)
+		writeAPIError(w, http.StatusBadGateway, fmt.Sprintf("failed to cancel proof %s: %v", req.ProofID, err))
+		return
+	}
+
+	log.Infof("Proof %s canceled through the admin API", req.ProofID)
+	writeAPIResponse(w, req)
+}
+
+func (a *Aggregator) handleReprove(w http.ResponseWriter, r *http.Request) {
+	var req ReproveRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminRequestSize)).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	if req.FromBatch == 0 || req.FromBatch > req.ToBatch {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid batch range %d-%d", req.FromBatch, req.ToBatch))
+		return
+	}
+
+	a.StateDBMutex.Lock()
+	defer a.StateDBMutex.Unlock()
+
+	lastVerifiedBatch, err := a.State.GetLastVerifiedBatch(r.Context(), nil)
+	if err != nil {
+		log.Errorf("Failed to get last verified batch for the admin API: %v", err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to get last verified batch")
+		return
+	}
+	if req.FromBatch <= lastVerifiedBatch.BatchNumber {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("batch %d is already verified", req.FromBatch))
+		return
+	}
+
+	// the proofs being generated over the range would store their result once done, so cancel them
+	res := ReproveResponse{CanceledProofIDs: []string{}}
+	for _, proofID := range a.proverRegistry.overlapping(req.FromBatch, req.ToBatch) {
+		if _, err := a.proverRegistry.cancelProof(proofID); err != nil {
+			log.Warnf("Failed to cancel proof %s to reprove batches %d-%d: %v", proofID, req.FromBatch, req.ToBatch, err)
+			continue
+		}
+		res.CanceledProofIDs = append(res.CanceledProofIDs, proofID)
+	}
+
+	res.DeletedProofs, err = a.State.DeleteProofsContainingBatches(r.Context(), req.FromBatch, req.ToBatch, nil)
+	if err != nil {
+		log.Errorf("Failed to delete proofs of batches %d-%d: %v", req.FromBatch, req.ToBatch, err)
+		writeAPIError(w, http.StatusInternalServerError, "failed to delete proofs")
+		return
+	}
+
+	log.Infof("Batches %d-%d marked to be proven again through the admin API, %d proofs deleted", req.FromBatch, req.ToBatch, res.DeletedProofs)
+	writeAPIResponse(w, res)
+}
+
+func (a *Aggregator) handleSettlement(w http.ResponseWriter, r *http.Request) {
+	writeAPIResponse(w, a.settlementStatus())
+}
+
+func (a *Aggregator) settlementStatus() SettlementStatus {
+	status := SettlementStatus{Paused: a.isFinalProofPaused()}
+	if a.multiSettler != nil {
+		status.LastSettlement = a.multiSettler.status()
+	}
+	return status
+}
+
+func (a *Aggregator) handlePauseSettlement(paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.setFinalProofPaused(paused)
+		if paused {
+			log.Info("Final proof submission paused through the admin API")
+		} else {
+			log.Info("Final proof submission resumed through the admin API")
+		}
+		writeAPIResponse(w, a.settlementStatus())
+	}
+}
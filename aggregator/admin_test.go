@@ -0,0 +1,198 @@
+package aggregator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "secret"
+
+func newAdminTestAggregator(t *testing.T, adminToken string) (*Aggregator, *mocks.StateMock) {
+	storageMock := mocks.NewProverStorageMock(t)
+	storageMock.On("AddOrUpdateProver", mock.Anything, mock.Anything, nil).Return(nil).Maybe()
+	stateMock := mocks.NewStateMock(t)
+	a := &Aggregator{
+		cfg:                     Config{API: APIConfig{AdminToken: adminToken}},
+		State:                   stateMock,
+		StateDBMutex:            &sync.Mutex{},
+		TimeSendFinalProofMutex: &sync.RWMutex{},
+		proverRegistry:          newProverRegistry(storageMock),
+	}
+	return a, stateMock
+}
+
+func adminRequest(t *testing.T, srv *httptest.Server, method, path, token string, body interface{}) *http.Response {
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+	req, err := http.NewRequest(method, srv.URL+path, &reqBody)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func TestAdminAPIAuth(t *testing.T) {
+	disabled, _ := newAdminTestAggregator(t, "")
+	srv := httptest.NewServer(disabled.newAPIHandler())
+	defer srv.Close()
+	res := adminRequest(t, srv, http.MethodGet, SettlementEndpoint, testAdminToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	a, _ := newAdminTestAggregator(t, testAdminToken)
+	srv = httptest.NewServer(a.newAPIHandler())
+	defer srv.Close()
+	res = adminRequest(t, srv, http.MethodGet, SettlementEndpoint, "", nil)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	res = adminRequest(t, srv, http.MethodGet, SettlementEndpoint, "wrong", nil)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	res = adminRequest(t, srv, http.MethodPost, SettlementEndpoint, testAdminToken, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	res = adminRequest(t, srv, http.MethodGet, SettlementEndpoint, testAdminToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// the prover registry stays public
+	res = adminRequest(t, srv, http.MethodGet, ProversEndpoint, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestAdminAPIProofs(t *testing.T) {
+	a, stateMock := newAdminTestAggregator(t, testAdminToken)
+	srv := httptest.NewServer(a.newAPIHandler())
+	defer srv.Close()
+
+	proofID, proverName, proverID := "storedProofId", "prover1", "p1"
+	generatingSince := time.Now().Round(time.Microsecond)
+	stateMock.On("GetLastVerifiedBatch", mock.Anything, nil).Return(&state.VerifiedBatch{BatchNumber: 10}, nil)
+	stateMock.On("GetProofs", mock.Anything, nil).Return([]*state.Proof{
+		{BatchNumber: 11, BatchNumberFinal: 12, ProofID: &proofID, Prover: &proverName, ProverID: &proverID},
+		{BatchNumber: 13, BatchNumberFinal: 13, ProofID: &proofID, Prover: &proverName, ProverID: &proverID},
+		{BatchNumber: 14, BatchNumberFinal: 14, Prover: &proverName, ProverID: &proverID, GeneratingSince: &generatingSince},
+		{BatchNumber: 15, BatchNumberFinal: 15, Prover: &proverName, ProverID: &proverID, GeneratingSince: &generatingSince},
+	}, nil)
+
+	ctx := context.Background()
+	a.proverRegistry.connect(ctx, "p2", "prover2", "", forkId9, nil)
+	a.proverRegistry.setCurrentProof("p2", prover.InFlightProof{ID: "inFlightProofId", Type: prover.ProofTypeBatch, StartedAt: generatingSince})
+	a.proverRegistry.assignBatches("inFlightProofId", 14, 14)
+
+	res := adminRequest(t, srv, http.MethodGet, ProofsEndpoint, testAdminToken, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var proofs []ProofStatus
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&proofs))
+	require.Len(t, proofs, 4)
+	assert.Equal(t, ProofStateFinal, proofs[0].State)
+	assert.Equal(t, ProofStateAggregatable, proofs[1].State)
+	assert.Equal(t, proofID, proofs[1].ProofID)
+	assert.Equal(t, ProofStateGenerating, proofs[2].State)
+	assert.Equal(t, "inFlightProofId", proofs[2].ProofID)
+	assert.Equal(t, "prover2", proofs[2].Prover)
+	assert.Equal(t, "p2", proofs[2].ProverID)
+	// the prover that locked the batch is reported while the proof isn't requested
+	assert.Equal(t, ProofStateGenerating, proofs[3].State)
+	assert.Empty(t, proofs[3].ProofID)
+	assert.Equal(t, proverID, proofs[3].ProverID)
+
+	res = adminRequest(t, srv, http.MethodGet, ProofsEndpoint+"?state=generating", testAdminToken, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	proofs = nil
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&proofs))
+	require.Len(t, proofs, 2)
+	assert.Equal(t, uint64(14), proofs[0].BatchNumber)
+	assert.Equal(t, uint64(15), proofs[1].BatchNumber)
+
+	res = adminRequest(t, srv, http.MethodGet, ProofsEndpoint+"?state=unknown", testAdminToken, nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestAdminAPICancelProof(t *testing.T) {
+	a, _ := newAdminTestAggregator(t, testAdminToken)
+	srv := httptest.NewServer(a.newAPIHandler())
+	defer srv.Close()
+
+	proverMock := mocks.NewProverMock(t)
+	proverMock.On("CancelProofRequest", "proofId").Return(nil).Once()
+	a.proverRegistry.connect(context.Background(), "p1", "prover1", "", forkId9, proverMock)
+	a.proverRegistry.setCurrentProof("p1", prover.InFlightProof{ID: "proofId", Type: prover.ProofTypeAggregated})
+
+	res := adminRequest(t, srv, http.MethodPost, CancelProofEndpoint, testAdminToken, CancelProofRequest{ProofID: "proofId"})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res = adminRequest(t, srv, http.MethodPost, CancelProofEndpoint, testAdminToken, CancelProofRequest{ProofID: "unknown"})
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res = adminRequest(t, srv, http.MethodPost, CancelProofEndpoint, testAdminToken, CancelProofRequest{})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// once idle the prover isn't generating the proof anymore
+	a.proverRegistry.setBusy("p1", false)
+	res = adminRequest(t, srv, http.MethodPost, CancelProofEndpoint, testAdminToken, CancelProofRequest{ProofID: "proofId"})
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestAdminAPIReprove(t *testing.T) {
+	a, stateMock := newAdminTestAggregator(t, testAdminToken)
+	srv := httptest.NewServer(a.newAPIHandler())
+	defer srv.Close()
+
+	proverMock := mocks.NewProverMock(t)
+	proverMock.On("CancelProofRequest", "aggregatedProofId").Return(nil).Once()
+	a.proverRegistry.connect(context.Background(), "p1", "prover1", "", forkId9, proverMock)
+	a.proverRegistry.setCurrentProof("p1", prover.InFlightProof{ID: "aggregatedProofId", Type: prover.ProofTypeAggregated})
+	a.proverRegistry.assignBatches("aggregatedProofId", 18, 22)
+
+	stateMock.On("GetLastVerifiedBatch", mock.Anything, nil).Return(&state.VerifiedBatch{BatchNumber: 10}, nil)
+	stateMock.On("DeleteProofsContainingBatches", mock.Anything, uint64(20), uint64(25), nil).Return(int64(3), nil).Once()
+
+	res := adminRequest(t, srv, http.MethodPost, ReproveEndpoint, testAdminToken, ReproveRequest{FromBatch: 20, ToBatch: 25})
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var reprove ReproveResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&reprove))
+	assert.Equal(t, ReproveResponse{DeletedProofs: 3, CanceledProofIDs: []string{"aggregatedProofId"}}, reprove)
+
+	res = adminRequest(t, srv, http.MethodPost, ReproveEndpoint, testAdminToken, ReproveRequest{FromBatch: 10, ToBatch: 25})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res = adminRequest(t, srv, http.MethodPost, ReproveEndpoint, testAdminToken, ReproveRequest{FromBatch: 25, ToBatch: 20})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestAdminAPIPauseSettlement(t *testing.T) {
+	a, _ := newAdminTestAggregator(t, testAdminToken)
+	srv := httptest.NewServer(a.newAPIHandler())
+	defer srv.Close()
+	a.TimeSendFinalProof = time.Now().Add(-time.Minute)
+	require.True(t, a.canVerifyProof())
+
+	res := adminRequest(t, srv, http.MethodPost, PauseSettlementEndpoint, testAdminToken, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.False(t, a.canVerifyProof())
+
+	res = adminRequest(t, srv, http.MethodGet, SettlementEndpoint, testAdminToken, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var status SettlementStatus
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&status))
+	assert.True(t, status.Paused)
+
+	res = adminRequest(t, srv, http.MethodPost, ResumeSettlementEndpoint, testAdminToken, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.True(t, a.canVerifyProof())
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,6 +19,7 @@ import (
 	"github.com/0xPolygon/agglayer/client"
 	agglayerTypes "github.com/0xPolygon/agglayer/rpc/types"
 	"github.com/0xPolygon/agglayer/tx"
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/archive"
 	"github.com/0xPolygonHermez/zkevm-node/aggregator/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
@@ -42,6 +44,9 @@ const (
 	monitoredIDFormat = "proof-from-%v-to-%v"
 
 	forkId9 = uint64(9)
+
+	// queueMetricsInterval is the interval to update the metrics of the proving queue
+	queueMetricsInterval = 30 * time.Second
 )
 
 type finalProofMsg struct {
@@ -68,10 +73,22 @@ type Aggregator struct {
 	TimeSendFinalProofMutex *sync.RWMutex
 	finalProof              chan finalProofMsg
 	verifyingProof          bool
-
-	srv  *grpc.Server
-	ctx  context.Context
-	exit context.CancelFunc
+	// finalProofPaused stops the submission of final proofs, guarded by TimeSendFinalProofMutex
+	finalProofPaused bool
+	// lastBtcAnchor is the time the last final proof was inscribed in Bitcoin, guarded by TimeSendFinalProofMutex
+	lastBtcAnchor time.Time
+
+	srv    *grpc.Server
+	apiSrv *http.Server
+	ctx    context.Context
+	exit   context.CancelFunc
+
+	proverRegistry *proverRegistry
+	multiSettler   *multiSettler
+	// settlementChecker holds back the final proofs while settling them isn't worth it, nil if they are always settled
+	settlementChecker settlementProfitabilityChecker
+	// archive keeps the settled final proofs, nil if disabled
+	archive *archive.Archive
 
 	AggLayerClient      client.ClientInterface
 	sequencerPrivateKey *ecdsa.PrivateKey
@@ -84,15 +101,35 @@ func New(
 	ethTxManager ethTxManager,
 	etherman etherman,
 	btcman btcman,
+	storage proverStorage,
 	agglayerClient client.ClientInterface,
 	sequencerPrivateKey *ecdsa.PrivateKey,
 ) (Aggregator, error) {
-	var profitabilityChecker aggregatorTxProfitabilityChecker
+	var (
+		profitabilityChecker aggregatorTxProfitabilityChecker
+		settlementChecker    settlementProfitabilityChecker
+	)
 	switch cfg.TxProfitabilityCheckerType {
 	case ProfitabilityBase:
 		profitabilityChecker = NewTxProfitabilityCheckerBase(stateInterface, cfg.IntervalAfterWhichBatchConsolidateAnyway.Duration, cfg.TxProfitabilityMinReward.Int)
 	case ProfitabilityAcceptAll:
 		profitabilityChecker = NewTxProfitabilityCheckerAcceptAll(stateInterface, cfg.IntervalAfterWhichBatchConsolidateAnyway.Duration)
+	case ProfitabilityCost:
+		costChecker, err := NewTxProfitabilityCheckerCost(cfg, stateInterface, etherman, btcman)
+		if err != nil {
+			return Aggregator{}, err
+		}
+		profitabilityChecker = costChecker
+		settlementChecker = costChecker
+	}
+
+	var proofArchive *archive.Archive
+	if cfg.Archive.Enabled {
+		var err error
+		proofArchive, err = archive.New(cfg.Archive)
+		if err != nil {
+			return Aggregator{}, fmt.Errorf("failed to create proof archive: %w", err)
+		}
 	}
 	a := Aggregator{
 		cfg: cfg,
@@ -109,6 +146,9 @@ func New(
 		finalProof:          make(chan finalProofMsg),
 		AggLayerClient:      agglayerClient,
 		sequencerPrivateKey: sequencerPrivateKey,
+		proverRegistry:      newProverRegistry(storage),
+		settlementChecker:   settlementChecker,
+		archive:             proofArchive,
 	}
 
 	return a, nil
@@ -140,6 +180,18 @@ func (a *Aggregator) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize proofs cache %w", err)
 	}
 
+	err = a.proverRegistry.load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load prover registry %w", err)
+	}
+
+	if a.cfg.SettlementBackend == Multi {
+		a.multiSettler, err = a.newMultiSettler()
+		if err != nil {
+			return fmt.Errorf("failed to configure multi settlement %w", err)
+		}
+	}
+
 	address := fmt.Sprintf("%s:%d", a.cfg.Host, a.cfg.Port)
 	lis, err := net.Listen("tcp", address)
 	if err != nil {
@@ -160,10 +212,15 @@ func (a *Aggregator) Start(ctx context.Context) error {
 		}
 	}()
 
+	if a.cfg.API.Enabled {
+		go a.startAPI()
+	}
+
 	a.resetVerifyProofTime()
 
 	go a.cleanupLockedProofs()
 	go a.sendFinalProof()
+	go a.updateQueueMetrics()
 
 	<-ctx.Done()
 	return ctx.Err()
@@ -173,6 +230,11 @@ func (a *Aggregator) Start(ctx context.Context) error {
 func (a *Aggregator) Stop() {
 	a.exit()
 	a.srv.Stop()
+	if a.apiSrv != nil {
+		if err := a.apiSrv.Close(); err != nil {
+			log.Errorf("Failed to stop aggregator API: %v", err)
+		}
+	}
 }
 
 // Channel implements the bi-directional communication channel between the
@@ -187,25 +249,35 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 	if ok {
 		proverAddr = p.Addr
 	}
-	prover, err := prover.New(stream, proverAddr, a.cfg.ProofStatePollingInterval)
+	grpcProver, err := prover.New(stream, proverAddr, a.cfg.ProofStatePollingInterval)
 	if err != nil {
 		return err
 	}
 
 	log := log.WithFields(
-		"prover", prover.Name(),
-		"proverId", prover.ID(),
-		"proverAddr", prover.Addr(),
+		"prover", grpcProver.Name(),
+		"proverId", grpcProver.ID(),
+		"proverAddr", grpcProver.Addr(),
 	)
 	log.Info("Establishing stream connection with prover")
 
+	forkID, err := grpcProver.ForkID()
+	if err != nil {
+		log.Warnf("Failed to get prover fork ID: %v", err)
+		return err
+	}
+	a.proverRegistry.connect(ctx, grpcProver.ID(), grpcProver.Name(), grpcProver.Addr(), forkID, grpcProver)
+	defer a.proverRegistry.disconnect(grpcProver.ID())
+
 	// Check if prover supports the required Fork ID
-	if !prover.SupportsForkID(forkId9) {
+	if forkID != forkId9 {
 		err := errors.New("prover does not support required fork ID")
 		log.Warn(FirstToUpper(err.Error()))
 		return err
 	}
 
+	tracked := newTrackedProver(a.ctx, grpcProver, a.proverRegistry)
+
 	for {
 		select {
 		case <-a.ctx.Done():
@@ -216,7 +288,7 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 			return ctx.Err()
 
 		default:
-			isIdle, err := prover.IsIdle()
+			isIdle, err := tracked.IsIdle()
 			if err != nil {
 				log.Errorf("Failed to check if prover is idle: %v", err)
 				time.Sleep(a.cfg.RetryTime.Duration)
@@ -228,22 +300,29 @@ func (a *Aggregator) Channel(stream prover.AggregatorService_ChannelServer) erro
 				continue
 			}
 
-			_, err = a.tryBuildFinalProof(ctx, prover, nil)
-			if err != nil {
-				log.Errorf("Error checking proofs to verify: %v", err)
+			// final and aggregated proofs are left to the best suited idle prover, batch proofs are
+			// generated by any prover
+			if a.proverRegistry.isBestSuited(tracked.ID(), prover.ProofTypeFinal, forkId9) {
+				_, err = a.tryBuildFinalProof(ctx, tracked, nil)
+				if err != nil {
+					log.Errorf("Error checking proofs to verify: %v", err)
 
-				if errors.Is(err, context.Canceled) {
-					// the context was canceled, just continue, the loop will stop in the <-ctx.Done() case
-					continue
+					if errors.Is(err, context.Canceled) {
+						// the context was canceled, just continue, the loop will stop in the <-ctx.Done() case
+						continue
+					}
 				}
 			}
 
-			proofGenerated, err := a.tryAggregateProofs(ctx, prover)
-			if err != nil {
-				log.Errorf("Error trying to aggregate proofs: %v", err)
+			proofGenerated := false
+			if a.proverRegistry.isBestSuited(tracked.ID(), prover.ProofTypeAggregated, forkId9) {
+				proofGenerated, err = a.tryAggregateProofs(ctx, tracked)
+				if err != nil {
+					log.Errorf("Error trying to aggregate proofs: %v", err)
+				}
 			}
 			if !proofGenerated {
-				proofGenerated, err = a.tryGenerateBatchProof(ctx, prover)
+				proofGenerated, err = a.tryGenerateBatchProof(ctx, tracked)
 				if err != nil {
 					log.Errorf("Error trying to generate proof: %v", err)
 				}
@@ -295,18 +374,39 @@ func (a *Aggregator) sendFinalProof() {
 				if success := a.settleWithAggLayer(ctx, proof, inputs); !success {
 					continue
 				}
+			case Multi:
+				if success := a.settleMulti(ctx, proof, inputs); !success {
+					continue
+				}
 			default:
 				if success := a.settleDirect(ctx, proof, inputs); !success {
 					continue
 				}
 			}
 
+			a.archiveFinalProof(msg, inputs)
 			a.resetVerifyProofTime()
 			a.endProofVerification()
 		}
 	}
 }
 
+// archiveFinalProof keeps the settled final proof in the archive, if enabled. The settlement
+// isn't affected by a failure archiving the proof
+func (a *Aggregator) archiveFinalProof(msg finalProofMsg, inputs ethmanTypes.FinalProofInputs) {
+	if a.archive == nil {
+		return
+	}
+	proof := msg.recursiveProof
+	bundle := archive.NewBundle(proof, msg.proverName, msg.proverID, inputs, time.Now())
+	hash, err := a.archive.Put(bundle)
+	if err != nil {
+		log.Errorf("Failed to archive final proof for batches %d-%d: %v", proof.BatchNumber, proof.BatchNumberFinal, err)
+		return
+	}
+	log.Infof("Final proof for batches %d-%d archived as %s", proof.BatchNumber, proof.BatchNumberFinal, hash)
+}
+
 func (a *Aggregator) settleDirect(
 	ctx context.Context,
 	proof *state.Proof,
@@ -358,17 +458,7 @@ func (a *Aggregator) settleDirect(
 		nil,
 	)
 
-	newLocalExitRoot := hex.EncodeToString(inputs.NewLocalExitRoot)
-	newStateRoot := hex.EncodeToString(inputs.NewStateRoot)
-	proofData := strings.TrimPrefix(inputs.FinalProof.Proof, "0x")
-	message := fmt.Sprintf("%s%s%s", newLocalExitRoot, newStateRoot, proofData)
-
-	log.Debugf("newLocalExitRoot: %s", newLocalExitRoot)
-	log.Debugf("newStateRoot: %s", newStateRoot)
-	log.Debugf("proof data: %s", proofData)
-	log.Debugf("message: %s", message)
-
-	messageBytes, err := hex.DecodeString(message)
+	messageBytes, err := buildInscriptionMessage(inputs)
 	if err != nil {
 		log.Fatalf("Can't decode message %s", err)
 	}
@@ -383,6 +473,7 @@ func (a *Aggregator) settleDirect(
 	if err != nil {
 		log.Fatalf("Can't decode inscription %s", err)
 	}
+	a.btcAnchored()
 
 	return true
 }
@@ -392,18 +483,7 @@ func (a *Aggregator) settleWithAggLayer(
 	proof *state.Proof,
 	inputs ethmanTypes.FinalProofInputs,
 ) (success bool) {
-	proofStrNo0x := strings.TrimPrefix(inputs.FinalProof.Proof, "0x")
-	proofBytes := common.Hex2Bytes(proofStrNo0x)
-	tx := tx.Tx{
-		LastVerifiedBatch: agglayerTypes.ArgUint64(proof.BatchNumber - 1),
-		NewVerifiedBatch:  agglayerTypes.ArgUint64(proof.BatchNumberFinal),
-		ZKP: tx.ZKP{
-			NewStateRoot:     common.BytesToHash(inputs.NewStateRoot),
-			NewLocalExitRoot: common.BytesToHash(inputs.NewLocalExitRoot),
-			Proof:            agglayerTypes.ArgBytes(proofBytes),
-		},
-		RollupID: a.Ethman.GetRollupId(),
-	}
+	tx := newAggLayerTx(a.Ethman.GetRollupId(), proof, inputs)
 	signedTx, err := tx.Sign(a.sequencerPrivateKey)
 
 	if err != nil {
@@ -437,6 +517,65 @@ func (a *Aggregator) settleWithAggLayer(
 	return true
 }
 
+// newAggLayerTx builds the AggLayer tx settling the final proof
+func newAggLayerTx(rollupID uint32, proof *state.Proof, inputs ethmanTypes.FinalProofInputs) tx.Tx {
+	proofStrNo0x := strings.TrimPrefix(inputs.FinalProof.Proof, "0x")
+	proofBytes := common.Hex2Bytes(proofStrNo0x)
+	return tx.Tx{
+		LastVerifiedBatch: agglayerTypes.ArgUint64(proof.BatchNumber - 1),
+		NewVerifiedBatch:  agglayerTypes.ArgUint64(proof.BatchNumberFinal),
+		ZKP: tx.ZKP{
+			NewStateRoot:     common.BytesToHash(inputs.NewStateRoot),
+			NewLocalExitRoot: common.BytesToHash(inputs.NewLocalExitRoot),
+			Proof:            agglayerTypes.ArgBytes(proofBytes),
+		},
+		RollupID: rollupID,
+	}
+}
+
+// buildInscriptionMessage builds the message anchoring the final proof in Bitcoin:
+// the new local exit root, the new state root and the proof
+func buildInscriptionMessage(inputs ethmanTypes.FinalProofInputs) ([]byte, error) {
+	newLocalExitRoot := hex.EncodeToString(inputs.NewLocalExitRoot)
+	newStateRoot := hex.EncodeToString(inputs.NewStateRoot)
+	proofData := strings.TrimPrefix(inputs.FinalProof.Proof, "0x")
+	message := fmt.Sprintf("%s%s%s", newLocalExitRoot, newStateRoot, proofData)
+
+	log.Debugf("newLocalExitRoot: %s", newLocalExitRoot)
+	log.Debugf("newStateRoot: %s", newStateRoot)
+	log.Debugf("proof data: %s", proofData)
+	log.Debugf("message: %s", message)
+
+	return hex.DecodeString(message)
+}
+
+func (a *Aggregator) settleMulti(
+	ctx context.Context,
+	proof *state.Proof,
+	inputs ethmanTypes.FinalProofInputs,
+) (success bool) {
+	if err := a.multiSettler.settle(ctx, proof, inputs); err != nil {
+		log.Errorf("Failed to settle final proof: %v", err)
+		a.handleFailureToSettle(ctx, proof)
+
+		return false
+	}
+
+	return true
+}
+
+func (a *Aggregator) handleFailureToSettle(ctx context.Context, proof *state.Proof) {
+	log := log.WithFields("proofId", proof.ProofID, "batches", fmt.Sprintf("%d-%d", proof.BatchNumber, proof.BatchNumberFinal))
+	proof.GeneratingSince = nil
+
+	err := a.State.UpdateGeneratedProof(ctx, proof, nil)
+	if err != nil {
+		log.Errorf("Failed updating proof state (false): %v", err)
+	}
+
+	a.endProofVerification()
+}
+
 func (a *Aggregator) handleFailureToSendToAggLayer(ctx context.Context, proof *state.Proof) {
 	log := log.WithFields("proofId", proof.ProofID, "batches", fmt.Sprintf("%d-%d", proof.BatchNumber, proof.BatchNumberFinal))
 	proof.GeneratingSince = nil
@@ -475,6 +614,7 @@ func (a *Aggregator) buildFinalProof(ctx context.Context, prover proverInterface
 		return nil, fmt.Errorf("failed to get final proof id: %w", err)
 	}
 	proof.ProofID = finalProofID
+	a.proverRegistry.assignBatches(*proof.ProofID, proof.BatchNumber, proof.BatchNumberFinal)
 
 	log.Infof("Final proof ID for batches [%d-%d]: %s", proof.BatchNumber, proof.BatchNumberFinal, *proof.ProofID)
 	log = log.WithFields("finalProofId", finalProofID)
@@ -518,7 +658,10 @@ func (a *Aggregator) tryBuildFinalProof(ctx context.Context, prover proverInterf
 	)
 	log.Debug("tryBuildFinalProof start")
 
-	var err error
+	var (
+		err    error
+		settle bool
+	)
 	if !a.canVerifyProof() {
 		log.Debug("Time to verify proof not reached or proof verification in progress")
 		return false, nil
@@ -554,7 +697,7 @@ func (a *Aggregator) tryBuildFinalProof(ctx context.Context, prover proverInterf
 		}
 
 		defer func() {
-			if err != nil {
+			if err != nil || !settle {
 				// Set the generating state to false for the proof ("unlock" it)
 				proof.GeneratingSince = nil
 				err2 := a.State.UpdateGeneratedProof(a.ctx, proof, nil)
@@ -575,6 +718,15 @@ func (a *Aggregator) tryBuildFinalProof(ctx context.Context, prover proverInterf
 		}
 	}
 
+	settle, err = a.isSettlementProfitable(ctx, proof)
+	if err != nil {
+		return false, err
+	}
+	if !settle {
+		log.Debug("Waiting to aggregate more batches before settling")
+		return false, nil
+	}
+
 	log = log.WithFields(
 		"proofId", *proof.ProofID,
 		"batches", fmt.Sprintf("%d-%d", proof.BatchNumber, proof.BatchNumberFinal),
@@ -605,6 +757,18 @@ func (a *Aggregator) tryBuildFinalProof(ctx context.Context, prover proverInterf
 	return true, nil
 }
 
+// isSettlementProfitable returns true if the proof is worth settling instead of aggregating more batches into it
+func (a *Aggregator) isSettlementProfitable(ctx context.Context, proof *state.Proof) (bool, error) {
+	if a.settlementChecker == nil {
+		return true, nil
+	}
+	profitable, err := a.settlementChecker.IsSettlementProfitable(ctx, proof)
+	if err != nil {
+		return false, fmt.Errorf("failed to check settlement profitability, %w", err)
+	}
+	return profitable, nil
+}
+
 func (a *Aggregator) validateEligibleFinalProof(ctx context.Context, proof *state.Proof, lastVerifiedBatchNum uint64) (bool, error) {
 	batchNumberToVerify := lastVerifiedBatchNum + 1
 
@@ -805,6 +969,7 @@ func (a *Aggregator) tryAggregateProofs(ctx context.Context, prover proverInterf
 	}
 
 	proof.ProofID = aggrProofID
+	a.proverRegistry.assignBatches(*proof.ProofID, proof.BatchNumber, proof.BatchNumberFinal)
 
 	log.Infof("Proof ID for aggregated proof: %v", *proof.ProofID)
 	log = log.WithFields("proofId", *proof.ProofID)
@@ -1031,6 +1196,7 @@ func (a *Aggregator) tryGenerateBatchProof(ctx context.Context, prover proverInt
 	}
 
 	proof.ProofID = genProofID
+	a.proverRegistry.assignBatches(*proof.ProofID, proof.BatchNumber, proof.BatchNumberFinal)
 
 	log.Infof("Proof ID %v", *proof.ProofID)
 	log = log.WithFields("proofId", *proof.ProofID)
@@ -1072,12 +1238,27 @@ func (a *Aggregator) tryGenerateBatchProof(ctx context.Context, prover proverInt
 	return true, nil
 }
 
-// canVerifyProof returns true if we have reached the timeout to verify a proof
-// and no other prover is verifying a proof (verifyingProof = false).
+// canVerifyProof returns true if we have reached the timeout to verify a proof,
+// no other prover is verifying a proof (verifyingProof = false) and the
+// submission of final proofs isn't paused.
 func (a *Aggregator) canVerifyProof() bool {
 	a.TimeSendFinalProofMutex.RLock()
 	defer a.TimeSendFinalProofMutex.RUnlock()
-	return a.TimeSendFinalProof.Before(time.Now()) && !a.verifyingProof
+	return a.TimeSendFinalProof.Before(time.Now()) && !a.verifyingProof && !a.finalProofPaused
+}
+
+// setFinalProofPaused pauses or resumes the submission of final proofs
+func (a *Aggregator) setFinalProofPaused(paused bool) {
+	a.TimeSendFinalProofMutex.Lock()
+	defer a.TimeSendFinalProofMutex.Unlock()
+	a.finalProofPaused = paused
+}
+
+// isFinalProofPaused returns true if the submission of final proofs is paused
+func (a *Aggregator) isFinalProofPaused() bool {
+	a.TimeSendFinalProofMutex.RLock()
+	defer a.TimeSendFinalProofMutex.RUnlock()
+	return a.finalProofPaused
 }
 
 // startProofVerification sets to true the verifyingProof variable to indicate that there is a proof verification in progress
@@ -1385,6 +1566,64 @@ func (a *Aggregator) cleanupLockedProofs() {
 	}
 }
 
+// btcAnchored records that a final proof has been inscribed in Bitcoin
+func (a *Aggregator) btcAnchored() {
+	a.TimeSendFinalProofMutex.Lock()
+	defer a.TimeSendFinalProofMutex.Unlock()
+	a.lastBtcAnchor = time.Now()
+}
+
+// updateQueueMetrics periodically updates the metrics of the batches and proofs
+// waiting in the proving pipeline and the time since they were last settled
+func (a *Aggregator) updateQueueMetrics() {
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(queueMetricsInterval):
+			if err := a.setQueueMetrics(a.ctx); err != nil {
+				log.Warnf("Failed to update proving queue metrics: %v", err)
+			}
+		}
+	}
+}
+
+func (a *Aggregator) setQueueMetrics(ctx context.Context) error {
+	a.TimeSendFinalProofMutex.RLock()
+	lastBtcAnchor := a.lastBtcAnchor
+	a.TimeSendFinalProofMutex.RUnlock()
+	if !lastBtcAnchor.IsZero() {
+		metrics.TimeSinceLastBtcAnchor(time.Since(lastBtcAnchor))
+	}
+
+	proofs, err := a.State.CountProofsToAggregate(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to count proofs to aggregate, %w", err)
+	}
+	metrics.ProofsToAggregate(proofs)
+
+	var lastVerifiedBatchNum uint64
+	lastVerifiedBatch, err := a.State.GetLastVerifiedBatch(ctx, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return fmt.Errorf("failed to get last verified batch, %w", err)
+	}
+	if lastVerifiedBatch != nil {
+		lastVerifiedBatchNum = lastVerifiedBatch.BatchNumber
+		block, err := a.State.GetBlockByNumber(ctx, lastVerifiedBatch.BlockNumber, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get block %d of the last verified batch, %w", lastVerifiedBatch.BlockNumber, err)
+		}
+		metrics.TimeSinceLastL1Verification(time.Since(block.ReceivedAt))
+	}
+
+	batches, err := a.State.CountBatchesToProve(ctx, lastVerifiedBatchNum, nil)
+	if err != nil {
+		return fmt.Errorf("failed to count batches to prove, %w", err)
+	}
+	metrics.BatchesToProve(batches)
+	return nil
+}
+
 // FirstToUpper returns the string passed as argument with the first letter in
 // uppercase.
 func FirstToUpper(s string) string {
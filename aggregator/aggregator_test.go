@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/archive"
 	"github.com/0xPolygonHermez/zkevm-node/aggregator/mocks"
 	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
 	configTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
@@ -193,7 +194,7 @@ func TestSendFinalProof(t *testing.T) {
 			ethTxManager := mocks.NewEthTxManager(t)
 			etherman := mocks.NewEtherman(t)
 			btcman := mocks.NewBtcman(t)
-			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil)
+			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil, nil)
 			require.NoError(err)
 			a.ctx, a.exit = context.WithCancel(context.Background())
 			m := mox{
@@ -689,7 +690,7 @@ func TestTryAggregateProofs(t *testing.T) {
 			etherman := mocks.NewEtherman(t)
 			btcman := mocks.NewBtcman(t)
 			proverMock := mocks.NewProverMock(t)
-			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil)
+			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil, nil)
 			require.NoError(err)
 			aggregatorCtx := context.WithValue(context.Background(), "owner", "aggregator") //nolint:staticcheck
 			a.ctx, a.exit = context.WithCancel(aggregatorCtx)
@@ -1033,7 +1034,7 @@ func TestTryGenerateBatchProof(t *testing.T) {
 			etherman := mocks.NewEtherman(t)
 			btcman := mocks.NewBtcman(t)
 			proverMock := mocks.NewProverMock(t)
-			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil)
+			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil, nil)
 			require.NoError(err)
 			aggregatorCtx := context.WithValue(context.Background(), "owner", "aggregator") //nolint:staticcheck
 			a.ctx, a.exit = context.WithCancel(aggregatorCtx)
@@ -1311,7 +1312,7 @@ func TestTryBuildFinalProof(t *testing.T) {
 			etherman := mocks.NewEtherman(t)
 			btcman := mocks.NewBtcman(t)
 			proverMock := mocks.NewProverMock(t)
-			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil)
+			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil, nil)
 			require.NoError(err)
 			aggregatorCtx := context.WithValue(context.Background(), "owner", "aggregator") //nolint:staticcheck
 			a.ctx, a.exit = context.WithCancel(aggregatorCtx)
@@ -1442,7 +1443,7 @@ func TestIsSynced(t *testing.T) {
 			etherman := mocks.NewEtherman(t)
 			btcman := mocks.NewBtcman(t)
 			proverMock := mocks.NewProverMock(t)
-			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil)
+			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil, nil)
 			require.NoError(err)
 			aggregatorCtx := context.WithValue(context.Background(), "owner", "aggregator") //nolint:staticcheck
 			a.ctx, a.exit = context.WithCancel(aggregatorCtx)
@@ -1510,7 +1511,7 @@ func TestWaitForSynchronizerToSyncUp(t *testing.T) {
 			etherman := mocks.NewEtherman(t)
 			btcman := mocks.NewBtcman(t)
 			proverMock := mocks.NewProverMock(t)
-			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil)
+			a, err := New(cfg, stateMock, ethTxManager, etherman, btcman, nil, nil, nil)
 			require.NoError(t, err)
 			aggregatorCtx := context.WithValue(context.Background(), "owner", "aggregator") //nolint:staticcheck
 			a.ctx, a.exit = context.WithCancel(aggregatorCtx)
@@ -1533,3 +1534,52 @@ func TestWaitForSynchronizerToSyncUp(t *testing.T) {
 		})
 	}
 }
+
+func TestArchiveFinalProof(t *testing.T) {
+	proofArchive, err := archive.New(archive.Config{Backend: archive.LocalBackend, Path: t.TempDir()})
+	require.NoError(t, err)
+	a := Aggregator{archive: proofArchive}
+
+	proofID := "finalProofId"
+	msg := finalProofMsg{
+		proverName:     "prover",
+		proverID:       "proverId",
+		recursiveProof: &state.Proof{BatchNumber: 2, BatchNumberFinal: 5, ProofID: &proofID},
+	}
+	inputs := ethmanTypes.FinalProofInputs{
+		FinalProof:   &prover.FinalProof{Proof: "0x1234"},
+		NewStateRoot: common.HexToHash("0x3").Bytes(),
+	}
+	a.archiveFinalProof(msg, inputs)
+
+	_, data, err := proofArchive.GetByBatch(4)
+	require.NoError(t, err)
+	var bundle archive.Bundle
+	require.NoError(t, json.Unmarshal(data, &bundle))
+	assert.Equal(t, proofID, bundle.ProofID)
+	assert.Equal(t, "proverId", bundle.ProverID)
+	assert.Equal(t, "0x1234", bundle.Proof)
+	assert.Equal(t, common.HexToHash("0x3"), bundle.NewStateRoot)
+}
+
+func TestSetQueueMetrics(t *testing.T) {
+	ctx := context.Background()
+	stateMock := mocks.NewStateMock(t)
+	a := Aggregator{State: stateMock, TimeSendFinalProofMutex: &sync.RWMutex{}}
+	a.btcAnchored()
+
+	stateMock.On("CountProofsToAggregate", ctx, nil).Return(uint64(2), nil).Once()
+	stateMock.On("GetLastVerifiedBatch", ctx, nil).Return(&state.VerifiedBatch{BatchNumber: 10, BlockNumber: 100}, nil).Once()
+	stateMock.On("GetBlockByNumber", ctx, uint64(100), nil).Return(&state.Block{ReceivedAt: time.Now()}, nil).Once()
+	stateMock.On("CountBatchesToProve", ctx, uint64(10), nil).Return(uint64(5), nil).Once()
+	require.NoError(t, a.setQueueMetrics(ctx))
+
+	// nothing verified yet
+	stateMock.On("CountProofsToAggregate", ctx, nil).Return(uint64(0), nil).Once()
+	stateMock.On("GetLastVerifiedBatch", ctx, nil).Return(nil, state.ErrNotFound).Once()
+	stateMock.On("CountBatchesToProve", ctx, uint64(0), nil).Return(uint64(3), nil).Once()
+	require.NoError(t, a.setQueueMetrics(ctx))
+
+	stateMock.On("CountProofsToAggregate", ctx, nil).Return(uint64(0), errors.New("banana")).Once()
+	assert.ErrorContains(t, a.setQueueMetrics(ctx), "banana")
+}
@@ -0,0 +1,87 @@
+package aggregator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const (
+	// ProversEndpoint lists the provers of the registry, or returns one of them if its id is appended to the path
+	ProversEndpoint = "/provers"
+
+	apiReadTimeout = 10 * time.Second
+)
+
+// newAPIHandler returns the handler of the aggregator HTTP API
+func (a *Aggregator) newAPIHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ProversEndpoint, a.handleProvers)
+	mux.HandleFunc(ProversEndpoint+"/", a.handleProvers)
+	a.registerAdminHandlers(mux)
+	return mux
+}
+
+// startAPI serves the aggregator HTTP API
+func (a *Aggregator) startAPI() {
+	address := fmt.Sprintf("%s:%d", a.cfg.API.Host, a.cfg.API.Port)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Errorf("Failed to create tcp listener for the aggregator API: %v", err)
+		return
+	}
+
+	a.apiSrv = &http.Server{
+		Handler:           a.newAPIHandler(),
+		ReadHeaderTimeout: apiReadTimeout,
+		ReadTimeout:       apiReadTimeout,
+	}
+	log.Infof("Aggregator API listening on port %d", a.cfg.API.Port)
+	if err := a.apiSrv.Serve(lis); err != nil {
+		if err == http.ErrServerClosed {
+			log.Warn("Aggregator API stopped")
+			return
+		}
+		log.Errorf("Closed http connection for the aggregator API: %v", err)
+	}
+}
+
+func (a *Aggregator) handleProvers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, ProversEndpoint), "/")
+	if id == "" {
+		writeAPIResponse(w, a.proverRegistry.list())
+		return
+	}
+
+	p, found := a.proverRegistry.get(id)
+	if !found {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("prover %s not found", id))
+		return
+	}
+	writeAPIResponse(w, p)
+}
+
+func writeAPIResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("Failed to write aggregator API response: %v", err)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]string{"error": msg}); err != nil {
+		log.Errorf("Failed to write aggregator API response: %v", err)
+	}
+}
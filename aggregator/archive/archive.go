@@ -0,0 +1,236 @@
+// Package archive keeps the final proofs settled by the aggregator, together with the inputs
+// needed to verify them again, in a content-addressed store. The proofs are removed from the
+// state once settled, the archive allows to re-verify or republish them later.
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	ethmanTypes "github.com/0xPolygonHermez/zkevm-node/etherman/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// BundleVersion is the version of the format of the bundles
+	BundleVersion = 1
+
+	proofsPrefix  = "proofs/"
+	batchesPrefix = "batches/"
+)
+
+// ErrNotFound is returned when there isn't an archived proof for a batch
+var ErrNotFound = errors.New("proof not found in the archive")
+
+// Backend is the type of store of the archive
+type Backend string
+
+const (
+	// LocalBackend stores the proofs in a local directory
+	LocalBackend Backend = "local"
+)
+
+// Config is the configuration of the proof archive
+type Config struct {
+	// Enabled archives the final proofs once settled
+	Enabled bool `mapstructure:"Enabled"`
+	// Backend is the type of store of the archive, only local is supported
+	Backend Backend `mapstructure:"Backend"`
+	// Path is the directory of the local store
+	Path string `mapstructure:"Path"`
+}
+
+// Store is an object store, where the archive keeps the bundles under their content hash and an
+// index of the batches settled by them
+type Store interface {
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+	// List returns the keys starting by the prefix
+	List(prefix string) ([]string, error)
+}
+
+// Bundle is a settled final proof with the inputs needed to verify it again, so it can be
+// checked by third parties without access to the node
+type Bundle struct {
+	Version          uint64         `json:"version"`
+	BatchNumber      uint64         `json:"batchNumber"`
+	BatchNumberFinal uint64         `json:"batchNumberFinal"`
+	ProofID          string         `json:"proofId"`
+	Prover           string         `json:"prover"`
+	ProverID         string         `json:"proverId"`
+	Proof            string         `json:"proof"`
+	ChainID          uint64         `json:"chainId"`
+	ForkID           uint64         `json:"forkId"`
+	AggregatorAddr   common.Address `json:"aggregatorAddr"`
+	OldBatchNumber   uint64         `json:"oldBatchNumber"`
+	OldStateRoot     common.Hash    `json:"oldStateRoot"`
+	OldAccInputHash  common.Hash    `json:"oldAccInputHash"`
+	NewStateRoot     common.Hash    `json:"newStateRoot"`
+	NewAccInputHash  common.Hash    `json:"newAccInputHash"`
+	NewLocalExitRoot common.Hash    `json:"newLocalExitRoot"`
+	SettledAt        time.Time      `json:"settledAt"`
+}
+
+// NewBundle builds the bundle of a settled final proof
+func NewBundle(proof *state.Proof, proverName, proverID string, inputs ethmanTypes.FinalProofInputs, settledAt time.Time) Bundle {
+	bundle := Bundle{
+		Version:          BundleVersion,
+		BatchNumber:      proof.BatchNumber,
+		BatchNumberFinal: proof.BatchNumberFinal,
+		Prover:           proverName,
+		ProverID:         proverID,
+		NewStateRoot:     common.BytesToHash(inputs.NewStateRoot),
+		NewLocalExitRoot: common.BytesToHash(inputs.NewLocalExitRoot),
+		SettledAt:        settledAt.UTC(),
+	}
+	if proof.ProofID != nil {
+		bundle.ProofID = *proof.ProofID
+	}
+	if inputs.FinalProof == nil {
+		return bundle
+	}
+
+	bundle.Proof = inputs.FinalProof.Proof
+	if public := inputs.FinalProof.Public; public != nil {
+		bundle.NewAccInputHash = common.BytesToHash(public.NewAccInputHash)
+		setPublicInputs(&bundle, public.PublicInputs)
+	}
+	return bundle
+}
+
+func setPublicInputs(bundle *Bundle, inputs *prover.PublicInputs) {
+	if inputs == nil {
+		return
+	}
+	bundle.ChainID = inputs.ChainId
+	bundle.ForkID = inputs.ForkId
+	bundle.AggregatorAddr = common.HexToAddress(inputs.AggregatorAddr)
+	bundle.OldBatchNumber = inputs.OldBatchNum
+	bundle.OldStateRoot = common.BytesToHash(inputs.OldStateRoot)
+	bundle.OldAccInputHash = common.BytesToHash(inputs.OldAccInputHash)
+}
+
+// Archive keeps the settled final proofs
+type Archive struct {
+	store Store
+}
+
+// New creates the archive for the configured store
+func New(cfg Config) (*Archive, error) {
+	switch cfg.Backend {
+	case LocalBackend:
+		store, err := NewLocalStore(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		return NewWithStore(store), nil
+	default:
+		return nil, fmt.Errorf("unsupported proof archive backend %q", cfg.Backend)
+	}
+}
+
+// NewWithStore creates an archive keeping the proofs in the store
+func NewWithStore(store Store) *Archive {
+	return &Archive{store: store}
+}
+
+// Put archives the bundle and returns its content hash
+func (a *Archive) Put(bundle Bundle) (common.Hash, error) {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode proof bundle: %w", err)
+	}
+	hash := crypto.Keccak256Hash(data)
+
+	if err := a.store.Put(proofKey(hash), data); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to store proof bundle: %w", err)
+	}
+	// the index is written last, so it always points to a stored bundle
+	if err := a.store.Put(batchesKey(bundle.BatchNumber, bundle.BatchNumberFinal), []byte(hash.Hex())); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to index proof bundle: %w", err)
+	}
+	return hash, nil
+}
+
+// Get returns the encoded bundle with the content hash, checking its integrity
+func (a *Archive) Get(hash common.Hash) ([]byte, error) {
+	data, err := a.store.Get(proofKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	if crypto.Keccak256Hash(data) != hash {
+		return nil, fmt.Errorf("proof bundle %s is corrupted", hash)
+	}
+	return data, nil
+}
+
+// GetByBatch returns the content hash and the encoded bundle of the last settled proof containing the batch
+func (a *Archive) GetByBatch(batchNumber uint64) (common.Hash, []byte, error) {
+	keys, err := a.store.List(batchesPrefix)
+	if err != nil {
+		return common.Hash{}, nil, fmt.Errorf("failed to list archived proofs: %w", err)
+	}
+	// the ranges are sorted so the last settled proof wins when a range has been proven again
+	sort.Strings(keys)
+
+	var (
+		found bool
+		hash  common.Hash
+		data  []byte
+		last  time.Time
+	)
+	for _, key := range keys {
+		from, to, err := parseBatchesKey(key)
+		if err != nil {
+			return common.Hash{}, nil, err
+		}
+		if batchNumber < from || batchNumber > to {
+			continue
+		}
+
+		ref, err := a.store.Get(key)
+		if err != nil {
+			return common.Hash{}, nil, err
+		}
+		candidate := common.HexToHash(string(ref))
+		candidateData, err := a.Get(candidate)
+		if err != nil {
+			return common.Hash{}, nil, err
+		}
+		var bundle Bundle
+		if err := json.Unmarshal(candidateData, &bundle); err != nil {
+			return common.Hash{}, nil, fmt.Errorf("failed to decode proof bundle %s: %w", candidate, err)
+		}
+		if !found || !bundle.SettledAt.Before(last) {
+			found, hash, data, last = true, candidate, candidateData, bundle.SettledAt
+		}
+	}
+	if !found {
+		return common.Hash{}, nil, ErrNotFound
+	}
+	return hash, data, nil
+}
+
+func proofKey(hash common.Hash) string {
+	return proofsPrefix + strings.TrimPrefix(hash.Hex(), "0x") + ".json"
+}
+
+// batchesKey is padded so the keys sort as the batch numbers
+func batchesKey(batchNumber, batchNumberFinal uint64) string {
+	return fmt.Sprintf("%s%020d-%020d", batchesPrefix, batchNumber, batchNumberFinal)
+}
+
+func parseBatchesKey(key string) (uint64, uint64, error) {
+	var from, to uint64
+	if _, err := fmt.Sscanf(strings.TrimPrefix(key, batchesPrefix), "%d-%d", &from, &to); err != nil {
+		return 0, 0, fmt.Errorf("invalid archive index key %s: %w", key, err)
+	}
+	return from, to, nil
+}
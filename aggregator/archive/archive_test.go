@@ -0,0 +1,119 @@
+package archive
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	ethmanTypes "github.com/0xPolygonHermez/zkevm-node/etherman/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBundle(from, to uint64, settledAt time.Time) Bundle {
+	proofID := "finalProofId"
+	inputs := ethmanTypes.FinalProofInputs{
+		FinalProof: &prover.FinalProof{
+			Proof: "0x1234",
+			Public: &prover.PublicInputsExtended{
+				NewAccInputHash: common.HexToHash("0x5").Bytes(),
+				PublicInputs: &prover.PublicInputs{
+					OldStateRoot:    common.HexToHash("0x6").Bytes(),
+					OldAccInputHash: common.HexToHash("0x7").Bytes(),
+					OldBatchNum:     from - 1,
+					ChainId:         1001,
+					ForkId:          9,
+					AggregatorAddr:  "0x0000000000000000000000000000000000000008",
+				},
+			},
+		},
+		NewStateRoot:     common.HexToHash("0x3").Bytes(),
+		NewLocalExitRoot: common.HexToHash("0x4").Bytes(),
+	}
+	return NewBundle(&state.Proof{BatchNumber: from, BatchNumberFinal: to, ProofID: &proofID}, "prover", "proverId", inputs, settledAt)
+}
+
+func TestNewBundle(t *testing.T) {
+	settledAt := time.Unix(1000, 0)
+	assert.Equal(t, Bundle{
+		Version:          BundleVersion,
+		BatchNumber:      2,
+		BatchNumberFinal: 5,
+		ProofID:          "finalProofId",
+		Prover:           "prover",
+		ProverID:         "proverId",
+		Proof:            "0x1234",
+		ChainID:          1001,
+		ForkID:           9,
+		AggregatorAddr:   common.HexToAddress("0x8"),
+		OldBatchNumber:   1,
+		OldStateRoot:     common.HexToHash("0x6"),
+		OldAccInputHash:  common.HexToHash("0x7"),
+		NewStateRoot:     common.HexToHash("0x3"),
+		NewAccInputHash:  common.HexToHash("0x5"),
+		NewLocalExitRoot: common.HexToHash("0x4"),
+		SettledAt:        settledAt.UTC(),
+	}, newBundle(2, 5, settledAt))
+
+	// the proof inputs are optional
+	bundle := NewBundle(&state.Proof{BatchNumber: 2, BatchNumberFinal: 5}, "prover", "proverId", ethmanTypes.FinalProofInputs{}, settledAt)
+	assert.Empty(t, bundle.Proof)
+	assert.Empty(t, bundle.ProofID)
+}
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	a, err := New(Config{Backend: LocalBackend, Path: dir})
+	require.NoError(t, err)
+
+	_, _, err = a.GetByBatch(3)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	settledAt := time.Unix(1000, 0)
+	hash, err := a.Put(newBundle(2, 5, settledAt))
+	require.NoError(t, err)
+	_, err = a.Put(newBundle(6, 8, settledAt))
+	require.NoError(t, err)
+
+	// the bundles are content addressed
+	found, data, err := a.GetByBatch(3)
+	require.NoError(t, err)
+	assert.Equal(t, hash, found)
+	assert.Equal(t, hash, crypto.Keccak256Hash(data))
+	var bundle Bundle
+	require.NoError(t, json.Unmarshal(data, &bundle))
+	assert.Equal(t, newBundle(2, 5, settledAt), bundle)
+
+	_, _, err = a.GetByBatch(9)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// the last settled proof is returned when the batch has been proven again
+	reproved, err := a.Put(newBundle(3, 4, settledAt.Add(time.Hour)))
+	require.NoError(t, err)
+	found, _, err = a.GetByBatch(3)
+	require.NoError(t, err)
+	assert.Equal(t, reproved, found)
+	found, _, err = a.GetByBatch(2)
+	require.NoError(t, err)
+	assert.Equal(t, hash, found)
+
+	// a modified bundle is detected
+	file := filepath.Join(dir, filepath.FromSlash(proofKey(hash)))
+	require.NoError(t, os.WriteFile(file, []byte("{}"), 0600))
+	_, err = a.Get(hash)
+	assert.ErrorContains(t, err, "corrupted")
+}
+
+func TestNewArchive(t *testing.T) {
+	_, err := New(Config{Backend: "s3", Path: t.TempDir()})
+	assert.ErrorContains(t, err, "unsupported")
+
+	_, err = New(Config{Backend: LocalBackend})
+	assert.ErrorContains(t, err, "empty")
+}
@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore is a store keeping the objects as files of a local directory
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates a store in the directory, creating it if needed
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if dir == "" {
+		return nil, errors.New("the path of the proof archive is empty")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create proof archive directory: %w", err)
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+// Put writes the object, replacing it atomically if it already exists
+func (s *LocalStore) Put(key string, data []byte) error {
+	file := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// Get reads the object, returning ErrNotFound if it doesn't exist
+func (s *LocalStore) Get(key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// List returns the keys of the objects of the directory of the prefix starting by it
+func (s *LocalStore) List(prefix string) ([]string, error) {
+	dir, base := path.Split(prefix)
+	entries, err := os.ReadDir(s.path(dir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, base) || strings.HasPrefix(name, ".") {
+			continue
+		}
+		keys = append(keys, dir+name)
+	}
+	return keys, nil
+}
+
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
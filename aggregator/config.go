@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/archive"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/encoding"
 )
@@ -17,6 +18,23 @@ const (
 
 	// L1 settlement backend
 	L1 SettlementBackend = "l1"
+
+	// Multi settlement backend, sending the final proof in parallel to the sinks of MultiSettlement
+	Multi SettlementBackend = "multi"
+)
+
+// SettlementSink is a destination of the final proofs in the multi settlement backend
+type SettlementSink string
+
+const (
+	// SinkL1 sends the final proof to the rollup contract on L1
+	SinkL1 SettlementSink = "l1"
+	// SinkAggLayer sends the final proof to the AggLayer
+	SinkAggLayer SettlementSink = "agglayer"
+	// SinkBTC anchors the final proof in a Bitcoin inscription
+	SinkBTC SettlementSink = "btc"
+	// SinkWebhook posts the final proof to an HTTP endpoint
+	SinkWebhook SettlementSink = "webhook"
 )
 
 // TokenAmountWithDecimals is a wrapper type that parses token amount with decimals to big int
@@ -57,14 +75,15 @@ type Config struct {
 	ProofStatePollingInterval types.Duration `mapstructure:"ProofStatePollingInterval"`
 
 	// TxProfitabilityCheckerType type for checking is it profitable for aggregator to validate batch
-	// possible values: base/acceptall
+	// possible values: base/acceptall/cost
 	TxProfitabilityCheckerType TxProfitabilityCheckerType `mapstructure:"TxProfitabilityCheckerType"`
 
 	// TxProfitabilityMinReward min reward for base tx profitability checker when aggregator will validate batch
 	// this parameter is used for the base tx profitability checker
 	TxProfitabilityMinReward TokenAmountWithDecimals `mapstructure:"TxProfitabilityMinReward"`
 
-	// IntervalAfterWhichBatchConsolidateAnyway this is interval for the main sequencer, that will check if there is no transactions
+	// IntervalAfterWhichBatchConsolidateAnyway this is interval for the main sequencer, that will check if there is no transactions.
+	// The cost tx profitability checker settles a proof anyway once its first batch is older than this interval
 	IntervalAfterWhichBatchConsolidateAnyway types.Duration `mapstructure:"IntervalAfterWhichBatchConsolidateAnyway"`
 
 	// ChainID is the L2 ChainID provided by the Network Config
@@ -114,4 +133,88 @@ type Config struct {
 
 	// BatchProofL1BlockConfirmations is number of L1 blocks to consider we can generate the proof for a virtual batch
 	BatchProofL1BlockConfirmations uint64 `mapstructure:"BatchProofL1BlockConfirmations"`
+
+	// API is the configuration of the HTTP API exposing the status of the aggregator
+	API APIConfig `mapstructure:"API"`
+
+	// MultiSettlement is the configuration of the multi settlement backend
+	MultiSettlement MultiSettlementConfig `mapstructure:"MultiSettlement"`
+
+	// CostProfitability is the configuration of the cost tx profitability checker
+	CostProfitability CostProfitabilityConfig `mapstructure:"CostProfitability"`
+
+	// Archive is the configuration of the archive of the settled final proofs
+	Archive archive.Config `mapstructure:"Archive"`
+}
+
+// CostProfitabilityConfig represents the configuration of the cost tx profitability checker, which holds
+// the final proofs back, aggregating more batches, until the cost of settling them is low enough per batch
+type CostProfitabilityConfig struct {
+	// VerifyBatchesGas is the gas used by verifyBatchesTrustedAggregator, without the GasOffset
+	VerifyBatchesGas uint64 `mapstructure:"VerifyBatchesGas"`
+	// MaxL1CostPerBatch is the max cost in ETH of the L1 settlement tx divided by the batches of the proof,
+	// the L1 cost isn't checked if zero or if the proofs aren't verified in L1 by the aggregator
+	MaxL1CostPerBatch TokenAmountWithDecimals `mapstructure:"MaxL1CostPerBatch"`
+	// MaxBtcCostPerBatch is the max fee in satoshis of the Bitcoin inscription divided by the batches of the proof,
+	// the Bitcoin fee isn't checked if zero or if the proofs aren't inscribed in Bitcoin
+	MaxBtcCostPerBatch uint64 `mapstructure:"MaxBtcCostPerBatch"`
+	// FallbackBtcFeeRate is the fee rate in satoshis per vbyte of the Bitcoin inscription fee when the Bitcoin node
+	// can't estimate it. If zero, the proofs aren't settled while the fee can't be estimated
+	FallbackBtcFeeRate uint64 `mapstructure:"FallbackBtcFeeRate"`
+}
+
+// MultiSettlementConfig represents the configuration of the multi settlement backend
+type MultiSettlementConfig struct {
+	// Sinks are the destinations the final proofs are sent to in parallel: l1, agglayer, btc and webhook.
+	// The l1 and agglayer sinks can't be used together, as both verify the batches on L1
+	Sinks []SettlementSink `mapstructure:"Sinks"`
+	// Required are the sinks that must settle a final proof for it to count as settled. All the sinks are
+	// required if empty. The rest of the sinks keep retrying in the background without holding the next proof.
+	// The l1 or the agglayer sink must be required, as the batches are verified through them
+	Required []SettlementSink `mapstructure:"Required"`
+	// MaxRetries is the number of times the settlement in a sink is retried after failing
+	MaxRetries uint64 `mapstructure:"MaxRetries"`
+	// RetryInterval is the time to wait before retrying the settlement in a sink
+	RetryInterval types.Duration `mapstructure:"RetryInterval"`
+	// WebhookURL is the endpoint the final proofs are posted to by the webhook sink
+	WebhookURL string `mapstructure:"WebhookURL"`
+	// WebhookTimeout is the timeout of the requests of the webhook sink
+	WebhookTimeout types.Duration `mapstructure:"WebhookTimeout"`
+}
+
+// APIConfig represents the configuration of the aggregator HTTP API
+type APIConfig struct {
+	// Enabled starts the HTTP API
+	Enabled bool `mapstructure:"Enabled"`
+	// Host for the HTTP API
+	Host string `mapstructure:"Host"`
+	// Port for the HTTP API
+	Port int `mapstructure:"Port"`
+	// AdminToken is the bearer token required by the admin endpoints, which are disabled if it's empty
+	AdminToken string `mapstructure:"AdminToken"`
+}
+
+// settlesIn returns true if the final proofs are sent to the sink, either by the settlement backend
+// or as one of the sinks of the multi settlement backend
+func (c Config) settlesIn(sink SettlementSink) bool {
+	switch c.SettlementBackend {
+	case AggLayer:
+		return sink == SinkAggLayer
+	case Multi:
+		for _, s := range c.MultiSettlement.Sinks {
+			if s == sink {
+				return true
+			}
+		}
+		return false
+	default:
+		// the direct settlement verifies the batches in L1 and inscribes the proof in Bitcoin
+		return sink == SinkL1 || sink == SinkBTC
+	}
+}
+
+// UsesAggLayer returns true if the final proofs are sent to the AggLayer, either as the
+// settlement backend or as one of the sinks of the multi settlement backend
+func (c Config) UsesAggLayer() bool {
+	return c.settlesIn(SinkAggLayer)
 }
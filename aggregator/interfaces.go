@@ -25,6 +25,7 @@ type proverInterface interface {
 	FinalProof(inputProof string, aggregatorAddr string) (*string, error)
 	WaitRecursiveProof(ctx context.Context, proofID string) (string, error)
 	WaitFinalProof(ctx context.Context, proofID string) (*prover.FinalProof, error)
+	CancelProofRequest(proofID string) error
 }
 
 // ethTxManager contains the methods required to send txs to
@@ -34,6 +35,7 @@ type ethTxManager interface {
 	Result(ctx context.Context, owner, id string, dbTx pgx.Tx) (ethtxmanager.MonitoredTxResult, error)
 	ResultsByStatus(ctx context.Context, owner string, statuses []ethtxmanager.MonitoredTxStatus, dbTx pgx.Tx) ([]ethtxmanager.MonitoredTxResult, error)
 	ProcessPendingMonitoredTxs(ctx context.Context, owner string, failedResultHandler ethtxmanager.ResultHandler, dbTx pgx.Tx)
+	SetStatusDone(ctx context.Context, owner, id string, dbTx pgx.Tx) error
 }
 
 // etherman contains the methods required to interact with ethereum
@@ -42,12 +44,14 @@ type etherman interface {
 	GetLatestVerifiedBatchNum() (uint64, error)
 	BuildTrustedVerifyBatchesTxData(lastVerifiedBatch, newVerifiedBatch uint64, inputs *ethmanTypes.FinalProofInputs, beneficiary common.Address) (to *common.Address, data []byte, err error)
 	GetLatestBlockHeader(ctx context.Context) (*types.Header, error)
+	SuggestedGasPrice(ctx context.Context) (*big.Int, error)
 }
 
 // btcman contains the methods required to interact with bitcoin
 type btcman interface {
 	Inscribe(data []byte) (string, error)
 	DecodeInscription(txHash string) error
+	EstimateInscriptionFee(dataSize int) (int64, error)
 	Shutdown()
 }
 
@@ -57,6 +61,19 @@ type aggregatorTxProfitabilityChecker interface {
 	IsProfitable(context.Context, *big.Int) (bool, error)
 }
 
+// settlementProfitabilityChecker decides if it is worth settling a proof or
+// if it is better to aggregate more batches into it.
+type settlementProfitabilityChecker interface {
+	IsSettlementProfitable(context.Context, *state.Proof) (bool, error)
+}
+
+// proverStorage gathers the methods to persist the prover registry.
+type proverStorage interface {
+	AddOrUpdateProver(ctx context.Context, p prover.Info, dbTx pgx.Tx) error
+	UpdateProverStats(ctx context.Context, proverID string, proofType prover.ProofType, stats prover.ProofStats, dbTx pgx.Tx) error
+	GetProvers(ctx context.Context, dbTx pgx.Tx) ([]prover.Info, error)
+}
+
 // stateInterface gathers the methods to interact with the state.
 type stateInterface interface {
 	BeginStateTransaction(ctx context.Context) (pgx.Tx, error)
@@ -64,11 +81,16 @@ type stateInterface interface {
 	GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*state.VerifiedBatch, error)
 	GetProofReadyToVerify(ctx context.Context, lastVerfiedBatchNumber uint64, dbTx pgx.Tx) (*state.Proof, error)
 	GetVirtualBatchToProve(ctx context.Context, lastVerfiedBatchNumber uint64, maxL1Block uint64, dbTx pgx.Tx) (*state.Batch, error)
+	CountBatchesToProve(ctx context.Context, lastVerfiedBatchNumber uint64, dbTx pgx.Tx) (uint64, error)
 	GetProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (*state.Proof, *state.Proof, error)
 	GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error)
 	AddGeneratedProof(ctx context.Context, proof *state.Proof, dbTx pgx.Tx) error
 	UpdateGeneratedProof(ctx context.Context, proof *state.Proof, dbTx pgx.Tx) error
 	DeleteGeneratedProofs(ctx context.Context, batchNumber uint64, batchNumberFinal uint64, dbTx pgx.Tx) error
+	GetProofs(ctx context.Context, dbTx pgx.Tx) ([]*state.Proof, error)
+	CountProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+	GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error)
+	DeleteProofsContainingBatches(ctx context.Context, batchNumber uint64, batchNumberFinal uint64, dbTx pgx.Tx) (int64, error)
 	DeleteUngeneratedProofs(ctx context.Context, dbTx pgx.Tx) error
 	CleanupGeneratedProofs(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
 	CleanupLockedProofs(ctx context.Context, duration string, dbTx pgx.Tx) (int64, error)
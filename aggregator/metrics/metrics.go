@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/0xPolygonHermez/zkevm-node/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -9,8 +11,24 @@ const (
 	prefix                      = "aggregator_"
 	currentConnectedProversName = prefix + "current_connected_provers"
 	currentWorkingProversName   = prefix + "current_working_provers"
+
+	batchProofTimeName              = prefix + "batch_proof_time"
+	aggregatedProofTimeName         = prefix + "aggregated_proof_time"
+	finalProofTimeName              = prefix + "final_proof_time"
+	batchesToProveName              = prefix + "batches_to_prove"
+	proofsToAggregateName           = prefix + "proofs_to_aggregate"
+	timeSinceLastL1VerificationName = prefix + "time_since_last_l1_verification"
+	timeSinceLastBtcAnchorName      = prefix + "time_since_last_btc_anchor"
+	proverDisconnectsName           = prefix + "prover_disconnects"
+	canceledProofsName              = prefix + "canceled_proofs"
+
+	// ProverLabelName is the name of the label with the name of the prover
+	ProverLabelName = "prover"
 )
 
+// proofTimeBuckets go from 10 seconds to more than 1 hour
+var proofTimeBuckets = prometheus.ExponentialBuckets(10, 2, 10)
+
 // Register the metrics for the sequencer package.
 func Register() {
 	gauges := []prometheus.GaugeOpts{
@@ -22,9 +40,65 @@ func Register() {
 			Name: currentWorkingProversName,
 			Help: "[AGGREGATOR] current working provers",
 		},
+		{
+			Name: batchesToProveName,
+			Help: "[AGGREGATOR] virtual batches waiting to be proven",
+		},
+		{
+			Name: proofsToAggregateName,
+			Help: "[AGGREGATOR] generated proofs waiting to be aggregated",
+		},
+		{
+			Name: timeSinceLastL1VerificationName,
+			Help: "[AGGREGATOR] seconds since the last verification of batches in L1",
+		},
+		{
+			Name: timeSinceLastBtcAnchorName,
+			Help: "[AGGREGATOR] seconds since the last final proof anchored in Bitcoin",
+		},
+	}
+
+	histogramVecs := []metrics.HistogramVecOpts{
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name:    batchProofTimeName,
+				Help:    "[AGGREGATOR] seconds to generate a batch proof",
+				Buckets: proofTimeBuckets,
+			},
+			Labels: []string{ProverLabelName},
+		},
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name:    aggregatedProofTimeName,
+				Help:    "[AGGREGATOR] seconds to generate an aggregated proof",
+				Buckets: proofTimeBuckets,
+			},
+			Labels: []string{ProverLabelName},
+		},
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name:    finalProofTimeName,
+				Help:    "[AGGREGATOR] seconds to generate a final proof",
+				Buckets: proofTimeBuckets,
+			},
+			Labels: []string{ProverLabelName},
+		},
+	}
+
+	counters := []prometheus.CounterOpts{
+		{
+			Name: proverDisconnectsName,
+			Help: "[AGGREGATOR] provers disconnected",
+		},
+		{
+			Name: canceledProofsName,
+			Help: "[AGGREGATOR] proofs canceled before being generated",
+		},
 	}
 
 	metrics.RegisterGauges(gauges...)
+	metrics.RegisterHistogramVecs(histogramVecs...)
+	metrics.RegisterCounters(counters...)
 }
 
 // ConnectedProver increments the gauge for the current number of connected
@@ -34,9 +108,10 @@ func ConnectedProver() {
 }
 
 // DisconnectedProver decrements the gauge for the current number of connected
-// provers.
+// provers and counts the disconnection.
 func DisconnectedProver() {
 	metrics.GaugeDec(currentConnectedProversName)
+	metrics.CounterInc(proverDisconnectsName)
 }
 
 // WorkingProver increments the gauge for the current number of working
@@ -49,3 +124,49 @@ func WorkingProver() {
 func IdlingProver() {
 	metrics.GaugeDec(currentWorkingProversName)
 }
+
+// BatchProofTime observes the time a prover took to generate a batch proof.
+func BatchProofTime(prover string, elapsed time.Duration) {
+	metrics.HistogramVecObserve(batchProofTimeName, prover, elapsed.Seconds())
+}
+
+// AggregatedProofTime observes the time a prover took to generate an
+// aggregated proof.
+func AggregatedProofTime(prover string, elapsed time.Duration) {
+	metrics.HistogramVecObserve(aggregatedProofTimeName, prover, elapsed.Seconds())
+}
+
+// FinalProofTime observes the time a prover took to generate a final proof.
+func FinalProofTime(prover string, elapsed time.Duration) {
+	metrics.HistogramVecObserve(finalProofTimeName, prover, elapsed.Seconds())
+}
+
+// CanceledProof increments the counter of proofs canceled before being
+// generated.
+func CanceledProof() {
+	metrics.CounterInc(canceledProofsName)
+}
+
+// BatchesToProve sets the gauge for the number of batches waiting to be
+// proven.
+func BatchesToProve(batches uint64) {
+	metrics.GaugeSet(batchesToProveName, float64(batches))
+}
+
+// ProofsToAggregate sets the gauge for the number of proofs waiting to be
+// aggregated.
+func ProofsToAggregate(proofs uint64) {
+	metrics.GaugeSet(proofsToAggregateName, float64(proofs))
+}
+
+// TimeSinceLastL1Verification sets the gauge for the time since the last
+// verification of batches in L1.
+func TimeSinceLastL1Verification(elapsed time.Duration) {
+	metrics.GaugeSet(timeSinceLastL1VerificationName, elapsed.Seconds())
+}
+
+// TimeSinceLastBtcAnchor sets the gauge for the time since the last final
+// proof anchored in Bitcoin.
+func TimeSinceLastBtcAnchor(elapsed time.Duration) {
+	metrics.GaugeSet(timeSinceLastBtcAnchorName, elapsed.Seconds())
+}
@@ -16,6 +16,11 @@ func (_m *Btcman) DecodeInscription(txHash string) error {
 	return nil
 }
 
+func (_m *Btcman) EstimateInscriptionFee(dataSize int) (int64, error) {
+	ret := _m.Called(dataSize)
+	return ret.Get(0).(int64), ret.Error(1)
+}
+
 func (_m *Btcman) Shutdown() {
 
 }
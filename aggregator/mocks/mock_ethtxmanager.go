@@ -101,6 +101,24 @@ func (_m *EthTxManager) ResultsByStatus(ctx context.Context, owner string, statu
 	return r0, r1
 }
 
+// SetStatusDone provides a mock function with given fields: ctx, owner, id, dbTx
+func (_m *EthTxManager) SetStatusDone(ctx context.Context, owner string, id string, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, owner, id, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatusDone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, pgx.Tx) error); ok {
+		r0 = rf(ctx, owner, id, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewEthTxManager creates a new instance of EthTxManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEthTxManager(t interface {
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.39.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pgx "github.com/jackc/pgx/v4"
+	mock "github.com/stretchr/testify/mock"
+
+	prover "github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+)
+
+// ProverStorageMock is an autogenerated mock type for the proverStorage type
+type ProverStorageMock struct {
+	mock.Mock
+}
+
+// AddOrUpdateProver provides a mock function with given fields: ctx, p, dbTx
+func (_m *ProverStorageMock) AddOrUpdateProver(ctx context.Context, p prover.Info, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, p, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOrUpdateProver")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, prover.Info, pgx.Tx) error); ok {
+		r0 = rf(ctx, p, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetProvers provides a mock function with given fields: ctx, dbTx
+func (_m *ProverStorageMock) GetProvers(ctx context.Context, dbTx pgx.Tx) ([]prover.Info, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProvers")
+	}
+
+	var r0 []prover.Info
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) ([]prover.Info, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) []prover.Info); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]prover.Info)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProverStats provides a mock function with given fields: ctx, proverID, proofType, stats, dbTx
+func (_m *ProverStorageMock) UpdateProverStats(ctx context.Context, proverID string, proofType prover.ProofType, stats prover.ProofStats, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, proverID, proofType, stats, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProverStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, prover.ProofType, prover.ProofStats, pgx.Tx) error); ok {
+		r0 = rf(ctx, proverID, proofType, stats, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewProverStorageMock creates a new instance of ProverStorageMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProverStorageMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProverStorageMock {
+	mock := &ProverStorageMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,67 @@
+// Code generated by mockery v2.39.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	state "github.com/0xPolygonHermez/zkevm-node/state"
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/0xPolygonHermez/zkevm-node/etherman/types"
+)
+
+// SettlerMock is an autogenerated mock type for the Settler type
+type SettlerMock struct {
+	mock.Mock
+}
+
+// Name provides a mock function with given fields:
+func (_m *SettlerMock) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Settle provides a mock function with given fields: ctx, proof, inputs
+func (_m *SettlerMock) Settle(ctx context.Context, proof *state.Proof, inputs types.FinalProofInputs) error {
+	ret := _m.Called(ctx, proof, inputs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Settle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *state.Proof, types.FinalProofInputs) error); ok {
+		r0 = rf(ctx, proof, inputs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSettlerMock creates a new instance of SettlerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSettlerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SettlerMock {
+	mock := &SettlerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
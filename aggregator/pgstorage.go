@@ -0,0 +1,139 @@
+package aggregator
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PostgresStorage persists the prover registry of the aggregator
+type PostgresStorage struct {
+	*pgxpool.Pool
+}
+
+// NewPostgresStorage creates a new instance of storage that use
+// postgres to store data
+func NewPostgresStorage(dbCfg db.Config) (*PostgresStorage, error) {
+	db, err := db.NewSQLDB(dbCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresStorage{
+		db,
+	}, nil
+}
+
+// AddOrUpdateProver persists a prover, overwriting its name, address, fork ids and last seen time if it already exists
+func (s *PostgresStorage) AddOrUpdateProver(ctx context.Context, p prover.Info, dbTx pgx.Tx) error {
+	conn := s.dbConn(dbTx)
+	const cmd = `
+        INSERT INTO state.prover (prover_id, prover_name, addr, fork_ids, first_seen_at, last_seen_at)
+                          VALUES (       $1,          $2,   $3,       $4,            $5,           $6)
+        ON CONFLICT (prover_id) DO UPDATE SET
+            prover_name = EXCLUDED.prover_name, addr = EXCLUDED.addr, fork_ids = EXCLUDED.fork_ids, last_seen_at = EXCLUDED.last_seen_at`
+
+	forkIDs := make([]int64, 0, len(p.ForkIDs))
+	for _, forkID := range p.ForkIDs {
+		forkIDs = append(forkIDs, int64(forkID))
+	}
+	_, err := conn.Exec(ctx, cmd, p.ID, p.Name, p.Addr, forkIDs, p.FirstSeenAt, p.LastSeenAt)
+	return err
+}
+
+// UpdateProverStats persists the stats of a prover for a proof type
+func (s *PostgresStorage) UpdateProverStats(ctx context.Context, proverID string, proofType prover.ProofType, stats prover.ProofStats, dbTx pgx.Tx) error {
+	conn := s.dbConn(dbTx)
+	const cmd = `
+        INSERT INTO state.prover_proof_stats (prover_id, proof_type, proofs, failures, total_duration_ms, last_duration_ms)
+                                      VALUES (       $1,         $2,     $3,       $4,                $5,               $6)
+        ON CONFLICT (prover_id, proof_type) DO UPDATE SET
+            proofs = EXCLUDED.proofs, failures = EXCLUDED.failures,
+            total_duration_ms = EXCLUDED.total_duration_ms, last_duration_ms = EXCLUDED.last_duration_ms`
+
+	_, err := conn.Exec(ctx, cmd, proverID, string(proofType), stats.Proofs, stats.Failures,
+		stats.TotalDuration.Milliseconds(), stats.LastDuration.Milliseconds())
+	return err
+}
+
+// GetProvers loads the persisted provers with their stats
+func (s *PostgresStorage) GetProvers(ctx context.Context, dbTx pgx.Tx) ([]prover.Info, error) {
+	conn := s.dbConn(dbTx)
+	const proversCmd = `SELECT prover_id, prover_name, addr, fork_ids, first_seen_at, last_seen_at FROM state.prover ORDER BY prover_id`
+	const statsCmd = `SELECT prover_id, proof_type, proofs, failures, total_duration_ms, last_duration_ms FROM state.prover_proof_stats`
+
+	rows, err := conn.Query(ctx, proversCmd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	provers := []prover.Info{}
+	indexes := map[string]int{}
+	for rows.Next() {
+		var (
+			p       prover.Info
+			addr    *string
+			forkIDs []int64
+		)
+		if err := rows.Scan(&p.ID, &p.Name, &addr, &forkIDs, &p.FirstSeenAt, &p.LastSeenAt); err != nil {
+			return nil, err
+		}
+		if addr != nil {
+			p.Addr = *addr
+		}
+		for _, forkID := range forkIDs {
+			p.ForkIDs = append(p.ForkIDs, uint64(forkID))
+		}
+		p.Stats = map[prover.ProofType]prover.ProofStats{}
+		indexes[p.ID] = len(provers)
+		provers = append(provers, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statsRows, err := conn.Query(ctx, statsCmd)
+	if err != nil {
+		return nil, err
+	}
+	defer statsRows.Close()
+
+	for statsRows.Next() {
+		var (
+			proverID, proofType             string
+			stats                           prover.ProofStats
+			totalDurationMs, lastDurationMs int64
+		)
+		if err := statsRows.Scan(&proverID, &proofType, &stats.Proofs, &stats.Failures, &totalDurationMs, &lastDurationMs); err != nil {
+			return nil, err
+		}
+		stats.TotalDuration = time.Duration(totalDurationMs) * time.Millisecond
+		stats.LastDuration = time.Duration(lastDurationMs) * time.Millisecond
+		if i, found := indexes[proverID]; found {
+			provers[i].Stats[prover.ProofType(proofType)] = stats
+		}
+	}
+	return provers, statsRows.Err()
+}
+
+// dbConn represents an instance of an object that can
+// connect to a postgres db to execute sql commands and query data
+type dbConn interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (commandTag pgconn.CommandTag, err error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// dbConn determines which db connection to use, dbTx or the main pgxpool
+func (s *PostgresStorage) dbConn(dbTx pgx.Tx) dbConn {
+	if dbTx != nil {
+		return dbTx
+	}
+	return s
+}
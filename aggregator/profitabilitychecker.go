@@ -2,8 +2,14 @@ package aggregator
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"time"
+
+	btcManager "github.com/0xPolygonHermez/zkevm-node/btcman"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // TxProfitabilityCheckerType checks profitability of batch validation
@@ -14,6 +20,12 @@ const (
 	ProfitabilityBase = "base"
 	// ProfitabilityAcceptAll validate batch anyway and don't check anything
 	ProfitabilityAcceptAll = "acceptall"
+	// ProfitabilityCost aggregates batches until the cost of settling them is low enough per batch
+	ProfitabilityCost = "cost"
+
+	// finalProofInscriptionSize is the size of the message inscribed in Bitcoin: the new local
+	// exit root, the new state root and the 24 words of the final proof
+	finalProofInscriptionSize = 26 * common.HashLength
 )
 
 // TxProfitabilityCheckerBase checks pol collateral with min reward
@@ -76,6 +88,100 @@ func (pc *TxProfitabilityCheckerAcceptAll) IsProfitable(ctx context.Context, pol
 	return true, nil
 }
 
+// TxProfitabilityCheckerCost estimates the cost of settling a proof, the L1 gas of
+// verifyBatchesTrustedAggregator and the fee of the Bitcoin inscription, and holds
+// the proof back to aggregate more batches while it is too high per batch
+type TxProfitabilityCheckerCost struct {
+	State                             stateInterface
+	Ethman                            etherman
+	Btcman                            btcman
+	IntervalAfterWhichBatchSentAnyway time.Duration
+	// VerifyBatchesGas is the gas of the L1 settlement tx, MaxL1CostPerBatch is nil if it isn't checked
+	VerifyBatchesGas  uint64
+	MaxL1CostPerBatch *big.Int
+	// MaxBtcCostPerBatch is zero if the Bitcoin inscription fee isn't checked
+	MaxBtcCostPerBatch uint64
+	// FallbackBtcFeeRate is the fee rate in sat/vB used when the inscription fee can't be estimated
+	FallbackBtcFeeRate uint64
+}
+
+// NewTxProfitabilityCheckerCost init cost tx profitability checker, only the costs of the
+// settlement sinks of the config with a max cost per batch are checked
+func NewTxProfitabilityCheckerCost(cfg Config, state stateInterface, etherman etherman, btcman btcman) (*TxProfitabilityCheckerCost, error) {
+	if cfg.IntervalAfterWhichBatchConsolidateAnyway.Duration <= 0 {
+		return nil, fmt.Errorf("the %s tx profitability checker requires IntervalAfterWhichBatchConsolidateAnyway", ProfitabilityCost)
+	}
+
+	pc := &TxProfitabilityCheckerCost{
+		State:                             state,
+		Ethman:                            etherman,
+		Btcman:                            btcman,
+		IntervalAfterWhichBatchSentAnyway: cfg.IntervalAfterWhichBatchConsolidateAnyway.Duration,
+	}
+	maxL1Cost := cfg.CostProfitability.MaxL1CostPerBatch.Int
+	if cfg.settlesIn(SinkL1) && maxL1Cost != nil && maxL1Cost.Sign() > 0 {
+		pc.VerifyBatchesGas = cfg.CostProfitability.VerifyBatchesGas + cfg.GasOffset
+		pc.MaxL1CostPerBatch = cfg.CostProfitability.MaxL1CostPerBatch.Int
+	}
+	if cfg.settlesIn(SinkBTC) {
+		pc.MaxBtcCostPerBatch = cfg.CostProfitability.MaxBtcCostPerBatch
+		pc.FallbackBtcFeeRate = cfg.CostProfitability.FallbackBtcFeeRate
+	}
+	return pc, nil
+}
+
+// IsProfitable generates the proofs of every batch, the cost is checked when settling them
+func (pc *TxProfitabilityCheckerCost) IsProfitable(ctx context.Context, polCollateral *big.Int) (bool, error) {
+	return true, nil
+}
+
+// IsSettlementProfitable checks the cost of settling the proof per batch, settling it anyway
+// once its first batch is older than IntervalAfterWhichBatchSentAnyway
+func (pc *TxProfitabilityCheckerCost) IsSettlementProfitable(ctx context.Context, proof *state.Proof) (bool, error) {
+	log := log.WithFields("batches", fmt.Sprintf("%d-%d", proof.BatchNumber, proof.BatchNumberFinal))
+
+	batch, err := pc.State.GetBatchByNumber(ctx, proof.BatchNumber, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get batch %d, %w", proof.BatchNumber, err)
+	}
+	if time.Since(batch.Timestamp) >= pc.IntervalAfterWhichBatchSentAnyway {
+		log.Debugf("Batch %d closed more than %v ago, settling anyway", proof.BatchNumber, pc.IntervalAfterWhichBatchSentAnyway)
+		return true, nil
+	}
+
+	batches := proof.BatchNumberFinal - proof.BatchNumber + 1
+
+	if pc.MaxL1CostPerBatch != nil {
+		gasPrice, err := pc.Ethman.SuggestedGasPrice(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to get L1 gas price, %w", err)
+		}
+		cost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(pc.VerifyBatchesGas))
+		maxCost := new(big.Int).Mul(pc.MaxL1CostPerBatch, new(big.Int).SetUint64(batches))
+		if cost.Cmp(maxCost) > 0 {
+			log.Infof("L1 settlement cost %d wei is higher than %d wei for %d batches", cost, maxCost, batches)
+			return false, nil
+		}
+	}
+
+	if pc.MaxBtcCostPerBatch != 0 {
+		fee, err := pc.Btcman.EstimateInscriptionFee(finalProofInscriptionSize)
+		if err != nil {
+			if pc.FallbackBtcFeeRate == 0 {
+				return false, fmt.Errorf("failed to estimate Bitcoin inscription fee, %w", err)
+			}
+			fee = int64(pc.FallbackBtcFeeRate) * btcManager.InscriptionVSize(finalProofInscriptionSize)
+			log.Warnf("Failed to estimate Bitcoin inscription fee, using the fallback fee rate of %d sat/vB: %v", pc.FallbackBtcFeeRate, err)
+		}
+		if maxFee := pc.MaxBtcCostPerBatch * batches; uint64(fee) > maxFee {
+			log.Infof("Bitcoin inscription fee %d sats is higher than %d sats for %d batches", fee, maxFee, batches)
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 // TODO: now it's impossible to check, when batch got consolidated, bcs it's not saved
 //func isConsolidatedBatchAppeared(ctx context.Context, state stateInterface, intervalAfterWhichBatchConsolidatedAnyway time.Duration) (bool, error) {
 //	batch, err := state.GetLastVerifiedBatch(ctx, nil)
@@ -0,0 +1,191 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTxProfitabilityCheckerCost(t *testing.T) {
+	cfg := Config{
+		SettlementBackend: L1,
+		GasOffset:         1000,
+		CostProfitability: CostProfitabilityConfig{
+			VerifyBatchesGas:   350000,
+			MaxL1CostPerBatch:  TokenAmountWithDecimals{Int: big.NewInt(1000)},
+			MaxBtcCostPerBatch: 500,
+			FallbackBtcFeeRate: 10,
+		},
+	}
+	_, err := NewTxProfitabilityCheckerCost(cfg, nil, nil, nil)
+	assert.ErrorContains(t, err, "IntervalAfterWhichBatchConsolidateAnyway")
+
+	cfg.IntervalAfterWhichBatchConsolidateAnyway = types.NewDuration(time.Hour)
+	pc, err := NewTxProfitabilityCheckerCost(cfg, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(351000), pc.VerifyBatchesGas)
+	assert.Equal(t, big.NewInt(1000), pc.MaxL1CostPerBatch)
+	assert.Equal(t, uint64(500), pc.MaxBtcCostPerBatch)
+	assert.Equal(t, uint64(10), pc.FallbackBtcFeeRate)
+
+	// the agglayer pays the L1 gas and doesn't inscribe the proofs
+	cfg.SettlementBackend = AggLayer
+	pc, err = NewTxProfitabilityCheckerCost(cfg, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, pc.MaxL1CostPerBatch)
+	assert.Zero(t, pc.MaxBtcCostPerBatch)
+
+	cfg.SettlementBackend = Multi
+	cfg.MultiSettlement.Sinks = []SettlementSink{SinkAggLayer, SinkBTC}
+	pc, err = NewTxProfitabilityCheckerCost(cfg, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, pc.MaxL1CostPerBatch)
+	assert.Equal(t, uint64(500), pc.MaxBtcCostPerBatch)
+}
+
+func TestTxProfitabilityCheckerCost(t *testing.T) {
+	ctx := context.Background()
+	stateMock := mocks.NewStateMock(t)
+	ethermanMock := mocks.NewEtherman(t)
+	btcmanMock := mocks.NewBtcman(t)
+	pc := &TxProfitabilityCheckerCost{
+		State:                             stateMock,
+		Ethman:                            ethermanMock,
+		Btcman:                            btcmanMock,
+		IntervalAfterWhichBatchSentAnyway: time.Hour,
+		VerifyBatchesGas:                  100,
+		MaxL1CostPerBatch:                 big.NewInt(1000),
+		MaxBtcCostPerBatch:                500,
+	}
+	profitable, err := pc.IsProfitable(ctx, big.NewInt(0))
+	require.NoError(t, err)
+	assert.True(t, profitable)
+
+	testCases := []struct {
+		name       string
+		proof      *state.Proof
+		batchAge   time.Duration
+		gasPrice   int64
+		btcFee     int64
+		profitable bool
+	}{
+		{
+			name:       "single batch cheap enough",
+			proof:      &state.Proof{BatchNumber: 1, BatchNumberFinal: 1},
+			gasPrice:   10,
+			btcFee:     500,
+			profitable: true,
+		},
+		{
+			name:       "L1 gas too expensive for a single batch",
+			proof:      &state.Proof{BatchNumber: 1, BatchNumberFinal: 1},
+			gasPrice:   20,
+			profitable: false,
+		},
+		{
+			name:       "L1 gas split among the batches",
+			proof:      &state.Proof{BatchNumber: 1, BatchNumberFinal: 2},
+			gasPrice:   20,
+			btcFee:     1000,
+			profitable: true,
+		},
+		{
+			name:       "Bitcoin fee too expensive",
+			proof:      &state.Proof{BatchNumber: 1, BatchNumberFinal: 2},
+			gasPrice:   20,
+			btcFee:     1001,
+			profitable: false,
+		},
+		{
+			name:       "deadline reached",
+			proof:      &state.Proof{BatchNumber: 1, BatchNumberFinal: 1},
+			batchAge:   2 * time.Hour,
+			profitable: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stateMock.On("GetBatchByNumber", ctx, tc.proof.BatchNumber, nil).Return(&state.Batch{Timestamp: time.Now().Add(-tc.batchAge)}, nil).Once()
+			if tc.gasPrice != 0 {
+				ethermanMock.On("SuggestedGasPrice", ctx).Return(big.NewInt(tc.gasPrice), nil).Once()
+			}
+			if tc.btcFee != 0 {
+				btcmanMock.On("EstimateInscriptionFee", finalProofInscriptionSize).Return(tc.btcFee, nil).Once()
+			}
+
+			profitable, err := pc.IsSettlementProfitable(ctx, tc.proof)
+			require.NoError(t, err)
+			assert.Equal(t, tc.profitable, profitable)
+		})
+	}
+
+	// the inscription fee is computed at the fallback fee rate when it can't be estimated
+	pc.MaxL1CostPerBatch = nil
+	proof := &state.Proof{BatchNumber: 1, BatchNumberFinal: 1}
+	stateMock.On("GetBatchByNumber", ctx, proof.BatchNumber, nil).Return(&state.Batch{Timestamp: time.Now()}, nil)
+	btcmanMock.On("EstimateInscriptionFee", finalProofInscriptionSize).Return(int64(0), errors.New("no feerate found"))
+
+	_, err = pc.IsSettlementProfitable(ctx, proof)
+	assert.ErrorContains(t, err, "no feerate found")
+
+	pc.FallbackBtcFeeRate = 1
+	profitable, err = pc.IsSettlementProfitable(ctx, proof)
+	require.NoError(t, err)
+	assert.True(t, profitable)
+
+	pc.FallbackBtcFeeRate = 2
+	profitable, err = pc.IsSettlementProfitable(ctx, proof)
+	require.NoError(t, err)
+	assert.False(t, profitable)
+}
+
+func TestTryBuildFinalProofWaitsForProfitableSettlement(t *testing.T) {
+	ctx := context.Background()
+	proofID := "proofId"
+	proof := &state.Proof{BatchNumber: 1, BatchNumberFinal: 1, ProofID: &proofID}
+
+	stateMock := mocks.NewStateMock(t)
+	ethermanMock := mocks.NewEtherman(t)
+	proverMock := mocks.NewProverMock(t)
+	settlementChecker := &TxProfitabilityCheckerCost{
+		State:                             stateMock,
+		Ethman:                            ethermanMock,
+		IntervalAfterWhichBatchSentAnyway: time.Hour,
+		VerifyBatchesGas:                  100,
+		MaxL1CostPerBatch:                 big.NewInt(1000),
+	}
+	a := Aggregator{
+		State:                   stateMock,
+		Ethman:                  ethermanMock,
+		StateDBMutex:            &sync.Mutex{},
+		TimeSendFinalProofMutex: &sync.RWMutex{},
+		settlementChecker:       settlementChecker,
+	}
+	a.ctx = ctx
+
+	proverMock.On("Name").Return("prover").Once()
+	proverMock.On("ID").Return("proverID").Once()
+	proverMock.On("Addr").Return("addr").Once()
+	stateMock.On("GetLastVerifiedBatch", mock.Anything, nil).Return(&state.VerifiedBatch{BatchNumber: 0}, nil).Twice()
+	ethermanMock.On("GetLatestVerifiedBatchNum").Return(uint64(0), nil).Once()
+	stateMock.On("GetProofReadyToVerify", mock.Anything, uint64(0), nil).Return(proof, nil).Once()
+	stateMock.On("UpdateGeneratedProof", mock.Anything, proof, nil).Return(nil).Twice()
+	stateMock.On("GetBatchByNumber", mock.Anything, uint64(1), nil).Return(&state.Batch{Timestamp: time.Now()}, nil).Once()
+	ethermanMock.On("SuggestedGasPrice", mock.Anything).Return(big.NewInt(20), nil).Once()
+
+	built, err := a.tryBuildFinalProof(ctx, proverMock, nil)
+	require.NoError(t, err)
+	assert.False(t, built)
+	// the proof is unlocked to be aggregated with the next ones
+	assert.Nil(t, proof.GeneratingSince)
+}
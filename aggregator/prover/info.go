@@ -0,0 +1,103 @@
+package prover
+
+import (
+	"math"
+	"time"
+)
+
+// ProofType is the kind of proof a prover generates
+type ProofType string
+
+const (
+	// ProofTypeBatch is a proof of a single batch
+	ProofTypeBatch ProofType = "batch"
+	// ProofTypeAggregated is a proof aggregating two recursive proofs
+	ProofTypeAggregated ProofType = "aggregated"
+	// ProofTypeFinal is the proof sent to be settled
+	ProofTypeFinal ProofType = "final"
+)
+
+// ProofStats are the performance figures of a prover for a proof type
+type ProofStats struct {
+	Proofs        uint64        `json:"proofs"`
+	Failures      uint64        `json:"failures"`
+	TotalDuration time.Duration `json:"totalDuration"`
+	LastDuration  time.Duration `json:"lastDuration"`
+}
+
+// AverageDuration returns the average duration of the successful proofs
+func (s ProofStats) AverageDuration() time.Duration {
+	if s.Proofs == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Proofs)
+}
+
+// Score is the expected time to get a successful proof, taking into account the failure rate.
+// Lower is better. Provers without history score 0, so they get work to measure them
+func (s ProofStats) Score() float64 {
+	attempts := s.Proofs + s.Failures
+	if attempts == 0 {
+		return 0
+	}
+	if s.Proofs == 0 {
+		return math.Inf(1)
+	}
+	return float64(s.AverageDuration()) * float64(attempts) / float64(s.Proofs)
+}
+
+// Info is what the aggregator knows about a prover, including its history
+// from previous connections
+type Info struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Addr        string                   `json:"addr"`
+	ForkIDs     []uint64                 `json:"forkIds"`
+	Connected   bool                     `json:"connected"`
+	Busy        bool                     `json:"busy"`
+	FirstSeenAt time.Time                `json:"firstSeenAt"`
+	LastSeenAt  time.Time                `json:"lastSeenAt"`
+	Stats       map[ProofType]ProofStats `json:"stats"`
+	// CurrentProof is the proof the prover is generating, if any
+	CurrentProof *InFlightProof `json:"currentProof,omitempty"`
+}
+
+// InFlightProof is a proof being generated by a prover
+type InFlightProof struct {
+	ID        string    `json:"id"`
+	Type      ProofType `json:"type"`
+	StartedAt time.Time `json:"startedAt"`
+	// BatchNumber and BatchNumberFinal are the range of batches covered by the proof, zero until known
+	BatchNumber      uint64 `json:"batchNumber"`
+	BatchNumberFinal uint64 `json:"batchNumberFinal"`
+}
+
+// Covers returns true if the proof covers the range of batches
+func (p InFlightProof) Covers(batchNumber, batchNumberFinal uint64) bool {
+	return p.BatchNumberFinal != 0 && p.BatchNumber <= batchNumber && batchNumberFinal <= p.BatchNumberFinal
+}
+
+// SupportsForkID returns true if the prover reported the fork id
+func (p Info) SupportsForkID(forkID uint64) bool {
+	for _, id := range p.ForkIDs {
+		if id == forkID {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy returns a deep copy of the prover info
+func (p Info) Copy() Info {
+	cpy := p
+	cpy.ForkIDs = append([]uint64{}, p.ForkIDs...)
+	cpy.Stats = make(map[ProofType]ProofStats, len(p.Stats))
+	for proofType, stats := range p.Stats {
+		cpy.Stats[proofType] = stats
+	}
+	if p.CurrentProof != nil {
+		current := *p.CurrentProof
+		cpy.CurrentProof = &current
+	}
+	return cpy
+}
@@ -0,0 +1,257 @@
+// Package mockprover implements a stand-in for the zkProver speaking the aggregator gRPC protocol.
+// It connects to the aggregator and answers its requests with deterministic fake proofs, after a
+// configurable latency and with a configurable failure rate, so the whole proving and settlement
+// pipeline can run without the real prover.
+package mockprover
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// MockedStateRoot is the new state root of the final proofs. The aggregator replaces it by the
+	// state root of the last batch of the proof, as it does with the proofs of the zkProver mock
+	MockedStateRoot = "0x090bcaf734c4f06c93954a827b45a6e8c67b8e0fd1e0a35a1c5982d6961828f9"
+	// MockedLocalExitRoot is the new local exit root of the final proofs, replaced by the aggregator
+	// by the local exit root of the last batch of the proof
+	MockedLocalExitRoot = "0x17c04c3760510b48c6012742c540a81aba4bca2f78b9d14bfd2f123e2e53ea3e"
+
+	versionProto = "v0_0_1"
+	// finalProofWords is the amount of 32 bytes words of a final proof
+	finalProofWords = 24
+)
+
+// Config is the configuration of a mock prover
+type Config struct {
+	// Name is the prover name reported in the status
+	Name string
+	// ID is the prover id reported in the status
+	ID string
+	// ForkID is the fork id reported in the status
+	ForkID uint64
+	// Latency is the time it takes to generate a proof
+	Latency time.Duration
+	// FailureRate is the probability, between 0 and 1, of a proof generation failing
+	FailureRate float64
+	// Seed seeds the generator deciding which proofs fail, so the failures are reproducible
+	Seed int64
+}
+
+type proofKind int
+
+const (
+	recursiveProof proofKind = iota
+	finalProof
+)
+
+// proof is a proof requested to the mock prover
+type proof struct {
+	kind      proofKind
+	content   string
+	readyAt   time.Time
+	failed    bool
+	cancelled bool
+}
+
+// Prover is a mock prover
+type Prover struct {
+	cfg Config
+
+	mu      sync.Mutex
+	rand    *rand.Rand
+	proofs  map[string]*proof
+	counter uint64
+	now     func() time.Time
+}
+
+// New creates a mock prover
+func New(cfg Config) *Prover {
+	return &Prover{
+		cfg:    cfg,
+		rand:   rand.New(rand.NewSource(cfg.Seed)), //nolint:gosec
+		proofs: map[string]*proof{},
+		now:    time.Now,
+	}
+}
+
+// Run answers the requests received through the stream until it is closed or the context is done
+func (p *Prover) Run(ctx context.Context, stream prover.AggregatorService_ChannelClient) error {
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to receive aggregator message: %w", err)
+		}
+
+		res := p.handle(msg)
+		if err := stream.Send(res); err != nil {
+			return fmt.Errorf("failed to send prover message: %w", err)
+		}
+	}
+}
+
+// handle returns the response to an aggregator message
+func (p *Prover) handle(msg *prover.AggregatorMessage) *prover.ProverMessage {
+	res := &prover.ProverMessage{Id: msg.Id}
+	switch req := msg.Request.(type) {
+	case *prover.AggregatorMessage_GetStatusRequest:
+		res.Response = &prover.ProverMessage_GetStatusResponse{GetStatusResponse: p.status()}
+	case *prover.AggregatorMessage_GenBatchProofRequest:
+		id := p.request(recursiveProof, batchProofContent(req.GenBatchProofRequest.Input))
+		res.Response = &prover.ProverMessage_GenBatchProofResponse{
+			GenBatchProofResponse: &prover.GenBatchProofResponse{Id: id, Result: prover.Result_RESULT_OK},
+		}
+	case *prover.AggregatorMessage_GenAggregatedProofRequest:
+		content := hashProof("aggregated", []byte(req.GenAggregatedProofRequest.RecursiveProof_1), []byte(req.GenAggregatedProofRequest.RecursiveProof_2))
+		id := p.request(recursiveProof, content)
+		res.Response = &prover.ProverMessage_GenAggregatedProofResponse{
+			GenAggregatedProofResponse: &prover.GenAggregatedProofResponse{Id: id, Result: prover.Result_RESULT_OK},
+		}
+	case *prover.AggregatorMessage_GenFinalProofRequest:
+		id := p.request(finalProof, finalProofContent(req.GenFinalProofRequest.RecursiveProof, req.GenFinalProofRequest.AggregatorAddr))
+		res.Response = &prover.ProverMessage_GenFinalProofResponse{
+			GenFinalProofResponse: &prover.GenFinalProofResponse{Id: id, Result: prover.Result_RESULT_OK},
+		}
+	case *prover.AggregatorMessage_CancelRequest:
+		res.Response = &prover.ProverMessage_CancelResponse{
+			CancelResponse: &prover.CancelResponse{Result: p.cancel(req.CancelRequest.Id)},
+		}
+	case *prover.AggregatorMessage_GetProofRequest:
+		res.Response = &prover.ProverMessage_GetProofResponse{GetProofResponse: p.getProof(req.GetProofRequest.Id)}
+	default:
+		log.Warnf("Mock prover received an unknown request %T", msg.Request)
+	}
+	return res
+}
+
+func (p *Prover) status() *prover.GetStatusResponse {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := &prover.GetStatusResponse{
+		Status:       prover.GetStatusResponse_STATUS_IDLE,
+		VersionProto: versionProto,
+		ProverName:   p.cfg.Name,
+		ProverId:     p.cfg.ID,
+		ForkId:       p.cfg.ForkID,
+	}
+	now := p.now()
+	for id, proof := range p.proofs {
+		if !proof.cancelled && now.Before(proof.readyAt) {
+			status.Status = prover.GetStatusResponse_STATUS_COMPUTING
+			status.PendingRequestQueueIds = append(status.PendingRequestQueueIds, id)
+		}
+	}
+	return status
+}
+
+// request registers a proof generation, deciding if it will fail, and returns its id
+func (p *Prover) request(kind proofKind, content string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.counter++
+	id := fmt.Sprintf("%s-%d", p.cfg.ID, p.counter)
+	p.proofs[id] = &proof{
+		kind:    kind,
+		content: content,
+		readyAt: p.now().Add(p.cfg.Latency),
+		failed:  p.rand.Float64() < p.cfg.FailureRate,
+	}
+	log.Debugf("Mock prover %s generating proof %s", p.cfg.ID, id)
+	return id
+}
+
+func (p *Prover) cancel(id string) prover.Result {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	proof, found := p.proofs[id]
+	if !found {
+		return prover.Result_RESULT_ERROR
+	}
+	proof.cancelled = true
+	return prover.Result_RESULT_OK
+}
+
+func (p *Prover) getProof(id string) *prover.GetProofResponse {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	res := &prover.GetProofResponse{Id: id}
+	proof, found := p.proofs[id]
+	switch {
+	case !found:
+		res.Result = prover.GetProofResponse_RESULT_ERROR
+		res.ResultString = "unknown proof id"
+	case proof.cancelled:
+		res.Result = prover.GetProofResponse_RESULT_CANCEL
+	case p.now().Before(proof.readyAt):
+		res.Result = prover.GetProofResponse_RESULT_PENDING
+	case proof.failed:
+		res.Result = prover.GetProofResponse_RESULT_COMPLETED_ERROR
+		res.ResultString = "mock prover failure"
+	case proof.kind == finalProof:
+		res.Result = prover.GetProofResponse_RESULT_COMPLETED_OK
+		res.Proof = &prover.GetProofResponse_FinalProof{FinalProof: &prover.FinalProof{
+			Proof: proof.content,
+			Public: &prover.PublicInputsExtended{
+				NewStateRoot:     []byte(MockedStateRoot),
+				NewLocalExitRoot: []byte(MockedLocalExitRoot),
+			},
+		}}
+	default:
+		res.Result = prover.GetProofResponse_RESULT_COMPLETED_OK
+		res.Proof = &prover.GetProofResponse_RecursiveProof{RecursiveProof: proof.content}
+	}
+
+	// the proofs are handed out once finished
+	if found && res.Result != prover.GetProofResponse_RESULT_PENDING {
+		delete(p.proofs, id)
+	}
+	return res
+}
+
+// hashProof returns a recursive proof derived from its kind and inputs
+func hashProof(kind string, inputs ...[]byte) string {
+	return crypto.Keccak256Hash(append([][]byte{[]byte(kind)}, inputs...)...).Hex()
+}
+
+func batchProofContent(input *prover.InputProver) string {
+	if input == nil || input.PublicInputs == nil {
+		return hashProof("batch")
+	}
+	return hashProof("batch",
+		binary.BigEndian.AppendUint64(nil, input.PublicInputs.OldBatchNum),
+		input.PublicInputs.OldStateRoot,
+		input.PublicInputs.BatchL2Data,
+	)
+}
+
+// finalProofContent returns a final proof with the length expected by the rollup contract
+func finalProofContent(recursiveProof, aggregatorAddr string) string {
+	seed := crypto.Keccak256([]byte("final"), []byte(recursiveProof), common.HexToAddress(aggregatorAddr).Bytes())
+	words := make([]byte, 0, finalProofWords*common.HashLength)
+	for i := 0; i < finalProofWords; i++ {
+		words = append(words, crypto.Keccak256(seed, []byte{byte(i)})...)
+	}
+	return hexutil.Encode(words)
+}
@@ -0,0 +1,167 @@
+package mockprover
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func getProof(p *Prover, id string) *prover.GetProofResponse {
+	res := p.handle(&prover.AggregatorMessage{
+		Id:      "getProof",
+		Request: &prover.AggregatorMessage_GetProofRequest{GetProofRequest: &prover.GetProofRequest{Id: id}},
+	})
+	return res.Response.(*prover.ProverMessage_GetProofResponse).GetProofResponse
+}
+
+func genBatchProof(p *Prover, oldBatchNum uint64) string {
+	res := p.handle(&prover.AggregatorMessage{
+		Id: "genBatchProof",
+		Request: &prover.AggregatorMessage_GenBatchProofRequest{GenBatchProofRequest: &prover.GenBatchProofRequest{
+			Input: &prover.InputProver{PublicInputs: &prover.PublicInputs{OldBatchNum: oldBatchNum}},
+		}},
+	})
+	return res.Response.(*prover.ProverMessage_GenBatchProofResponse).GenBatchProofResponse.Id
+}
+
+func TestMockProverLatency(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := New(Config{Name: "mock", ID: "mock1", ForkID: 9, Latency: time.Minute})
+	p.now = func() time.Time { return now }
+
+	id := genBatchProof(p, 1)
+	assert.Equal(t, "mock1-1", id)
+	assert.Equal(t, prover.GetProofResponse_RESULT_PENDING, getProof(p, id).Result)
+
+	status := p.handle(&prover.AggregatorMessage{
+		Request: &prover.AggregatorMessage_GetStatusRequest{GetStatusRequest: &prover.GetStatusRequest{}},
+	}).Response.(*prover.ProverMessage_GetStatusResponse).GetStatusResponse
+	assert.Equal(t, prover.GetStatusResponse_STATUS_COMPUTING, status.Status)
+	assert.Equal(t, uint64(9), status.ForkId)
+	assert.Equal(t, "mock", status.ProverName)
+
+	now = now.Add(time.Minute)
+	res := getProof(p, id)
+	require.Equal(t, prover.GetProofResponse_RESULT_COMPLETED_OK, res.Result)
+	proof := res.Proof.(*prover.GetProofResponse_RecursiveProof).RecursiveProof
+
+	// the proofs are deterministic
+	other := New(Config{ID: "mock2"})
+	otherID := genBatchProof(other, 1)
+	assert.Equal(t, proof, getProof(other, otherID).Proof.(*prover.GetProofResponse_RecursiveProof).RecursiveProof)
+	otherID = genBatchProof(other, 2)
+	assert.NotEqual(t, proof, getProof(other, otherID).Proof.(*prover.GetProofResponse_RecursiveProof).RecursiveProof)
+
+	// finished proofs are forgotten
+	assert.Equal(t, prover.GetProofResponse_RESULT_ERROR, getProof(p, id).Result)
+}
+
+func TestMockProverFailuresAndCancel(t *testing.T) {
+	failing := New(Config{ID: "failing", FailureRate: 1})
+	assert.Equal(t, prover.GetProofResponse_RESULT_COMPLETED_ERROR, getProof(failing, genBatchProof(failing, 1)).Result)
+
+	// the same seed fails the same proofs
+	failures := func() []bool {
+		p := New(Config{ID: "mock", FailureRate: 0.5, Seed: 42})
+		res := []bool{}
+		for i := uint64(0); i < 20; i++ {
+			res = append(res, getProof(p, genBatchProof(p, i)).Result == prover.GetProofResponse_RESULT_COMPLETED_ERROR)
+		}
+		return res
+	}
+	first := failures()
+	assert.Equal(t, first, failures())
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+
+	p := New(Config{ID: "mock", Latency: time.Hour})
+	id := genBatchProof(p, 1)
+	cancel := func(id string) prover.Result {
+		res := p.handle(&prover.AggregatorMessage{
+			Request: &prover.AggregatorMessage_CancelRequest{CancelRequest: &prover.CancelRequest{Id: id}},
+		})
+		return res.Response.(*prover.ProverMessage_CancelResponse).CancelResponse.Result
+	}
+	assert.Equal(t, prover.Result_RESULT_OK, cancel(id))
+	assert.Equal(t, prover.Result_RESULT_ERROR, cancel("unknown"))
+	assert.Equal(t, prover.GetProofResponse_RESULT_CANCEL, getProof(p, id).Result)
+}
+
+// aggregatorServer runs the proving pipeline on the first prover connected
+type aggregatorServer struct {
+	prover.UnimplementedAggregatorServiceServer
+	t    *testing.T
+	done chan *prover.FinalProof
+}
+
+func (s *aggregatorServer) Channel(stream prover.AggregatorService_ChannelServer) error {
+	ctx := stream.Context()
+	p, err := prover.New(stream, nil, types.NewDuration(time.Millisecond))
+	require.NoError(s.t, err)
+	forkID, err := p.ForkID()
+	require.NoError(s.t, err)
+	assert.Equal(s.t, uint64(9), forkID)
+
+	recursiveProofs := []string{}
+	for i := uint64(0); i < 2; i++ {
+		id, err := p.BatchProof(&prover.InputProver{PublicInputs: &prover.PublicInputs{OldBatchNum: i}})
+		require.NoError(s.t, err)
+		proof, err := p.WaitRecursiveProof(ctx, *id)
+		require.NoError(s.t, err)
+		recursiveProofs = append(recursiveProofs, proof)
+	}
+
+	id, err := p.AggregatedProof(recursiveProofs[0], recursiveProofs[1])
+	require.NoError(s.t, err)
+	aggregatedProof, err := p.WaitRecursiveProof(ctx, *id)
+	require.NoError(s.t, err)
+
+	id, err = p.FinalProof(aggregatedProof, "0x1234")
+	require.NoError(s.t, err)
+	finalProof, err := p.WaitFinalProof(ctx, *id)
+	require.NoError(s.t, err)
+	s.done <- finalProof
+	return nil
+}
+
+func TestMockProverPipeline(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	aggregator := &aggregatorServer{t: t, done: make(chan *prover.FinalProof, 1)}
+	prover.RegisterAggregatorServiceServer(srv, aggregator)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	stream, err := prover.NewAggregatorServiceClient(conn).Channel(ctx)
+	require.NoError(t, err)
+
+	p := New(Config{Name: "mock", ID: "mock1", ForkID: 9, Latency: 5 * time.Millisecond})
+	go func() { _ = p.Run(ctx, stream) }()
+
+	select {
+	case finalProof := <-aggregator.done:
+		// the length of the proof is the one expected by the rollup contract
+		assert.Len(t, finalProof.Proof, 2+finalProofWords*64)
+		assert.Equal(t, MockedStateRoot, string(finalProof.Public.NewStateRoot))
+		assert.Equal(t, MockedLocalExitRoot, string(finalProof.Public.NewLocalExitRoot))
+	case <-ctx.Done():
+		t.Fatal("the proving pipeline didn't finish")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/aggregator/metrics"
@@ -29,6 +30,9 @@ type Prover struct {
 	address                   net.Addr
 	proofStatePollingInterval types.Duration
 	stream                    AggregatorService_ChannelServer
+	// callMu serializes the request/response pairs sent through the stream, as
+	// requests like cancellations can be made concurrently to the proving loop
+	callMu sync.Mutex
 }
 
 // New returns a new Prover instance.
@@ -87,6 +91,15 @@ func (p *Prover) IsIdle() (bool, error) {
 	return status.Status == GetStatusResponse_STATUS_IDLE, nil
 }
 
+// ForkID returns the fork id the prover supports.
+func (p *Prover) ForkID() (uint64, error) {
+	status, err := p.Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.ForkId, nil
+}
+
 // SupportsForkID returns true if the prover supports the given fork id.
 func (p *Prover) SupportsForkID(forkID uint64) bool {
 	status, err := p.Status()
@@ -325,6 +338,8 @@ func (p *Prover) waitProof(ctx context.Context, proofID string) (*GetProofRespon
 // call sends a message to the prover and waits to receive the response over
 // the connection stream.
 func (p *Prover) call(req *AggregatorMessage) (*ProverMessage, error) {
+	p.callMu.Lock()
+	defer p.callMu.Unlock()
 	if err := p.stream.Send(req); err != nil {
 		return nil, err
 	}
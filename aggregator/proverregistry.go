@@ -0,0 +1,347 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+// proverRegistry keeps track of the provers that have connected to the aggregator, persisting
+// their capabilities and performance so the work is scheduled on the provers best suited to it
+type proverRegistry struct {
+	storage proverStorage
+	mu      sync.RWMutex
+	provers map[string]*prover.Info
+	// conns are the streams of the connected provers, used to send them requests out of the proving loop
+	conns map[string]proverInterface
+}
+
+func newProverRegistry(storage proverStorage) *proverRegistry {
+	return &proverRegistry{
+		storage: storage,
+		provers: map[string]*prover.Info{},
+		conns:   map[string]proverInterface{},
+	}
+}
+
+// load reads the provers persisted in previous executions, all of them disconnected
+func (r *proverRegistry) load(ctx context.Context) error {
+	provers, err := r.storage.GetProvers(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range provers {
+		p := provers[i]
+		p.Connected = false
+		p.Busy = false
+		r.provers[p.ID] = &p
+	}
+	return nil
+}
+
+// connect registers a prover that has opened a stream with the aggregator
+func (r *proverRegistry) connect(ctx context.Context, id, name, addr string, forkID uint64, conn proverInterface) {
+	r.mu.Lock()
+	now := time.Now().Round(time.Microsecond)
+	p, found := r.provers[id]
+	if !found {
+		p = &prover.Info{ID: id, FirstSeenAt: now, Stats: map[prover.ProofType]prover.ProofStats{}}
+		r.provers[id] = p
+	}
+	p.Name = name
+	p.Addr = addr
+	p.Connected = true
+	p.Busy = false
+	p.CurrentProof = nil
+	p.LastSeenAt = now
+	if conn != nil {
+		r.conns[id] = conn
+	}
+	if !p.SupportsForkID(forkID) {
+		p.ForkIDs = append(p.ForkIDs, forkID)
+	}
+	info := p.Copy()
+	r.mu.Unlock()
+
+	if err := r.storage.AddOrUpdateProver(ctx, info, nil); err != nil {
+		log.Errorf("Failed to persist prover %s: %v", id, err)
+	}
+}
+
+// disconnect marks a prover as disconnected
+func (r *proverRegistry) disconnect(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.conns, id)
+	if p, found := r.provers[id]; found {
+		p.Connected = false
+		p.Busy = false
+		p.CurrentProof = nil
+		p.LastSeenAt = time.Now().Round(time.Microsecond)
+	}
+}
+
+// setBusy sets if a prover is generating a proof, forgetting the proof it was generating when it becomes idle
+func (r *proverRegistry) setBusy(id string, busy bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p, found := r.provers[id]; found {
+		p.Busy = busy
+		if !busy {
+			p.CurrentProof = nil
+		}
+	}
+}
+
+// setCurrentProof sets the proof a prover is generating
+func (r *proverRegistry) setCurrentProof(id string, proof prover.InFlightProof) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p, found := r.provers[id]; found {
+		p.CurrentProof = &proof
+	}
+}
+
+// assignBatches sets the range of batches covered by a proof being generated
+func (r *proverRegistry) assignBatches(proofID string, batchNumber, batchNumberFinal uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.provers {
+		if p.CurrentProof != nil && p.CurrentProof.ID == proofID {
+			p.CurrentProof.BatchNumber = batchNumber
+			p.CurrentProof.BatchNumberFinal = batchNumberFinal
+		}
+	}
+}
+
+// cancelProof asks the connected prover generating the proof to cancel it.
+// It returns false if no connected prover is generating the proof
+func (r *proverRegistry) cancelProof(proofID string) (bool, error) {
+	r.mu.RLock()
+	var conn proverInterface
+	for id, p := range r.provers {
+		if p.CurrentProof != nil && p.CurrentProof.ID == proofID {
+			conn = r.conns[id]
+			break
+		}
+	}
+	r.mu.RUnlock()
+
+	if conn == nil {
+		return false, nil
+	}
+	return true, conn.CancelProofRequest(proofID)
+}
+
+// overlapping returns the ids of the proofs being generated that cover any batch of the range
+func (r *proverRegistry) overlapping(batchNumber, batchNumberFinal uint64) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	proofIDs := []string{}
+	for _, p := range r.provers {
+		current := p.CurrentProof
+		if !p.Connected || current == nil || current.BatchNumberFinal == 0 {
+			continue
+		}
+		if current.BatchNumber <= batchNumberFinal && batchNumber <= current.BatchNumberFinal {
+			proofIDs = append(proofIDs, current.ID)
+		}
+	}
+	sort.Strings(proofIDs)
+	return proofIDs
+}
+
+// assignedTo returns the connected prover generating a proof that covers the range of batches
+func (r *proverRegistry) assignedTo(batchNumber, batchNumberFinal uint64) (prover.Info, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.provers {
+		if p.Connected && p.CurrentProof != nil && p.CurrentProof.Covers(batchNumber, batchNumberFinal) {
+			return p.Copy(), true
+		}
+	}
+	return prover.Info{}, false
+}
+
+// recordProof updates the stats of a prover with the result of a proof
+func (r *proverRegistry) recordProof(ctx context.Context, id string, proofType prover.ProofType, duration time.Duration, failed bool) {
+	r.mu.Lock()
+	p, found := r.provers[id]
+	if !found {
+		r.mu.Unlock()
+		return
+	}
+	stats := p.Stats[proofType]
+	if failed {
+		stats.Failures++
+	} else {
+		stats.Proofs++
+		stats.TotalDuration += duration
+		stats.LastDuration = duration
+	}
+	p.Stats[proofType] = stats
+	name := p.Name
+	r.mu.Unlock()
+
+	if !failed {
+		observeProofTime(name, proofType, duration)
+	}
+
+	if err := r.storage.UpdateProverStats(ctx, id, proofType, stats, nil); err != nil {
+		log.Errorf("Failed to persist stats of prover %s: %v", id, err)
+	}
+}
+
+// isBestSuited returns false if there is another connected and idle prover supporting the fork id
+// that is expected to generate the proof faster
+func (r *proverRegistry) isBestSuited(id string, proofType prover.ProofType, forkID uint64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, found := r.provers[id]
+	if !found {
+		return true
+	}
+	score := p.Stats[proofType].Score()
+	for _, other := range r.provers {
+		if other.ID == id || !other.Connected || other.Busy || !other.SupportsForkID(forkID) {
+			continue
+		}
+		if other.Stats[proofType].Score() < score {
+			return false
+		}
+	}
+	return true
+}
+
+// get returns a prover by its id
+func (r *proverRegistry) get(id string) (prover.Info, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, found := r.provers[id]
+	if !found {
+		return prover.Info{}, false
+	}
+	return p.Copy(), true
+}
+
+// list returns all the provers ordered by id
+func (r *proverRegistry) list() []prover.Info {
+	r.mu.RLock()
+	provers := make([]prover.Info, 0, len(r.provers))
+	for _, p := range r.provers {
+		provers = append(provers, p.Copy())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(provers, func(i, j int) bool { return provers[i].ID < provers[j].ID })
+	return provers
+}
+
+// trackedProver wraps a prover to record in the registry the time it takes to generate each proof
+type trackedProver struct {
+	proverInterface
+	registry *proverRegistry
+	ctx      context.Context
+
+	mu      sync.Mutex
+	pending map[string]pendingProof
+}
+
+type pendingProof struct {
+	proofType prover.ProofType
+	startedAt time.Time
+}
+
+func newTrackedProver(ctx context.Context, p proverInterface, registry *proverRegistry) *trackedProver {
+	return &trackedProver{
+		proverInterface: p,
+		registry:        registry,
+		ctx:             ctx,
+		pending:         map[string]pendingProof{},
+	}
+}
+
+// BatchProof requests a batch proof to the prover
+func (t *trackedProver) BatchProof(input *prover.InputProver) (*string, error) {
+	return t.request(prover.ProofTypeBatch, func() (*string, error) { return t.proverInterface.BatchProof(input) })
+}
+
+// AggregatedProof requests an aggregated proof to the prover
+func (t *trackedProver) AggregatedProof(inputProof1, inputProof2 string) (*string, error) {
+	return t.request(prover.ProofTypeAggregated, func() (*string, error) { return t.proverInterface.AggregatedProof(inputProof1, inputProof2) })
+}
+
+// FinalProof requests a final proof to the prover
+func (t *trackedProver) FinalProof(inputProof string, aggregatorAddr string) (*string, error) {
+	return t.request(prover.ProofTypeFinal, func() (*string, error) { return t.proverInterface.FinalProof(inputProof, aggregatorAddr) })
+}
+
+// WaitRecursiveProof waits for a batch or aggregated proof
+func (t *trackedProver) WaitRecursiveProof(ctx context.Context, proofID string) (string, error) {
+	proof, err := t.proverInterface.WaitRecursiveProof(ctx, proofID)
+	t.done(proofID, err)
+	return proof, err
+}
+
+// WaitFinalProof waits for a final proof
+func (t *trackedProver) WaitFinalProof(ctx context.Context, proofID string) (*prover.FinalProof, error) {
+	proof, err := t.proverInterface.WaitFinalProof(ctx, proofID)
+	t.done(proofID, err)
+	return proof, err
+}
+
+func (t *trackedProver) request(proofType prover.ProofType, fn func() (*string, error)) (*string, error) {
+	startedAt := time.Now()
+	t.registry.setBusy(t.ID(), true)
+	proofID, err := fn()
+	if err != nil {
+		t.registry.setBusy(t.ID(), false)
+		t.registry.recordProof(t.ctx, t.ID(), proofType, 0, true)
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.pending[*proofID] = pendingProof{proofType: proofType, startedAt: startedAt}
+	t.mu.Unlock()
+	t.registry.setCurrentProof(t.ID(), prover.InFlightProof{ID: *proofID, Type: proofType, StartedAt: startedAt})
+	return proofID, nil
+}
+
+func (t *trackedProver) done(proofID string, err error) {
+	t.mu.Lock()
+	pending, found := t.pending[proofID]
+	delete(t.pending, proofID)
+	t.mu.Unlock()
+
+	t.registry.setBusy(t.ID(), false)
+	if !found {
+		return
+	}
+	// canceled proofs say nothing about the performance of the prover
+	if errors.Is(err, context.Canceled) || errors.Is(err, prover.ErrProofCanceled) {
+		metrics.CanceledProof()
+		return
+	}
+	t.registry.recordProof(t.ctx, t.ID(), pending.proofType, time.Since(pending.startedAt), err != nil)
+}
+
+// observeProofTime records the time taken by a prover to generate a proof in the metrics
+func observeProofTime(proverName string, proofType prover.ProofType, duration time.Duration) {
+	switch proofType {
+	case prover.ProofTypeBatch:
+		metrics.BatchProofTime(proverName, duration)
+	case prover.ProofTypeAggregated:
+		metrics.AggregatedProofTime(proverName, duration)
+	case prover.ProofTypeFinal:
+		metrics.FinalProofTime(proverName, duration)
+	}
+}
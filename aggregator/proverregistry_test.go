@@ -0,0 +1,166 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProverRegistryIsBestSuited(t *testing.T) {
+	ctx := context.Background()
+	storageMock := mocks.NewProverStorageMock(t)
+	storageMock.On("AddOrUpdateProver", ctx, mock.Anything, nil).Return(nil)
+	storageMock.On("UpdateProverStats", ctx, mock.Anything, mock.Anything, mock.Anything, nil).Return(nil)
+
+	registry := newProverRegistry(storageMock)
+	registry.connect(ctx, "fast", "fast", "", forkId9, nil)
+	registry.connect(ctx, "slow", "slow", "", forkId9, nil)
+	registry.connect(ctx, "oldFork", "oldFork", "", forkId9-1, nil)
+
+	// without history every prover is suited
+	assert.True(t, registry.isBestSuited("fast", prover.ProofTypeFinal, forkId9))
+	assert.True(t, registry.isBestSuited("slow", prover.ProofTypeFinal, forkId9))
+
+	registry.recordProof(ctx, "fast", prover.ProofTypeFinal, time.Minute, false)
+	registry.recordProof(ctx, "slow", prover.ProofTypeFinal, 3*time.Minute, false)
+	registry.recordProof(ctx, "oldFork", prover.ProofTypeFinal, time.Second, false)
+	assert.True(t, registry.isBestSuited("fast", prover.ProofTypeFinal, forkId9))
+	assert.False(t, registry.isBestSuited("slow", prover.ProofTypeFinal, forkId9))
+	// the stats of other proof types aren't taken into account
+	assert.True(t, registry.isBestSuited("slow", prover.ProofTypeAggregated, forkId9))
+
+	// failures increase the expected time to get a proof
+	registry.recordProof(ctx, "fast", prover.ProofTypeFinal, 0, true)
+	registry.recordProof(ctx, "fast", prover.ProofTypeFinal, 0, true)
+	registry.recordProof(ctx, "fast", prover.ProofTypeFinal, 0, true)
+	assert.False(t, registry.isBestSuited("fast", prover.ProofTypeFinal, forkId9))
+	assert.True(t, registry.isBestSuited("slow", prover.ProofTypeFinal, forkId9))
+
+	// busy and disconnected provers don't take the work of the others
+	registry.setBusy("slow", true)
+	assert.True(t, registry.isBestSuited("fast", prover.ProofTypeFinal, forkId9))
+	registry.setBusy("slow", false)
+	registry.disconnect("slow")
+	assert.True(t, registry.isBestSuited("fast", prover.ProofTypeFinal, forkId9))
+
+	slow, found := registry.get("slow")
+	require.True(t, found)
+	assert.False(t, slow.Connected)
+	assert.Equal(t, prover.ProofStats{Proofs: 1, TotalDuration: 3 * time.Minute, LastDuration: 3 * time.Minute}, slow.Stats[prover.ProofTypeFinal])
+}
+
+func TestProverRegistryLoad(t *testing.T) {
+	ctx := context.Background()
+	storageMock := mocks.NewProverStorageMock(t)
+	stats := prover.ProofStats{Proofs: 2, Failures: 1, TotalDuration: 4 * time.Minute, LastDuration: time.Minute}
+	storageMock.On("GetProvers", ctx, nil).Return([]prover.Info{
+		{ID: "p1", Name: "prover1", ForkIDs: []uint64{forkId9}, Connected: true, Stats: map[prover.ProofType]prover.ProofStats{prover.ProofTypeBatch: stats}},
+	}, nil).Once()
+	storageMock.On("AddOrUpdateProver", ctx, mock.MatchedBy(func(p prover.Info) bool {
+		return p.ID == "p1" && p.Connected && p.Addr == "127.0.0.1:1234" && len(p.ForkIDs) == 1
+	}), nil).Return(nil).Once()
+
+	registry := newProverRegistry(storageMock)
+	require.NoError(t, registry.load(ctx))
+
+	provers := registry.list()
+	require.Len(t, provers, 1)
+	assert.False(t, provers[0].Connected)
+	assert.Equal(t, 2*time.Minute, provers[0].Stats[prover.ProofTypeBatch].AverageDuration())
+
+	registry.connect(ctx, "p1", "prover1", "127.0.0.1:1234", forkId9, nil)
+	p1, found := registry.get("p1")
+	require.True(t, found)
+	assert.True(t, p1.Connected)
+	assert.Equal(t, stats, p1.Stats[prover.ProofTypeBatch])
+}
+
+func TestTrackedProver(t *testing.T) {
+	ctx := context.Background()
+	errBanana := errors.New("banana")
+	proofID := "proofId"
+	storageMock := mocks.NewProverStorageMock(t)
+	proverMock := mocks.NewProverMock(t)
+	proverMock.On("ID").Return("p1")
+	storageMock.On("AddOrUpdateProver", ctx, mock.Anything, nil).Return(nil).Once()
+
+	registry := newProverRegistry(storageMock)
+	registry.connect(ctx, "p1", "prover1", "", forkId9, nil)
+	tracked := newTrackedProver(ctx, proverMock, registry)
+
+	proverMock.On("BatchProof", mock.Anything).Return(&proofID, nil).Once()
+	proverMock.On("WaitRecursiveProof", ctx, proofID).Return("proof", nil).Once()
+	storageMock.On("UpdateProverStats", ctx, "p1", prover.ProofTypeBatch, mock.MatchedBy(func(stats prover.ProofStats) bool {
+		return stats.Proofs == 1 && stats.Failures == 0
+	}), nil).Return(nil).Once()
+
+	id, err := tracked.BatchProof(&prover.InputProver{})
+	require.NoError(t, err)
+	p1, _ := registry.get("p1")
+	assert.True(t, p1.Busy)
+	proof, err := tracked.WaitRecursiveProof(ctx, *id)
+	require.NoError(t, err)
+	assert.Equal(t, "proof", proof)
+	p1, _ = registry.get("p1")
+	assert.False(t, p1.Busy)
+
+	proverMock.On("FinalProof", "proof", "aggregator").Return(nil, errBanana).Once()
+	storageMock.On("UpdateProverStats", ctx, "p1", prover.ProofTypeFinal, prover.ProofStats{Failures: 1}, nil).Return(nil).Once()
+
+	_, err = tracked.FinalProof("proof", "aggregator")
+	require.ErrorIs(t, err, errBanana)
+	p1, _ = registry.get("p1")
+	assert.False(t, p1.Busy)
+}
+
+func TestAPIProvers(t *testing.T) {
+	ctx := context.Background()
+	storageMock := mocks.NewProverStorageMock(t)
+	storageMock.On("AddOrUpdateProver", ctx, mock.Anything, nil).Return(nil)
+
+	a := Aggregator{proverRegistry: newProverRegistry(storageMock)}
+	a.proverRegistry.connect(ctx, "p2", "prover2", "", forkId9, nil)
+	a.proverRegistry.connect(ctx, "p1", "prover1", "", forkId9, nil)
+	srv := httptest.NewServer(a.newAPIHandler())
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + ProversEndpoint)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var provers []prover.Info
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&provers))
+	require.Len(t, provers, 2)
+	assert.Equal(t, "p1", provers[0].ID)
+	assert.Equal(t, "p2", provers[1].ID)
+	assert.Equal(t, []uint64{forkId9}, provers[0].ForkIDs)
+
+	res, err = http.Get(srv.URL + ProversEndpoint + "/p2")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var p2 prover.Info
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&p2))
+	assert.Equal(t, "prover2", p2.Name)
+	assert.True(t, p2.Connected)
+
+	res, err = http.Get(srv.URL + ProversEndpoint + "/unknown")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res, err = http.Post(srv.URL+ProversEndpoint, "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
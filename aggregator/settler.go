@@ -0,0 +1,444 @@
+package aggregator
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/agglayer/client"
+	ethmanTypes "github.com/0xPolygonHermez/zkevm-node/etherman/types"
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// Settler settles final proofs in a sink of the multi settlement backend
+type Settler interface {
+	// Name returns the sink the settler sends the final proofs to
+	Name() string
+	// Settle sends the final proof to the sink, returning once it's settled
+	Settle(ctx context.Context, proof *state.Proof, inputs ethmanTypes.FinalProofInputs) error
+}
+
+// SinkStatus is the settlement status of a final proof in a sink
+type SinkStatus struct {
+	Sink      string     `json:"sink"`
+	Required  bool       `json:"required"`
+	Settled   bool       `json:"settled"`
+	Attempts  uint64     `json:"attempts"`
+	LastError string     `json:"lastError,omitempty"`
+	SettledAt *time.Time `json:"settledAt,omitempty"`
+
+	running bool
+}
+
+// MultiSettlementStatus is the settlement status of the last final proof sent by the multi settlement backend
+type MultiSettlementStatus struct {
+	BatchNumber      uint64       `json:"batchNumber"`
+	BatchNumberFinal uint64       `json:"batchNumberFinal"`
+	Settled          bool         `json:"settled"`
+	Sinks            []SinkStatus `json:"sinks"`
+}
+
+// multiSettler sends the final proofs in parallel to several sinks, retrying each one on its own.
+// A proof is settled once all the required sinks have settled it
+type multiSettler struct {
+	settlers      []Settler
+	required      map[string]bool
+	maxRetries    uint64
+	retryInterval time.Duration
+
+	mu   sync.Mutex
+	last *MultiSettlementStatus
+}
+
+func newMultiSettler(cfg MultiSettlementConfig, settlers []Settler) (*multiSettler, error) {
+	if len(settlers) == 0 {
+		return nil, errors.New("no settlement sinks configured")
+	}
+	names := make(map[string]bool, len(settlers))
+	for _, settler := range settlers {
+		if names[settler.Name()] {
+			return nil, fmt.Errorf("duplicated settlement sink %s", settler.Name())
+		}
+		names[settler.Name()] = true
+	}
+	// the agglayer verifies the batches on L1 as well, so one of the verifications would revert
+	if names[string(SinkL1)] && names[string(SinkAggLayer)] {
+		return nil, fmt.Errorf("the %s and %s settlement sinks can't be used together", SinkL1, SinkAggLayer)
+	}
+
+	required := make(map[string]bool, len(settlers))
+	for _, sink := range cfg.Required {
+		if !names[string(sink)] {
+			return nil, fmt.Errorf("required settlement sink %s isn't a configured sink", sink)
+		}
+		required[string(sink)] = true
+	}
+	if len(required) == 0 {
+		required = names
+	}
+	// the batches are only verified, and their proofs cleaned up, once the proof is settled on L1 or in the AggLayer
+	if !required[string(SinkL1)] && !required[string(SinkAggLayer)] {
+		return nil, fmt.Errorf("the required settlement sinks must include the %s or the %s sink", SinkL1, SinkAggLayer)
+	}
+
+	return &multiSettler{
+		settlers:      settlers,
+		required:      required,
+		maxRetries:    cfg.MaxRetries,
+		retryInterval: cfg.RetryInterval.Duration,
+	}, nil
+}
+
+// settle sends the final proof to the sinks that haven't settled it yet, waiting for the required ones.
+// The sinks that settled the proof in a previous call are skipped, so the proof isn't sent twice to them
+func (m *multiSettler) settle(ctx context.Context, proof *state.Proof, inputs ethmanTypes.FinalProofInputs) error {
+	// the optional sinks may outlive the call, so they don't share the proof with the caller
+	proofCopy := *proof
+
+	m.mu.Lock()
+	last := m.last
+	if last == nil || last.BatchNumber != proof.BatchNumber || last.BatchNumberFinal != proof.BatchNumberFinal {
+		last = &MultiSettlementStatus{
+			BatchNumber:      proof.BatchNumber,
+			BatchNumberFinal: proof.BatchNumberFinal,
+			Sinks:            make([]SinkStatus, len(m.settlers)),
+		}
+		for i, settler := range m.settlers {
+			last.Sinks[i] = SinkStatus{Sink: settler.Name(), Required: m.required[settler.Name()]}
+		}
+		m.last = last
+	}
+
+	var wg sync.WaitGroup
+	for i, settler := range m.settlers {
+		status := &last.Sinks[i]
+		if status.Settled || status.running {
+			continue
+		}
+		status.running = true
+		if status.Required {
+			wg.Add(1)
+		}
+		go func(settler Settler, status *SinkStatus) {
+			if status.Required {
+				defer wg.Done()
+			}
+			m.settleInSink(ctx, settler, status, &proofCopy, inputs)
+		}(settler, status)
+	}
+	m.mu.Unlock()
+
+	wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	unsettled := []string{}
+	for _, status := range last.Sinks {
+		if status.Required && !status.Settled {
+			unsettled = append(unsettled, fmt.Sprintf("%s: %s", status.Sink, status.LastError))
+		}
+	}
+	if len(unsettled) > 0 {
+		return fmt.Errorf("final proof not settled in the required sinks [%s]", strings.Join(unsettled, ", "))
+	}
+	last.Settled = true
+	return nil
+}
+
+// settleInSink sends the final proof to a sink, retrying up to maxRetries times
+func (m *multiSettler) settleInSink(ctx context.Context, settler Settler, status *SinkStatus, proof *state.Proof, inputs ethmanTypes.FinalProofInputs) {
+	log := log.WithFields("sink", settler.Name(), "batches", fmt.Sprintf("%d-%d", proof.BatchNumber, proof.BatchNumberFinal))
+	defer func() {
+		m.mu.Lock()
+		status.running = false
+		m.mu.Unlock()
+	}()
+
+	for attempt := uint64(0); ; attempt++ {
+		err := settler.Settle(ctx, proof, inputs)
+
+		m.mu.Lock()
+		status.Attempts++
+		if err == nil {
+			now := time.Now()
+			status.Settled = true
+			status.SettledAt = &now
+			status.LastError = ""
+		} else {
+			status.LastError = err.Error()
+		}
+		m.mu.Unlock()
+
+		if err == nil {
+			log.Info("Final proof settled")
+			return
+		}
+		log.Warnf("Failed to settle final proof, attempt %d: %v", attempt+1, err)
+		if attempt >= m.maxRetries {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(m.retryInterval):
+		}
+	}
+}
+
+// status returns the settlement status of the last final proof, nil if none has been sent
+func (m *multiSettler) status() *MultiSettlementStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.last == nil {
+		return nil
+	}
+	status := *m.last
+	status.Sinks = append([]SinkStatus{}, m.last.Sinks...)
+	return &status
+}
+
+// newMultiSettler builds the settlers of the sinks of the multi settlement backend
+func (a *Aggregator) newMultiSettler() (*multiSettler, error) {
+	cfg := a.cfg.MultiSettlement
+	settlers := make([]Settler, 0, len(cfg.Sinks))
+	for _, sink := range cfg.Sinks {
+		switch sink {
+		case SinkL1:
+			settlers = append(settlers, &l1Settler{
+				ethTxManager: a.EthTxManager,
+				etherman:     a.Ethman,
+				sender:       common.HexToAddress(a.cfg.SenderAddress),
+				gasOffset:    a.cfg.GasOffset,
+				onConfirmed:  a.handleMonitoredTxResult,
+			})
+		case SinkAggLayer:
+			if a.AggLayerClient == nil || a.sequencerPrivateKey == nil {
+				return nil, errors.New("the agglayer settlement sink requires the agglayer client and the sequencer private key")
+			}
+			settlers = append(settlers, &aggLayerSettler{
+				client:     a.AggLayerClient,
+				etherman:   a.Ethman,
+				privateKey: a.sequencerPrivateKey,
+				timeout:    a.cfg.AggLayerTxTimeout.Duration,
+			})
+		case SinkBTC:
+			settlers = append(settlers, &btcSettler{btcman: a.Btcman, onAnchored: a.btcAnchored})
+		case SinkWebhook:
+			if cfg.WebhookURL == "" {
+				return nil, errors.New("the webhook settlement sink requires a webhook URL")
+			}
+			settlers = append(settlers, &webhookSettler{
+				url:    cfg.WebhookURL,
+				client: &http.Client{Timeout: cfg.WebhookTimeout.Duration},
+			})
+		default:
+			return nil, fmt.Errorf("unknown settlement sink %s", sink)
+		}
+	}
+	return newMultiSettler(cfg, settlers)
+}
+
+// l1Settler sends the final proof to the rollup contract on L1 through the eth tx manager
+type l1Settler struct {
+	ethTxManager ethTxManager
+	etherman     etherman
+	sender       common.Address
+	gasOffset    uint64
+	// onConfirmed handles the confirmed verifications, waiting for the network to sync them
+	onConfirmed func(ethtxmanager.MonitoredTxResult)
+
+	// attempts counts the verification txs sent for the last final proof, so every retry sends a new tx
+	mu          sync.Mutex
+	lastProofID string
+	attempts    uint64
+}
+
+// Name returns the l1 sink
+func (s *l1Settler) Name() string {
+	return string(SinkL1)
+}
+
+// Settle adds the batch verification to the eth tx manager and waits for it to be confirmed
+func (s *l1Settler) Settle(ctx context.Context, proof *state.Proof, inputs ethmanTypes.FinalProofInputs) error {
+	to, data, err := s.etherman.BuildTrustedVerifyBatchesTxData(proof.BatchNumber-1, proof.BatchNumberFinal, &inputs, s.sender)
+	if err != nil {
+		return fmt.Errorf("failed to build batch verification tx data: %w", err)
+	}
+
+	// a tx added before a restart is still monitored, so it's found instead of added again
+	monitoredTxID := s.nextMonitoredTxID(proof)
+	err = s.ethTxManager.Add(ctx, ethTxManagerOwner, monitoredTxID, s.sender, to, nil, data, s.gasOffset, nil)
+	if err != nil && !errors.Is(err, ethtxmanager.ErrAlreadyExists) {
+		return fmt.Errorf("failed to add batch verification tx to eth tx manager: %w", err)
+	}
+
+	var status ethtxmanager.MonitoredTxStatus
+	s.ethTxManager.ProcessPendingMonitoredTxs(ctx, ethTxManagerOwner, func(result ethtxmanager.MonitoredTxResult, dbTx pgx.Tx) {
+		if result.ID == monitoredTxID {
+			status = result.Status
+		}
+		if result.Status == ethtxmanager.MonitoredTxStatusConfirmed {
+			s.onConfirmed(result)
+			return
+		}
+
+		// the failed tx is set as done, otherwise the eth tx manager keeps handling it and never returns
+		mTxResultLogger := ethtxmanager.CreateMonitoredTxResultLogger(ethTxManagerOwner, result)
+		mTxResultLogger.Warn("batch verification tx failed")
+		if err := s.ethTxManager.SetStatusDone(ctx, ethTxManagerOwner, result.ID, dbTx); err != nil {
+			// the failed monitored tx will be handled again in the next cycle
+			mTxResultLogger.Errorf("failed to set monitored tx as done, err: %v", err)
+		}
+	}, nil)
+
+	if status != ethtxmanager.MonitoredTxStatusConfirmed {
+		return fmt.Errorf("batch verification tx %s not confirmed, status: %s", monitoredTxID, status)
+	}
+	return nil
+}
+
+// nextMonitoredTxID returns the monitored tx ID of the next attempt to verify the final proof.
+// The first attempt uses the ID of the proof, the retries add the attempt to it
+func (s *l1Settler) nextMonitoredTxID(proof *state.Proof) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proofID := buildMonitoredTxID(proof.BatchNumber, proof.BatchNumberFinal)
+	if proofID != s.lastProofID {
+		s.lastProofID = proofID
+		s.attempts = 0
+	}
+	s.attempts++
+	if s.attempts == 1 {
+		return proofID
+	}
+	return fmt.Sprintf("%s-attempt-%d", proofID, s.attempts)
+}
+
+// aggLayerSettler sends the final proof to the AggLayer
+type aggLayerSettler struct {
+	client     client.ClientInterface
+	etherman   etherman
+	privateKey *ecdsa.PrivateKey
+	timeout    time.Duration
+}
+
+// Name returns the agglayer sink
+func (s *aggLayerSettler) Name() string {
+	return string(SinkAggLayer)
+}
+
+// Settle sends the signed final proof to the AggLayer and waits for its tx to be mined
+func (s *aggLayerSettler) Settle(ctx context.Context, proof *state.Proof, inputs ethmanTypes.FinalProofInputs) error {
+	tx := newAggLayerTx(s.etherman.GetRollupId(), proof, inputs)
+	signedTx, err := tx.Sign(s.privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign tx: %w", err)
+	}
+
+	txHash, err := s.client.SendTx(*signedTx)
+	if err != nil {
+		return fmt.Errorf("failed to send tx to the agglayer: %w", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	if err := s.client.WaitTxToBeMined(txHash, waitCtx); err != nil {
+		return fmt.Errorf("agglayer didn't mine the tx %s: %w", txHash.Hex(), err)
+	}
+	return nil
+}
+
+// btcSettler anchors the final proof in a Bitcoin inscription
+type btcSettler struct {
+	btcman btcman
+	// onAnchored records the time of the inscription
+	onAnchored func()
+}
+
+// Name returns the btc sink
+func (s *btcSettler) Name() string {
+	return string(SinkBTC)
+}
+
+// Settle inscribes the final proof and checks the inscription can be decoded
+func (s *btcSettler) Settle(ctx context.Context, proof *state.Proof, inputs ethmanTypes.FinalProofInputs) error {
+	message, err := buildInscriptionMessage(inputs)
+	if err != nil {
+		return fmt.Errorf("failed to build inscription message: %w", err)
+	}
+
+	revealTxHash, err := s.btcman.Inscribe(message)
+	if err != nil {
+		return fmt.Errorf("failed to create inscription: %w", err)
+	}
+	if err := s.btcman.DecodeInscription(revealTxHash); err != nil {
+		return fmt.Errorf("failed to decode inscription %s: %w", revealTxHash, err)
+	}
+	if s.onAnchored != nil {
+		s.onAnchored()
+	}
+	return nil
+}
+
+// WebhookSettlement is the body posted by the webhook sink
+type WebhookSettlement struct {
+	BatchNumber      uint64      `json:"batchNumber"`
+	BatchNumberFinal uint64      `json:"batchNumberFinal"`
+	NewStateRoot     common.Hash `json:"newStateRoot"`
+	NewLocalExitRoot common.Hash `json:"newLocalExitRoot"`
+	Proof            string      `json:"proof"`
+}
+
+// webhookSettler posts the final proof to an HTTP endpoint
+type webhookSettler struct {
+	url    string
+	client *http.Client
+}
+
+// Name returns the webhook sink
+func (s *webhookSettler) Name() string {
+	return string(SinkWebhook)
+}
+
+// Settle posts the final proof, which is settled if the endpoint answers with a 2xx status
+func (s *webhookSettler) Settle(ctx context.Context, proof *state.Proof, inputs ethmanTypes.FinalProofInputs) error {
+	body, err := json.Marshal(WebhookSettlement{
+		BatchNumber:      proof.BatchNumber,
+		BatchNumberFinal: proof.BatchNumberFinal,
+		NewStateRoot:     common.BytesToHash(inputs.NewStateRoot),
+		NewLocalExitRoot: common.BytesToHash(inputs.NewLocalExitRoot),
+		Proof:            inputs.FinalProof.Proof,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post final proof to webhook: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook answered with status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	ethmanTypes "github.com/0xPolygonHermez/zkevm-node/etherman/types"
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSettlerMock(t *testing.T, name string) *mocks.SettlerMock {
+	settler := mocks.NewSettlerMock(t)
+	settler.On("Name").Return(name)
+	return settler
+}
+
+func TestNewMultiSettler(t *testing.T) {
+	l1 := newSettlerMock(t, string(SinkL1))
+	webhook := newSettlerMock(t, string(SinkWebhook))
+
+	_, err := newMultiSettler(MultiSettlementConfig{}, nil)
+	assert.Error(t, err)
+
+	_, err = newMultiSettler(MultiSettlementConfig{}, []Settler{l1, l1})
+	assert.ErrorContains(t, err, "duplicated")
+
+	_, err = newMultiSettler(MultiSettlementConfig{Required: []SettlementSink{SinkAggLayer}}, []Settler{l1, webhook})
+	assert.ErrorContains(t, err, "isn't a configured sink")
+
+	_, err = newMultiSettler(MultiSettlementConfig{}, []Settler{l1, newSettlerMock(t, string(SinkAggLayer))})
+	assert.ErrorContains(t, err, "can't be used together")
+
+	_, err = newMultiSettler(MultiSettlementConfig{Required: []SettlementSink{SinkWebhook}}, []Settler{l1, webhook})
+	assert.ErrorContains(t, err, "must include")
+
+	_, err = newMultiSettler(MultiSettlementConfig{}, []Settler{webhook})
+	assert.ErrorContains(t, err, "must include")
+
+	m, err := newMultiSettler(MultiSettlementConfig{}, []Settler{l1, webhook})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{string(SinkL1): true, string(SinkWebhook): true}, m.required)
+	assert.Nil(t, m.status())
+}
+
+func TestMultiSettlerSettle(t *testing.T) {
+	ctx := context.Background()
+	errBanana := errors.New("banana")
+	proof := &state.Proof{BatchNumber: 2, BatchNumberFinal: 5}
+	inputs := ethmanTypes.FinalProofInputs{FinalProof: &prover.FinalProof{Proof: "0x01"}}
+
+	l1 := newSettlerMock(t, string(SinkL1))
+	btc := newSettlerMock(t, string(SinkBTC))
+	webhook := newSettlerMock(t, string(SinkWebhook))
+	cfg := MultiSettlementConfig{
+		Required:      []SettlementSink{SinkL1, SinkBTC},
+		MaxRetries:    1,
+		RetryInterval: types.NewDuration(time.Millisecond),
+	}
+	m, err := newMultiSettler(cfg, []Settler{l1, btc, webhook})
+	require.NoError(t, err)
+
+	// the btc sink doesn't settle after retrying, so the proof isn't settled
+	l1.On("Settle", ctx, mock.Anything, inputs).Return(nil).Once()
+	btc.On("Settle", ctx, mock.Anything, inputs).Return(errBanana).Twice()
+	webhook.On("Settle", ctx, mock.Anything, inputs).Return(errBanana).Twice()
+	err = m.settle(ctx, proof, inputs)
+	require.ErrorContains(t, err, "btc: banana")
+	require.Eventually(t, func() bool {
+		webhookStatus := m.status().Sinks[2]
+		return webhookStatus.Attempts == 2 && !webhookStatus.running
+	}, time.Second, time.Millisecond)
+
+	status := m.status()
+	assert.False(t, status.Settled)
+	assert.Equal(t, uint64(2), status.BatchNumber)
+	assert.Equal(t, uint64(5), status.BatchNumberFinal)
+	assert.True(t, status.Sinks[0].Settled)
+	assert.Equal(t, uint64(1), status.Sinks[0].Attempts)
+	assert.False(t, status.Sinks[1].Settled)
+	assert.Equal(t, uint64(2), status.Sinks[1].Attempts)
+	assert.Equal(t, errBanana.Error(), status.Sinks[1].LastError)
+	assert.False(t, status.Sinks[2].Required)
+
+	// the second time the proof isn't sent again to L1, and the optional webhook doesn't hold the settlement
+	releaseWebhook := make(chan struct{})
+	btc.On("Settle", ctx, mock.Anything, inputs).Return(nil).Once()
+	webhook.On("Settle", ctx, mock.Anything, inputs).Run(func(args mock.Arguments) { <-releaseWebhook }).Return(nil).Once()
+	require.NoError(t, m.settle(ctx, proof, inputs))
+	status = m.status()
+	assert.True(t, status.Settled)
+	assert.True(t, status.Sinks[1].Settled)
+	assert.Equal(t, uint64(3), status.Sinks[1].Attempts)
+	assert.NotNil(t, status.Sinks[1].SettledAt)
+	close(releaseWebhook)
+	require.Eventually(t, func() bool { return m.status().Sinks[2].Settled }, time.Second, time.Millisecond)
+
+	// a new proof is sent to every sink
+	l1.On("Settle", ctx, mock.Anything, inputs).Return(nil).Once()
+	btc.On("Settle", ctx, mock.Anything, inputs).Return(nil).Once()
+	webhook.On("Settle", ctx, mock.Anything, inputs).Return(nil).Once()
+	require.NoError(t, m.settle(ctx, &state.Proof{BatchNumber: 6, BatchNumberFinal: 8}, inputs))
+	require.Eventually(t, func() bool { return m.status().Sinks[2].Settled }, time.Second, time.Millisecond)
+	assert.Equal(t, uint64(1), m.status().Sinks[0].Attempts)
+}
+
+func TestL1Settler(t *testing.T) {
+	ctx := context.Background()
+	errBanana := errors.New("banana")
+	proof := &state.Proof{BatchNumber: 2, BatchNumberFinal: 5}
+	inputs := ethmanTypes.FinalProofInputs{FinalProof: &prover.FinalProof{Proof: "0x01"}}
+	sender := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	data := []byte("data")
+	monitoredTxID := buildMonitoredTxID(proof.BatchNumber, proof.BatchNumberFinal)
+
+	ethTxManager := mocks.NewEthTxManager(t)
+	etherman := mocks.NewEtherman(t)
+	confirmed := []string{}
+	settler := &l1Settler{
+		ethTxManager: ethTxManager,
+		etherman:     etherman,
+		sender:       sender,
+		gasOffset:    100,
+		onConfirmed: func(result ethtxmanager.MonitoredTxResult) {
+			confirmed = append(confirmed, result.ID)
+		},
+	}
+
+	etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(5), &inputs, sender).Return(&to, data, nil)
+	ethTxManager.On("Add", ctx, ethTxManagerOwner, monitoredTxID, sender, &to, (*big.Int)(nil), data, uint64(100), nil).Return(errBanana).Once()
+	require.ErrorIs(t, settler.Settle(ctx, proof, inputs), errBanana)
+
+	// like the eth tx manager, the monitored txs are handled until they're set as done
+	results := map[string]ethtxmanager.MonitoredTxStatus{}
+	addedStatus := ethtxmanager.MonitoredTxStatusFailed
+	ethTxManager.On("Add", ctx, ethTxManagerOwner, mock.Anything, sender, &to, (*big.Int)(nil), data, uint64(100), nil).Run(func(args mock.Arguments) {
+		results[args.String(2)] = addedStatus
+	}).Return(nil).Twice()
+	ethTxManager.On("SetStatusDone", ctx, ethTxManagerOwner, mock.Anything, nil).Run(func(args mock.Arguments) {
+		results[args.String(2)] = ethtxmanager.MonitoredTxStatusDone
+	}).Return(nil)
+	ethTxManager.On("ProcessPendingMonitoredTxs", ctx, ethTxManagerOwner, mock.Anything, nil).Run(func(args mock.Arguments) {
+		for cycle := 0; ; cycle++ {
+			require.Less(t, cycle, 10, "monitored txs never set as done")
+			pending := false
+			for id, status := range results {
+				if status == ethtxmanager.MonitoredTxStatusDone {
+					continue
+				}
+				pending = true
+				if status == ethtxmanager.MonitoredTxStatusConfirmed {
+					results[id] = ethtxmanager.MonitoredTxStatusDone
+				}
+				args[2].(ethtxmanager.ResultHandler)(ethtxmanager.MonitoredTxResult{ID: id, Status: status}, nil)
+			}
+			if !pending {
+				return
+			}
+		}
+	})
+
+	// the failed tx is set as done, so the eth tx manager returns
+	require.ErrorContains(t, settler.Settle(ctx, proof, inputs), "not confirmed")
+	assert.Equal(t, map[string]ethtxmanager.MonitoredTxStatus{monitoredTxID + "-attempt-2": ethtxmanager.MonitoredTxStatusDone}, results)
+	assert.Empty(t, confirmed)
+
+	// the retry sends a new tx
+	addedStatus = ethtxmanager.MonitoredTxStatusConfirmed
+	require.NoError(t, settler.Settle(ctx, proof, inputs))
+	assert.Equal(t, []string{monitoredTxID + "-attempt-3"}, confirmed)
+
+	// a new proof starts from the ID of the proof
+	results = map[string]ethtxmanager.MonitoredTxStatus{}
+	newProof := &state.Proof{BatchNumber: 6, BatchNumberFinal: 8}
+	newMonitoredTxID := buildMonitoredTxID(newProof.BatchNumber, newProof.BatchNumberFinal)
+	etherman.On("BuildTrustedVerifyBatchesTxData", uint64(5), uint64(8), &inputs, sender).Return(&to, data, nil)
+	ethTxManager.On("Add", ctx, ethTxManagerOwner, newMonitoredTxID, sender, &to, (*big.Int)(nil), data, uint64(100), nil).Return(ethtxmanager.ErrAlreadyExists).Once()
+	require.ErrorContains(t, settler.Settle(ctx, newProof, inputs), "not confirmed")
+}
+
+func TestWebhookSettler(t *testing.T) {
+	ctx := context.Background()
+	proof := &state.Proof{BatchNumber: 2, BatchNumberFinal: 5}
+	inputs := ethmanTypes.FinalProofInputs{
+		FinalProof:       &prover.FinalProof{Proof: "0x01"},
+		NewStateRoot:     common.HexToHash("0x3").Bytes(),
+		NewLocalExitRoot: common.HexToHash("0x4").Bytes(),
+	}
+
+	status := http.StatusOK
+	var received WebhookSettlement
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	settler := &webhookSettler{url: srv.URL, client: srv.Client()}
+	require.NoError(t, settler.Settle(ctx, proof, inputs))
+	assert.Equal(t, WebhookSettlement{
+		BatchNumber:      2,
+		BatchNumberFinal: 5,
+		NewStateRoot:     common.HexToHash("0x3"),
+		NewLocalExitRoot: common.HexToHash("0x4"),
+		Proof:            "0x01",
+	}, received)
+
+	status = http.StatusInternalServerError
+	assert.ErrorContains(t, settler.Settle(ctx, proof, inputs), "status 500")
+}
+
+func TestNewMultiSettlerFromConfig(t *testing.T) {
+	a := Aggregator{cfg: Config{MultiSettlement: MultiSettlementConfig{
+		Sinks:      []SettlementSink{SinkL1, SinkBTC, SinkWebhook},
+		Required:   []SettlementSink{SinkL1},
+		WebhookURL: "http://localhost",
+	}}}
+	m, err := a.newMultiSettler()
+	require.NoError(t, err)
+	require.Len(t, m.settlers, 3)
+	assert.Equal(t, map[string]bool{string(SinkL1): true}, m.required)
+
+	a.cfg.MultiSettlement.Sinks = []SettlementSink{SinkAggLayer}
+	_, err = a.newMultiSettler()
+	assert.ErrorContains(t, err, "agglayer client")
+
+	a.cfg.MultiSettlement.Sinks = []SettlementSink{"unknown"}
+	_, err = a.newMultiSettler()
+	assert.ErrorContains(t, err, "unknown settlement sink")
+}
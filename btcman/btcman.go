@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -27,6 +28,7 @@ type Client struct {
 type Clienter interface {
 	Inscribe(data []byte) (string, error)
 	DecodeInscription(txHash string) error
+	EstimateInscriptionFee(dataSize int) (int64, error)
 	Shutdown()
 }
 
@@ -354,3 +356,43 @@ func (client *Client) getInscriptionMessage(txHex string) ([]byte, error) {
 func (client *Client) listUnspent() ([]btcjson.ListUnspentResult, error) {
 	return client.BtcClient.ListUnspentMinMaxAddresses(0, 999999, []btcutil.Address{client.address})
 }
+
+const (
+	// feeConfTarget is the number of blocks in which the inscriptions are expected to be confirmed
+	feeConfTarget = 6
+	// commitTxVSize is the virtual size of the commit tx, spending a P2WPKH output into a taproot one
+	commitTxVSize = 154
+	// revealTxBaseVSize is the virtual size of the reveal tx without the inscribed data
+	revealTxBaseVSize = 120
+	// maxScriptElementSize is the max size of the pushes the inscribed data is split into
+	maxScriptElementSize = 520
+)
+
+// EstimateInscriptionFee returns the fee in satoshis of the commit and reveal txs inscribing
+// dataSize bytes, at the fee rate estimated by the node for the current mempool
+func (client *Client) EstimateInscriptionFee(dataSize int) (int64, error) {
+	mode := btcjson.EstimateModeConservative
+	res, err := client.BtcClient.EstimateSmartFee(feeConfTarget, &mode)
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate fee rate: %w", err)
+	}
+	if res.FeeRate == nil {
+		return 0, fmt.Errorf("failed to estimate fee rate: %s", strings.Join(res.Errors, ", "))
+	}
+
+	// the fee rate is in BTC/kvB
+	feeRate, err := btcutil.NewAmount(*res.FeeRate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fee rate %f: %w", *res.FeeRate, err)
+	}
+
+	return int64(feeRate) * InscriptionVSize(dataSize) / 1000, nil
+}
+
+// InscriptionVSize returns the virtual size of the commit and reveal txs inscribing dataSize bytes
+func InscriptionVSize(dataSize int) int64 {
+	// the inscribed data is in the witness, which weighs a quarter, and every push adds an opcode
+	pushes := (dataSize + maxScriptElementSize - 1) / maxScriptElementSize
+	witnessSize := dataSize + pushes*3
+	return int64(commitTxVSize + revealTxBaseVSize + (witnessSize+blockchain.WitnessScaleFactor-1)/blockchain.WitnessScaleFactor)
+}
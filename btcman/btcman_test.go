@@ -403,3 +403,19 @@ func TestGetUTXO(t *testing.T) {
 	assert.NotNil(t, utxo)
 	assert.Equal(t, "txid1", utxo.TxID)
 }
+
+func TestEstimateInscriptionFee(t *testing.T) {
+	ctx := setupTest(t)
+	mode := btcjson.EstimateModeConservative
+
+	feeRate := 0.0001 // 10 sat/vB
+	ctx.mockClient.On("EstimateSmartFee", int64(feeConfTarget), &mode).Return(&btcjson.EstimateSmartFeeResult{FeeRate: &feeRate}, nil).Once()
+	fee, err := ctx.btcman.EstimateInscriptionFee(832)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4840), fee)
+	assert.Equal(t, int64(484), InscriptionVSize(832))
+
+	ctx.mockClient.On("EstimateSmartFee", int64(feeConfTarget), &mode).Return(&btcjson.EstimateSmartFeeResult{Errors: []string{"Insufficient data or no feerate found"}}, nil).Once()
+	_, err = ctx.btcman.EstimateInscriptionFee(832)
+	assert.ErrorContains(t, err, "no feerate found")
+}
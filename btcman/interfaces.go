@@ -15,6 +15,7 @@ type BtcRpcClienter interface {
 	SendRawTransaction(*wire.MsgTx, bool) (*chainhash.Hash, error)
 	GetTransaction(*chainhash.Hash) (*btcjson.GetTransactionResult, error)
 	GetRawTransactionVerbose(*chainhash.Hash) (*btcjson.TxRawResult, error)
+	EstimateSmartFee(int64, *btcjson.EstimateSmartFeeMode) (*btcjson.EstimateSmartFeeResult, error)
 	Shutdown()
 }
 
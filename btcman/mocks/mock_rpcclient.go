@@ -49,6 +49,12 @@ func (m *MockBtcRpcClient) GetRawTransactionVerbose(hash *chainhash.Hash) (*btcj
 	return args.Get(0).(*btcjson.TxRawResult), args.Error(1)
 }
 
+// EstimateSmartFee mocks the EstimateSmartFee method
+func (m *MockBtcRpcClient) EstimateSmartFee(confTarget int64, mode *btcjson.EstimateSmartFeeMode) (*btcjson.EstimateSmartFeeResult, error) {
+	args := m.Called(confTarget, mode)
+	return args.Get(0).(*btcjson.EstimateSmartFeeResult), args.Error(1)
+}
+
 // Shutdown mocks the Shutdown method
 func (m *MockBtcRpcClient) Shutdown() {
 	m.Called()
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/archive"
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	exportBatchFlag = cli.Uint64Flag{
+		Name:     "batch",
+		Usage:    "Batch number settled by the proof to export",
+		Required: true,
+	}
+	exportOutputFlag = cli.StringFlag{
+		Name:     "output",
+		Aliases:  []string{"o"},
+		Usage:    "Output `FILE` of the bundle, stdout if not set",
+		Required: false,
+	}
+)
+
+var aggregatorCommands = cli.Command{
+	Name:  "aggregator",
+	Usage: "Aggregator tools",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "export-proof",
+			Usage:  "Exports the archived final proof settling a batch as a self-contained JSON bundle",
+			Action: exportProof,
+			Flags:  []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag, &exportBatchFlag, &exportOutputFlag},
+		},
+	},
+}
+
+func exportProof(ctx *cli.Context) error {
+	c, err := config.Load(ctx, true)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	// the archive is read even if archiving new proofs is disabled
+	proofArchive, err := archive.New(c.Aggregator.Archive)
+	if err != nil {
+		return err
+	}
+
+	batchNumber := ctx.Uint64(exportBatchFlag.Name)
+	hash, bundle, err := proofArchive.GetByBatch(batchNumber)
+	if errors.Is(err, archive.ErrNotFound) {
+		return fmt.Errorf("there isn't an archived proof for batch %d", batchNumber)
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("Exporting proof bundle %s for batch %d", hash, batchNumber)
+
+	// the bundle is written as archived, so its keccak256 hash is the content hash
+	output := ctx.String(exportOutputFlag.Name)
+	if output == "" {
+		_, err = os.Stdout.Write(bundle)
+		return err
+	}
+	return os.WriteFile(output, bundle, 0600)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/backfill"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	auditBatchDataFlagFrom   = "from"
+	auditBatchDataFlagTo     = "to"
+	auditBatchDataFlagRepair = "repair"
+	auditBatchDataFlagReport = "report"
+)
+
+var auditBatchDataFlags = []cli.Flag{
+	&cli.Uint64Flag{
+		Name:     auditBatchDataFlagFrom,
+		Usage:    "First virtual batch to audit (default: 1)",
+		Required: false,
+	},
+	&cli.Uint64Flag{
+		Name:     auditBatchDataFlagTo,
+		Usage:    "Last virtual batch to audit (default: last virtual batch)",
+		Required: false,
+	},
+	&cli.BoolFlag{
+		Name:     auditBatchDataFlagRepair,
+		Usage:    "Refill the missing or wrong batch data from the backup table or the data availability layer",
+		Required: false,
+	},
+	&cli.StringFlag{
+		Name:     auditBatchDataFlagReport,
+		Aliases:  []string{"o"},
+		Usage:    "JSON file to write the report to (default: stdout)",
+		Required: false,
+	},
+	&configFileFlag,
+	&networkFlag,
+	&customNetworkFlag,
+}
+
+func auditBatchData(ctx *cli.Context) error {
+	c, err := config.Load(ctx, true)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	stateSqlDB, err := db.NewSQLDB(c.State.DB)
+	if err != nil {
+		return err
+	}
+	stateCfg := state.Config{}
+	st := state.NewState(stateCfg, pgstatestorage.NewPostgresStorage(stateCfg, stateSqlDB), nil, nil, nil, nil)
+
+	etherman, err := newEtherman(*c, st)
+	if err != nil {
+		return err
+	}
+	da, err := newDataAvailability(*c, st, etherman, false)
+	if err != nil {
+		return err
+	}
+
+	repair := ctx.Bool(auditBatchDataFlagRepair)
+	auditor := backfill.New(st, etherman, da, repair)
+	report, err := auditor.Run(ctx.Context, ctx.Uint64(auditBatchDataFlagFrom), ctx.Uint64(auditBatchDataFlagTo))
+	if err != nil {
+		return err
+	}
+	log.Infof("audited batches %d to %d: %d checked, %d forced, %d missing, %d mismatched, %d unknown, %d repaired, %d failed",
+		report.FromBatch, report.ToBatch, report.Checked, report.Forced, report.Missing, report.Mismatched, report.Unknown, report.Repaired, report.Failed)
+
+	output := os.Stdout
+	if reportFile := ctx.String(auditBatchDataFlagReport); reportFile != "" {
+		output, err = os.Create(reportFile)
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+	}
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
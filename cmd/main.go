@@ -193,6 +193,21 @@ func main() {
 			Action:  setDataAvailabilityProtocol,
 			Flags:   setDataAvailabilityProtocolFlags,
 		},
+		{
+			Name:    "audit-batch-data",
+			Aliases: []string{},
+			Usage:   "Audits the L2 data stored for the virtual batches and optionally refills it from the data availability layer",
+			Action:  auditBatchData,
+			Flags:   auditBatchDataFlags,
+		},
+		{
+			Name:    "pending-sequences",
+			Aliases: []string{},
+			Usage:   "Lists the sequences posted to the data availability layer that are pending to be confirmed on L1",
+			Action:  pendingSequences,
+			Flags:   []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag},
+		},
+		&aggregatorCommands,
 	}
 
 	err := app.Run(os.Args)
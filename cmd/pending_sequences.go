@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/sequencesender"
+	"github.com/urfave/cli/v2"
+)
+
+func pendingSequences(ctx *cli.Context) error {
+	c, err := config.Load(ctx, true)
+	if err != nil {
+		return err
+	}
+	setupLog(c.Log)
+
+	storage, err := sequencesender.NewPostgresStorage(c.State.DB)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	sequences, err := storage.GetPendingSequences(ctx.Context, nil)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sequences)
+}
@@ -5,13 +5,16 @@ import (
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/pool/pgpoolstorage"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/urfave/cli/v2"
 )
 
@@ -43,6 +46,39 @@ var (
 		Required: false,
 	}
 
+	laneFlag = cli.StringFlag{
+		Name:     "lane",
+		Aliases:  []string{"l"},
+		Usage:    "Name of priority lane to operate on",
+		Required: true,
+	}
+
+	maxGasFlag = cli.Uint64Flag{
+		Name:     "max-gas",
+		Usage:    "Max gas of the txs sent by the addresses, not limited if not set",
+		Required: false,
+	}
+	maxValueFlag = cli.StringFlag{
+		Name:     "max-value",
+		Usage:    "Max value in wei of the txs sent by the addresses, not limited if not set",
+		Required: false,
+	}
+	limitsCsvFlag = cli.StringFlag{
+		Name:     "csv",
+		Usage:    "CSV file with rows of address, max gas and max value, empty for no limit",
+		Required: false,
+	}
+	contractFlag = cli.StringFlag{
+		Name:     "contract",
+		Usage:    "Contract of the function selectors",
+		Required: false,
+	}
+	selectorsCsvFlag = cli.StringFlag{
+		Name:     "csv",
+		Usage:    "CSV file with rows of contract, function selector and allow or deny",
+		Required: false,
+	}
+
 	policyActionFlags = []cli.Flag{&policyFlag}
 )
 
@@ -77,6 +113,65 @@ var policyCommands = cli.Command{
 			Usage:  "Update the default action for a policy",
 			Action: updatePolicy,
 			Flags:  append(policyActionFlags, &allowFlag, &denyFlag),
+		}, {
+			Name:   "lane",
+			Usage:  "View and update the members of the priority lanes",
+			Action: describeLanes,
+			Flags:  []cli.Flag{&noHeaderFlag},
+			Subcommands: []*cli.Command{
+				{
+					Name:   "add",
+					Usage:  "Add address(es) to a priority lane, the txs sent by or to them are processed in the lane",
+					Action: addLaneMembers,
+					Flags:  []cli.Flag{&laneFlag, &csvFlag},
+				}, {
+					Name:   "remove",
+					Usage:  "Remove address(es) from a priority lane",
+					Action: removeLaneMembers,
+					Flags:  []cli.Flag{&laneFlag, &csvFlag},
+				}, {
+					Name:   "clear",
+					Usage:  "Remove all the addresses from a priority lane",
+					Action: clearLane,
+					Flags:  []cli.Flag{&laneFlag},
+				},
+			},
+		}, {
+			Name:   "limit",
+			Usage:  "View and update the gas and value limits of the txs sent by addresses",
+			Action: describeLimits,
+			Flags:  []cli.Flag{&noHeaderFlag},
+			Subcommands: []*cli.Command{
+				{
+					Name:   "set",
+					Usage:  "Set the gas and value limits of address(es), replacing the previous ones",
+					Action: setLimits,
+					Flags:  []cli.Flag{&maxGasFlag, &maxValueFlag, &limitsCsvFlag},
+				}, {
+					Name:   "remove",
+					Usage:  "Remove the gas and value limits of address(es)",
+					Action: removeLimits,
+					Flags:  []cli.Flag{&csvFlag},
+				},
+			},
+		}, {
+			Name:   "selector",
+			Usage:  "View and update the function selectors that can be called on contracts",
+			Action: describeSelectors,
+			Flags:  []cli.Flag{&noHeaderFlag},
+			Subcommands: []*cli.Command{
+				{
+					Name:   "add",
+					Usage:  "Allow or deny calling function selector(s) of a contract, only the allowed ones can be called if any",
+					Action: addSelectors,
+					Flags:  []cli.Flag{&contractFlag, &allowFlag, &denyFlag, &selectorsCsvFlag},
+				}, {
+					Name:   "remove",
+					Usage:  "Remove the filters of function selector(s) of a contract",
+					Action: removeSelectors,
+					Flags:  []cli.Flag{&contractFlag, &selectorsCsvFlag},
+				},
+			},
 		},
 	},
 }
@@ -226,6 +321,311 @@ func describePolicies(cli *cli.Context, showHeader bool) error {
 	return nil
 }
 
+func addLaneMembers(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+	addresses, err := resolveAddresses(cli, true)
+	if err != nil {
+		return err
+	}
+	return db.AddAddressesToPriorityLane(context.Background(), cli.String(laneFlag.Name), addresses)
+}
+
+func removeLaneMembers(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+	addresses, err := resolveAddresses(cli, true)
+	if err != nil {
+		return err
+	}
+	return db.RemoveAddressesFromPriorityLane(context.Background(), cli.String(laneFlag.Name), addresses)
+}
+
+func clearLane(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+	return db.ClearPriorityLane(context.Background(), cli.String(laneFlag.Name))
+}
+
+func describeLanes(cli *cli.Context) error {
+	c, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+	members, err := db.GetPriorityLaneMembers(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if !cli.Bool(noHeaderFlag.Name) {
+		fmt.Printf("%s: %s\n", "Lane", "Address")
+	}
+	configured := make(map[string]bool)
+	for _, lane := range c.Sequencer.PriorityLanes.Lanes {
+		configured[lane.Name] = true
+	}
+	for _, member := range members {
+		if configured[member.Lane] {
+			fmt.Printf("%s: %s\n", member.Lane, member.Address.Hex())
+		} else {
+			fmt.Printf("%s: %s (lane not configured)\n", member.Lane, member.Address.Hex())
+		}
+	}
+	return nil
+}
+
+func setLimits(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+
+	var limits []pool.AddressLimits
+	if cli.IsSet(limitsCsvFlag.Name) {
+		// the rows may have empty trailing cells for the limits not set
+		records, err := readCSV(cli.String(limitsCsvFlag.Name), -1)
+		if err != nil {
+			return err
+		}
+		for i, row := range records {
+			if len(row) != 3 { //nolint:gomnd
+				return fmt.Errorf("invalid row %d, expected address, max gas and max value", i+1)
+			}
+			l, err := parseLimits(row[0], row[1], row[2])
+			if err != nil {
+				return fmt.Errorf("invalid row %d: %w", i+1, err)
+			}
+			limits = append(limits, l)
+		}
+	}
+
+	if cli.Args().Len() > 0 {
+		maxGas := ""
+		if cli.IsSet(maxGasFlag.Name) {
+			maxGas = fmt.Sprint(cli.Uint64(maxGasFlag.Name))
+		}
+		for _, a := range cli.Args().Slice() {
+			l, err := parseLimits(a, maxGas, cli.String(maxValueFlag.Name))
+			if err != nil {
+				return err
+			}
+			limits = append(limits, l)
+		}
+	}
+
+	if len(limits) == 0 {
+		return errors.New("no addresses given")
+	}
+	return db.SetAddressLimits(context.Background(), limits)
+}
+
+func parseLimits(address, maxGas, maxValue string) (pool.AddressLimits, error) {
+	address = strings.TrimSpace(address)
+	if !common.IsHexAddress(address) {
+		return pool.AddressLimits{}, fmt.Errorf("invalid address: %s", address)
+	}
+	limits := pool.AddressLimits{Address: common.HexToAddress(address)}
+
+	if maxGas = strings.TrimSpace(maxGas); maxGas != "" {
+		gas, err := strconv.ParseUint(maxGas, 10, 64) //nolint:gomnd
+		if err != nil {
+			return pool.AddressLimits{}, fmt.Errorf("invalid max gas: %s", maxGas)
+		}
+		limits.MaxGas = &gas
+	}
+	if maxValue = strings.TrimSpace(maxValue); maxValue != "" {
+		value, ok := new(big.Int).SetString(maxValue, 10) //nolint:gomnd
+		if !ok || value.Sign() < 0 {
+			return pool.AddressLimits{}, fmt.Errorf("invalid max value: %s", maxValue)
+		}
+		limits.MaxValue = value
+	}
+	return limits, nil
+}
+
+func removeLimits(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+	addresses, err := resolveAddresses(cli, true)
+	if err != nil {
+		return err
+	}
+	return db.RemoveAddressLimits(context.Background(), addresses)
+}
+
+func describeLimits(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+	list, err := db.ListAddressLimits(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if !cli.Bool(noHeaderFlag.Name) {
+		fmt.Println("Address,MaxGas,MaxValue")
+	}
+	for _, l := range list {
+		maxGas, maxValue := "", ""
+		if l.MaxGas != nil {
+			maxGas = fmt.Sprint(*l.MaxGas)
+		}
+		if l.MaxValue != nil {
+			maxValue = l.MaxValue.String()
+		}
+		fmt.Printf("%s,%s,%s\n", l.Address.Hex(), maxGas, maxValue)
+	}
+	return nil
+}
+
+func addSelectors(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+
+	allow := cli.Bool(allowFlag.Name)
+	deny := cli.Bool(denyFlag.Name)
+	if cli.Args().Len() > 0 && allow == deny {
+		return errors.New("supply one policy action [--allow or --deny]")
+	}
+	filters, err := resolveSelectorFilters(cli, allow, true)
+	if err != nil {
+		return err
+	}
+	return db.AddSelectorFilters(context.Background(), filters)
+}
+
+func removeSelectors(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+	filters, err := resolveSelectorFilters(cli, false, false)
+	if err != nil {
+		return err
+	}
+	return db.RemoveSelectorFilters(context.Background(), filters)
+}
+
+func describeSelectors(cli *cli.Context) error {
+	_, db, err := configAndStorage(cli)
+	if err != nil {
+		return err
+	}
+	filters, err := db.ListSelectorFilters(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if !cli.Bool(noHeaderFlag.Name) {
+		fmt.Println("Contract,Selector,Action")
+	}
+	for _, f := range filters {
+		action := "deny"
+		if f.Allow {
+			action = "allow"
+		}
+		fmt.Printf("%s,%s,%s\n", f.Contract.Hex(), f.Selector, action)
+	}
+	return nil
+}
+
+// resolveSelectorFilters returns the filters of the CSV file and of the selectors given as arguments for the contract.
+// The action of the filters is only read from the CSV file if withAction is set
+func resolveSelectorFilters(cli *cli.Context, allow bool, withAction bool) ([]pool.SelectorFilter, error) {
+	var filters []pool.SelectorFilter
+	if cli.IsSet(selectorsCsvFlag.Name) {
+		// the rows are validated below since the action is only expected if withAction is set
+		records, err := readCSV(cli.String(selectorsCsvFlag.Name), -1)
+		if err != nil {
+			return nil, err
+		}
+		for i, row := range records {
+			if len(row) < 2 || (withAction && len(row) != 3) { //nolint:gomnd
+				return nil, fmt.Errorf("invalid row %d, expected contract, function selector and allow or deny", i+1)
+			}
+			rowAllow := false
+			if withAction {
+				switch strings.TrimSpace(row[2]) {
+				case "allow":
+					rowAllow = true
+				case "deny":
+				default:
+					return nil, fmt.Errorf("invalid row %d, invalid action: %s", i+1, row[2])
+				}
+			}
+			filter, err := parseSelectorFilter(row[0], row[1], rowAllow)
+			if err != nil {
+				return nil, fmt.Errorf("invalid row %d: %w", i+1, err)
+			}
+			filters = append(filters, filter)
+		}
+	}
+
+	if cli.Args().Len() > 0 {
+		if !cli.IsSet(contractFlag.Name) {
+			return nil, errors.New("supply the contract of the function selectors [--contract]")
+		}
+		for _, selector := range cli.Args().Slice() {
+			filter, err := parseSelectorFilter(cli.String(contractFlag.Name), selector, allow)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, filter)
+		}
+	}
+
+	if len(filters) == 0 {
+		return nil, errors.New("no function selectors given")
+	}
+	return filters, nil
+}
+
+func parseSelectorFilter(contract, selector string, allow bool) (pool.SelectorFilter, error) {
+	contract = strings.TrimSpace(contract)
+	if !common.IsHexAddress(contract) {
+		return pool.SelectorFilter{}, fmt.Errorf("invalid contract: %s", contract)
+	}
+	selector = strings.TrimSpace(selector)
+	if !strings.HasPrefix(selector, "0x") {
+		selector = "0x" + selector
+	}
+	decoded, err := hexutil.Decode(selector)
+	if err != nil || len(decoded) != pool.SelectorLength {
+		return pool.SelectorFilter{}, fmt.Errorf("invalid function selector: %s", selector)
+	}
+
+	filter := pool.SelectorFilter{Contract: common.HexToAddress(contract), Allow: allow}
+	copy(filter.Selector[:], decoded)
+	return filter, nil
+}
+
+// readCSV reads the records of the CSV file. The number of fields of the records isn't checked if fieldsPerRecord is
+// negative, otherwise it's checked as done by csv.Reader
+func readCSV(file string, fieldsPerRecord int) ([][]string, error) {
+	fd, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func(fd *os.File) {
+		_ = fd.Close()
+	}(fd)
+
+	fileReader := csv.NewReader(fd)
+	fileReader.FieldsPerRecord = fieldsPerRecord
+	return fileReader.ReadAll()
+}
+
 func configAndStorage(cli *cli.Context) (*config.Config, *pgpoolstorage.PostgresPoolStorage, error) {
 	c, err := config.Load(cli, false)
 	if err != nil {
@@ -266,18 +666,7 @@ func resolvePolicy(cli *cli.Context) (pool.PolicyName, error) {
 func resolveAddresses(cli *cli.Context, failIfEmpty bool) ([]common.Address, error) {
 	var set = make(map[common.Address]struct{})
 	if cli.IsSet("csv") {
-		file := cli.String(csvFlag.Name)
-		fd, err := os.Open(file)
-		if err != nil {
-			return nil, err
-		}
-		defer func(fd *os.File) {
-			_ = fd.Close()
-		}(fd)
-
-		fileReader := csv.NewReader(fd)
-		records, err := fileReader.ReadAll()
-
+		records, err := readCSV(cli.String(csvFlag.Name), 0)
 		if err != nil {
 			return nil, err
 		}
@@ -43,6 +43,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/0xPolygonHermez/zkevm-node/synchronizer"
 	"github.com/0xPolygonHermez/zkevm-node/synchronizer/common/syncinterfaces"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -175,7 +176,7 @@ func start(cliCtx *cli.Context) error {
 			if err != nil {
 				log.Fatal(err)
 			}
-			go runAggregator(cliCtx.Context, c.Aggregator, etherman, btcman, etm, st)
+			go runAggregator(cliCtx.Context, c.Aggregator, c.State.DB, etherman, btcman, etm, st)
 		case SEQUENCER:
 			c.Sequencer.StreamServer.Log = datastreamerlog.Config{
 				Environment: datastreamerlog.LogEnvironment(c.Log.Environment),
@@ -334,44 +335,31 @@ func newDataAvailability(c config.Config, st *state.State, etherman *etherman.Cl
 	}
 
 	// Backend specific config
-	daProtocolName, err := etherman.GetDAProtocolName()
-	if err != nil {
-		return nil, fmt.Errorf("error getting data availability protocol name: %v", err)
-	}
-	var daBackend dataavailability.DABackender
-	switch daProtocolName {
-	case string(dataavailability.DataAvailabilityCommittee):
-		var (
-			pk  *ecdsa.PrivateKey
-			err error
-		)
-		if isSequenceSender {
-			_, pk, err = etherman.LoadAuthFromKeyStore(c.SequenceSender.PrivateKey.Path, c.SequenceSender.PrivateKey.Password)
-			if err != nil {
-				return nil, err
-			}
-		}
-		dacAddr, err := etherman.GetDAProtocolAddr()
+	var pk *ecdsa.PrivateKey
+	if isSequenceSender {
+		_, pk, err = etherman.LoadAuthFromKeyStore(c.SequenceSender.PrivateKey.Path, c.SequenceSender.PrivateKey.Password)
 		if err != nil {
-			return nil, fmt.Errorf("error getting trusted sequencer URI. Error: %v", err)
+			return nil, err
 		}
-
-		daBackend, err = datacommittee.New(
+	}
+	registry := dataavailability.NewRegistry(etherman, c.NetworkConfig.Genesis.RollupBlockNumber)
+	registry.Register(dataavailability.DataAvailabilityCommittee, func(protocolAddr common.Address) (dataavailability.DABackender, error) {
+		return datacommittee.New(
+			c.DataCommittee,
 			c.Etherman.URL,
-			dacAddr,
+			protocolAddr,
 			pk,
 			dataCommitteeClient.NewFactory(),
 		)
-		if err != nil {
-			return nil, err
-		}
-	default:
-		return nil, fmt.Errorf("unexpected / unsupported DA protocol: %s", daProtocolName)
+	})
+	// Fail early if the protocol currently set on L1 is not supported
+	if _, err := registry.Backend(context.Background()); err != nil {
+		return nil, err
 	}
 
-	return dataavailability.New(
+	return dataavailability.NewWithRegistry(
 		c.IsTrustedSequencer,
-		daBackend,
+		registry,
 		st,
 		zkEVMClient,
 		dataSourcePriority,
@@ -489,7 +477,7 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 	if _, ok := apis[jsonrpc.APITxPool]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APITxPool,
-			Service: &jsonrpc.TxPoolEndpoints{},
+			Service: jsonrpc.NewTxPoolEndpoints(c.RPC, pool, st),
 		})
 	}
 
@@ -543,7 +531,12 @@ func createSequenceSender(cfg config.Config, pool *pool.Pool, etmStorage *ethtxm
 		log.Fatal(err)
 	}
 
-	seqSender, err := sequencesender.New(cfg.SequenceSender, st, etherman, ethTxManager, eventLog, da)
+	seqSenderStorage, err := sequencesender.NewPostgresStorage(cfg.State.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	seqSender, err := sequencesender.New(cfg.SequenceSender, st, etherman, ethTxManager, seqSenderStorage, eventLog, da)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -551,14 +544,14 @@ func createSequenceSender(cfg config.Config, pool *pool.Pool, etmStorage *ethtxm
 	return seqSender
 }
 
-func runAggregator(ctx context.Context, c aggregator.Config, etherman *etherman.Client, btcman btcman.Clienter, ethTxManager *ethtxmanager.Client, st *state.State) {
+func runAggregator(ctx context.Context, c aggregator.Config, stateDBCfg db.Config, etherman *etherman.Client, btcman btcman.Clienter, ethTxManager *ethtxmanager.Client, st *state.State) {
 	var (
-		aggCli *agglayerClient.Client
+		aggCli agglayerClient.ClientInterface
 		pk     *ecdsa.PrivateKey
 		err    error
 	)
 
-	if c.SettlementBackend == aggregator.AggLayer {
+	if c.UsesAggLayer() {
 		aggCli = agglayerClient.New(c.AggLayerURL)
 
 		// Load private key
@@ -568,7 +561,12 @@ func runAggregator(ctx context.Context, c aggregator.Config, etherman *etherman.
 		}
 	}
 
-	agg, err := aggregator.New(c, st, ethTxManager, etherman, btcman, aggCli, pk)
+	storage, err := aggregator.NewPostgresStorage(stateDBCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	agg, err := aggregator.New(c, st, ethTxManager, etherman, btcman, storage, aggCli, pk)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/aggregator"
 	"github.com/0xPolygonHermez/zkevm-node/btcman"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee"
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/etherman"
 	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
@@ -106,6 +107,8 @@ type Config struct {
 	Sequencer sequencer.Config
 	// Configuration of the sequence sender service
 	SequenceSender sequencesender.Config
+	// Configuration of the data availability committee backend
+	DataCommittee datacommittee.Config
 	// Configuration of the aggregator service
 	Aggregator aggregator.Config
 	// Configuration of the genesis of the network. This is used to known the initial state of the network
@@ -10,10 +10,13 @@ import (
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/aggregator"
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/archive"
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/dataavailability"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -105,6 +108,38 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.StateConsistencyCheckInterval",
 			expectedValue: types.NewDuration(5 * time.Second),
 		},
+		{
+			path:          "Sequencer.TxOrdering",
+			expectedValue: sequencer.GasPriceTxOrdering,
+		},
+		{
+			path:          "Sequencer.PriorityLanes.Lanes",
+			expectedValue: []sequencer.PriorityLaneCfg{},
+		},
+		{
+			path:          "Sequencer.PriorityLanes.RefreshInterval",
+			expectedValue: types.NewDuration(time.Minute),
+		},
+		{
+			path:          "Sequencer.HA.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.HA.InstanceID",
+			expectedValue: "",
+		},
+		{
+			path:          "Sequencer.HA.LeaseDuration",
+			expectedValue: types.NewDuration(15 * time.Second),
+		},
+		{
+			path:          "Sequencer.HA.RenewInterval",
+			expectedValue: types.NewDuration(5 * time.Second),
+		},
+		{
+			path:          "Sequencer.HA.AcquireInterval",
+			expectedValue: types.NewDuration(5 * time.Second),
+		},
 		{
 			path:          "Sequencer.Finalizer.ForcedBatchesTimeout",
 			expectedValue: types.NewDuration(60 * time.Second),
@@ -153,6 +188,14 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.Finalizer.FlushIdCheckInterval",
 			expectedValue: types.NewDuration(50 * time.Millisecond),
 		},
+		{
+			path:          "Sequencer.Finalizer.PublishPendingReceipts",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.Finalizer.PendingReceiptsTTL",
+			expectedValue: types.NewDuration(10 * time.Minute),
+		},
 		{
 			path:          "Sequencer.Finalizer.Metrics.Interval",
 			expectedValue: types.NewDuration(60 * time.Minute),
@@ -205,6 +248,46 @@ func Test_Defaults(t *testing.T) {
 			path:          "SequenceSender.MaxBatchesForL1",
 			expectedValue: uint64(300),
 		},
+		{
+			path:          "SequenceSender.BatchDataInBlobs",
+			expectedValue: false,
+		},
+		{
+			path:          "SequenceSender.BlobsForkID",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "SequenceSender.SendPolicy.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "SequenceSender.SendPolicy.DryRun",
+			expectedValue: false,
+		},
+		{
+			path:          "SequenceSender.SendPolicy.MaxBaseFee",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "SequenceSender.SendPolicy.TargetCostPerBatch",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "SequenceSender.SendPolicy.FixedGasPerSequence",
+			expectedValue: uint64(100000),
+		},
+		{
+			path:          "SequenceSender.SendPolicy.GasPerBatch",
+			expectedValue: uint64(20000),
+		},
+		{
+			path:          "DataCommittee.ErasureCoding",
+			expectedValue: false,
+		},
+		{
+			path:          "DataCommittee.DataShards",
+			expectedValue: uint64(0),
+		},
 		{
 			path:          "SequenceSender.SequenceL1BlockConfirmations",
 			expectedValue: uint64(32),
@@ -257,6 +340,14 @@ func Test_Defaults(t *testing.T) {
 			path:          "EthTxManager.MaxGasPriceLimit",
 			expectedValue: uint64(0),
 		},
+		{
+			path:          "EthTxManager.BlobGasPriceMarginFactor",
+			expectedValue: float64(1),
+		},
+		{
+			path:          "EthTxManager.MaxBlobGasPriceLimit",
+			expectedValue: uint64(0),
+		},
 		{
 			path:          "L2GasPriceSuggester.DefaultGasPriceWei",
 			expectedValue: uint64(2000000000),
@@ -398,6 +489,42 @@ func Test_Defaults(t *testing.T) {
 			path:          "Pool.DB.MaxConns",
 			expectedValue: 200,
 		},
+		{
+			path:          "Pool.Quotas.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.Quotas.Tiers",
+			expectedValue: []pool.QuotaTierCfg{},
+		},
+		{
+			path:          "Pool.Quotas.CleanupInterval",
+			expectedValue: types.NewDuration(1 * time.Minute),
+		},
+		{
+			path:          "Pool.Quotas.Default.TxsPerSecond",
+			expectedValue: float64(1),
+		},
+		{
+			path:          "Pool.Quotas.Default.TxsBurst",
+			expectedValue: uint64(10),
+		},
+		{
+			path:          "Pool.Quotas.Default.GasPerSecond",
+			expectedValue: uint64(1000000),
+		},
+		{
+			path:          "Pool.Quotas.Default.GasBurst",
+			expectedValue: uint64(30000000),
+		},
+		{
+			path:          "Pool.Sponsorship.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.Sponsorship.Sponsors",
+			expectedValue: []pool.SponsorCfg{},
+		},
 		{
 			path:          "RPC.Host",
 			expectedValue: "0.0.0.0",
@@ -446,6 +573,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.MaxNativeBlockHashBlockRange",
 			expectedValue: uint64(60000),
 		},
+		{
+			path:          "RPC.PendingReceipts.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.PendingReceipts.PollInterval",
+			expectedValue: types.NewDuration(100 * time.Millisecond),
+		},
+		{
+			path:          "RPC.MaxTxPoolContentSize",
+			expectedValue: uint64(10000),
+		},
 		{
 			path:          "RPC.EnableHttpLog",
 			expectedValue: true,
@@ -542,6 +681,74 @@ func Test_Defaults(t *testing.T) {
 			path:          "Aggregator.BatchProofL1BlockConfirmations",
 			expectedValue: uint64(2),
 		},
+		{
+			path:          "Aggregator.API.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Aggregator.API.Host",
+			expectedValue: "0.0.0.0",
+		},
+		{
+			path:          "Aggregator.API.Port",
+			expectedValue: 50082,
+		},
+		{
+			path:          "Aggregator.API.AdminToken",
+			expectedValue: "",
+		},
+		{
+			path:          "Aggregator.MultiSettlement.Sinks",
+			expectedValue: []aggregator.SettlementSink{},
+		},
+		{
+			path:          "Aggregator.MultiSettlement.Required",
+			expectedValue: []aggregator.SettlementSink{},
+		},
+		{
+			path:          "Aggregator.MultiSettlement.MaxRetries",
+			expectedValue: uint64(3),
+		},
+		{
+			path:          "Aggregator.MultiSettlement.RetryInterval",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "Aggregator.MultiSettlement.WebhookURL",
+			expectedValue: "",
+		},
+		{
+			path:          "Aggregator.MultiSettlement.WebhookTimeout",
+			expectedValue: types.NewDuration(30 * time.Second),
+		},
+		{
+			path:          "Aggregator.CostProfitability.VerifyBatchesGas",
+			expectedValue: uint64(350000),
+		},
+		{
+			path:          "Aggregator.CostProfitability.MaxL1CostPerBatch",
+			expectedValue: aggregator.TokenAmountWithDecimals{Int: big.NewInt(5000000000000000)},
+		},
+		{
+			path:          "Aggregator.CostProfitability.MaxBtcCostPerBatch",
+			expectedValue: uint64(1000),
+		},
+		{
+			path:          "Aggregator.CostProfitability.FallbackBtcFeeRate",
+			expectedValue: uint64(10),
+		},
+		{
+			path:          "Aggregator.Archive.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Aggregator.Archive.Backend",
+			expectedValue: archive.LocalBackend,
+		},
+		{
+			path:          "Aggregator.Archive.Path",
+			expectedValue: "/archive/proofs",
+		},
 		{
 			path:          "State.Batch.Constraints.MaxTxsPerBatch",
 			expectedValue: uint64(300),
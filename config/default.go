@@ -56,6 +56,18 @@ TxFeeCap = 1.0
 	EthTransferGasPrice = 0
 	EthTransferL1GasPriceFactor = 0	
 	L2GasPriceSuggesterFactor = 0.5
+    [Pool.Quotas]
+	Enabled = false
+	Tiers = []
+	CleanupInterval = "1m"
+		[Pool.Quotas.Default]
+		TxsPerSecond = 1
+		TxsBurst = 10
+		GasPerSecond = 1000000
+		GasBurst = 30000000
+    [Pool.Sponsorship]
+	Enabled = false
+	Sponsors = []
     [Pool.DB]
 	User = "pool_user"
 	Password = "pool_password"
@@ -78,6 +90,8 @@ WaitTxToBeMined = "2m"
 ForcedGas = 0
 GasPriceMarginFactor = 1
 MaxGasPriceLimit = 0
+BlobGasPriceMarginFactor = 1
+MaxBlobGasPriceLimit = 0
 
 [Btcman]
 Host = "host.docker.internal"
@@ -101,12 +115,16 @@ BatchRequestsLimit = 20
 MaxLogsCount = 10000
 MaxLogsBlockRange = 10000
 MaxNativeBlockHashBlockRange = 60000
+MaxTxPoolContentSize = 10000
 EnableHttpLog = true
 	[RPC.WebSockets]
 		Enabled = true
 		Host = "0.0.0.0"
 		Port = 8546
 		ReadLimit = 104857600
+	[RPC.PendingReceipts]
+		Enabled = false
+		PollInterval = "100ms"
 
 [Synchronizer]
 SyncInterval = "1s"
@@ -151,6 +169,10 @@ TxLifetimeCheckInterval = "10m"
 TxLifetimeMax = "3h"
 LoadPoolTxsCheckInterval = "500ms"
 StateConsistencyCheckInterval = "5s"
+TxOrdering = "gasprice"
+	[Sequencer.PriorityLanes]
+		Lanes = []
+		RefreshInterval = "1m"
 	[Sequencer.Finalizer]
 		NewTxsWaitInterval = "100ms"
 		ForcedBatchesTimeout = "60s"
@@ -166,6 +188,8 @@ StateConsistencyCheckInterval = "5s"
 		HaltOnBatchNumber = 0
 		SequentialBatchSanityCheck = false
 		SequentialProcessL2Block = false
+		PublishPendingReceipts = false
+		PendingReceiptsTTL = "10m"
 	[Sequencer.Finalizer.Metrics]
 		Interval = "60m"
 		EnableLog = true
@@ -175,6 +199,12 @@ StateConsistencyCheckInterval = "5s"
 		Version = 0
 		WriteTimeout = "5s"
 		Enabled = false
+	[Sequencer.HA]
+		Enabled = false
+		InstanceID = ""
+		LeaseDuration = "15s"
+		RenewInterval = "5s"
+		AcquireInterval = "5s"
 
 [SequenceSender]
 WaitPeriodSendSequence = "5s"
@@ -186,6 +216,19 @@ L2Coinbase = "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
 PrivateKey = {Path = "/pk/sequencer.keystore", Password = "testonly"}
 GasOffset = 80000
 MaxBatchesForL1 = 300
+BatchDataInBlobs = false
+BlobsForkID = 0
+	[SequenceSender.SendPolicy]
+	Enabled = false
+	DryRun = false
+	MaxBaseFee = 0
+	TargetCostPerBatch = 0
+	FixedGasPerSequence = 100000
+	GasPerBatch = 20000
+
+[DataCommittee]
+ErasureCoding = false
+DataShards = 0
 
 [Aggregator]
 Host = "0.0.0.0"
@@ -204,6 +247,27 @@ SettlementBackend = "agglayer"
 AggLayerTxTimeout = "5m"
 AggLayerURL = "http://zkevm-agglayer"
 SequencerPrivateKey = {Path = "/pk/sequencer.keystore", Password = "testonly"}
+	[Aggregator.API]
+	Enabled = false
+	Host = "0.0.0.0"
+	Port = 50082
+	AdminToken = ""
+	[Aggregator.MultiSettlement]
+	Sinks = []
+	Required = []
+	MaxRetries = 3
+	RetryInterval = "10s"
+	WebhookURL = ""
+	WebhookTimeout = "30s"
+	[Aggregator.CostProfitability]
+	VerifyBatchesGas = 350000
+	MaxL1CostPerBatch = "0.005"
+	MaxBtcCostPerBatch = 1000
+	FallbackBtcFeeRate = 10
+	[Aggregator.Archive]
+	Enabled = false
+	Backend = "local"
+	Path = "/archive/proofs"
 
 [L2GasPriceSuggester]
 Type = "follower"
@@ -0,0 +1,298 @@
+// Package backfill audits the L2 data stored locally for virtual batches and refills it from the
+// state.batch_data_backup table or the data availability layer when it's missing or corrupted.
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability"
+	"github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Status is the result of checking the L2 data of a batch against the hash sequenced on L1
+type Status string
+
+const (
+	// StatusOK means the stored data matches the sequenced hash
+	StatusOK Status = "ok"
+	// StatusMissing means there is no data stored for the batch
+	StatusMissing Status = "missing"
+	// StatusMismatch means the stored data doesn't match the sequenced hash
+	StatusMismatch Status = "mismatch"
+	// StatusUnknown means the sequenced hash couldn't be retrieved from L1
+	StatusUnknown Status = "unknown"
+)
+
+// RepairSource is where the data used to repair a batch was taken from
+type RepairSource string
+
+const (
+	// SourceBackup is the state.batch_data_backup table
+	SourceBackup RepairSource = "backup"
+	// SourceDataAvailability is the data availability layer, queried with the configured priority
+	SourceDataAvailability RepairSource = "dataAvailability"
+)
+
+// BatchReport is the audit result of a virtual batch whose data is not ok
+type BatchReport struct {
+	BatchNumber  uint64       `json:"batchNumber"`
+	L1TxHash     common.Hash  `json:"l1TxHash"`
+	ExpectedHash common.Hash  `json:"expectedHash"`
+	ActualHash   common.Hash  `json:"actualHash"`
+	Status       Status       `json:"status"`
+	BackupStatus Status       `json:"backupStatus"`
+	Repaired     bool         `json:"repaired"`
+	RepairSource RepairSource `json:"repairSource,omitempty"`
+	Error        string       `json:"error,omitempty"`
+
+	backupData []byte
+}
+
+// Report is the result of auditing a range of virtual batches
+type Report struct {
+	FromBatch  uint64        `json:"fromBatch"`
+	ToBatch    uint64        `json:"toBatch"`
+	Checked    uint64        `json:"checked"`
+	Forced     uint64        `json:"forced"`
+	Missing    uint64        `json:"missing"`
+	Mismatched uint64        `json:"mismatched"`
+	Unknown    uint64        `json:"unknown"`
+	Repaired   uint64        `json:"repaired"`
+	Failed     uint64        `json:"failed"`
+	Batches    []BatchReport `json:"batches"`
+}
+
+// Auditor checks the L2 data of the virtual batches and optionally repairs it
+type Auditor struct {
+	state    stateInterface
+	etherman ethermanInterface
+	da       dataavailability.BatchDataProvider
+	repair   bool
+}
+
+// New creates an Auditor. If repair is false the state is not modified
+func New(state stateInterface, etherman ethermanInterface, da dataavailability.BatchDataProvider, repair bool) *Auditor {
+	return &Auditor{
+		state:    state,
+		etherman: etherman,
+		da:       da,
+		repair:   repair,
+	}
+}
+
+// sequence groups the virtual batches sequenced by the same L1 tx
+type sequence struct {
+	txHash                  common.Hash
+	l1BlockNumber           uint64
+	infos                   map[uint64]etherman.SequencedBatchDAInfo
+	dataAvailabilityMessage []byte
+	err                     error
+	pending                 []*BatchReport
+}
+
+// Run audits the virtual batches in the range [fromBatch, toBatch]. If toBatch is 0, the last virtual batch is used
+func (a *Auditor) Run(ctx context.Context, fromBatch, toBatch uint64) (*Report, error) {
+	if fromBatch == 0 {
+		fromBatch = 1
+	}
+	if toBatch == 0 {
+		lastVirtualBatchNum, err := a.state.GetLastVirtualBatchNum(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last virtual batch number: %w", err)
+		}
+		toBatch = lastVirtualBatchNum
+	}
+	report := &Report{
+		FromBatch: fromBatch,
+		ToBatch:   toBatch,
+		Batches:   []BatchReport{},
+	}
+
+	var seq *sequence
+	for batchNumber := fromBatch; batchNumber <= toBatch; batchNumber++ {
+		virtualBatch, err := a.state.GetVirtualBatch(ctx, batchNumber, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get virtual batch %d: %w", batchNumber, err)
+		}
+		if seq == nil || seq.txHash != virtualBatch.TxHash {
+			if seq != nil {
+				a.repairSequence(ctx, seq, report)
+			}
+			seq = a.loadSequence(ctx, virtualBatch.TxHash, virtualBatch.BlockNumber)
+		}
+
+		batchReport, err := a.auditBatch(ctx, seq, virtualBatch)
+		if err != nil {
+			return nil, err
+		}
+		if batchReport != nil {
+			report.Checked++
+			switch batchReport.Status {
+			case StatusOK:
+				continue
+			case StatusMissing:
+				report.Missing++
+			case StatusMismatch:
+				report.Mismatched++
+			case StatusUnknown:
+				report.Unknown++
+			}
+			seq.pending = append(seq.pending, batchReport)
+		} else {
+			report.Forced++
+		}
+	}
+	if seq != nil {
+		a.repairSequence(ctx, seq, report)
+	}
+
+	return report, nil
+}
+
+func (a *Auditor) loadSequence(ctx context.Context, txHash common.Hash, l1BlockNumber uint64) *sequence {
+	seq := &sequence{
+		txHash:        txHash,
+		l1BlockNumber: l1BlockNumber,
+		infos:         make(map[uint64]etherman.SequencedBatchDAInfo),
+	}
+	infos, dataAvailabilityMessage, err := a.etherman.GetSequencedBatchesDAInfo(ctx, txHash)
+	if err != nil {
+		log.Warnf("failed to get sequenced batches info from L1 tx %s: %v", txHash.String(), err)
+		seq.err = err
+		return seq
+	}
+	for _, info := range infos {
+		seq.infos[info.BatchNumber] = info
+	}
+	seq.dataAvailabilityMessage = dataAvailabilityMessage
+	return seq
+}
+
+// auditBatch checks the data of a virtual batch. Returns nil if the batch is forced, as its data is not handled by the DA layer
+func (a *Auditor) auditBatch(ctx context.Context, seq *sequence, virtualBatch *state.VirtualBatch) (*BatchReport, error) {
+	batchReport := &BatchReport{
+		BatchNumber: virtualBatch.BatchNumber,
+		L1TxHash:    virtualBatch.TxHash,
+	}
+	info, ok := seq.infos[virtualBatch.BatchNumber]
+	if !ok {
+		batchReport.Status = StatusUnknown
+		if seq.err != nil {
+			batchReport.Error = seq.err.Error()
+		} else {
+			batchReport.Error = fmt.Sprintf("batch %d not found in L1 tx %s", virtualBatch.BatchNumber, virtualBatch.TxHash.String())
+		}
+		return batchReport, nil
+	}
+	if info.IsForced {
+		return nil, nil
+	}
+	batchReport.ExpectedHash = info.TransactionsHash
+
+	batch, err := a.state.GetBatchByNumber(ctx, virtualBatch.BatchNumber, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch %d: %w", virtualBatch.BatchNumber, err)
+	}
+	batchReport.ActualHash = crypto.Keccak256Hash(batch.BatchL2Data)
+	batchReport.Status = checkData(batch.BatchL2Data, info.TransactionsHash)
+	if batchReport.Status == StatusOK {
+		return batchReport, nil
+	}
+
+	backup, err := a.state.GetBatchL2DataByNumbersFromBackup(ctx, []uint64{virtualBatch.BatchNumber}, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get backup data of batch %d: %w", virtualBatch.BatchNumber, err)
+	}
+	batchReport.BackupStatus = checkData(backup[virtualBatch.BatchNumber], info.TransactionsHash)
+	if batchReport.BackupStatus == StatusOK {
+		batchReport.backupData = backup[virtualBatch.BatchNumber]
+	}
+	return batchReport, nil
+}
+
+// repairSequence refills the data of the pending batches of a sequence, first from the backup table and then from the DA layer
+func (a *Auditor) repairSequence(ctx context.Context, seq *sequence, report *Report) {
+	defer func() {
+		for _, batchReport := range seq.pending {
+			if batchReport.Repaired {
+				report.Repaired++
+			} else if a.repair {
+				report.Failed++
+			}
+			report.Batches = append(report.Batches, *batchReport)
+		}
+	}()
+	if !a.repair {
+		return
+	}
+
+	var (
+		batchNums   []uint64
+		batchHashes []common.Hash
+		toRetrieve  []*BatchReport
+	)
+	for _, batchReport := range seq.pending {
+		if batchReport.Status == StatusUnknown {
+			continue
+		}
+		if batchReport.backupData != nil {
+			a.updateBatchData(ctx, batchReport, batchReport.backupData, SourceBackup)
+			if batchReport.Repaired {
+				continue
+			}
+		}
+		batchNums = append(batchNums, batchReport.BatchNumber)
+		batchHashes = append(batchHashes, batchReport.ExpectedHash)
+		toRetrieve = append(toRetrieve, batchReport)
+	}
+	if len(toRetrieve) == 0 {
+		return
+	}
+
+	data, err := a.da.GetBatchL2Data(seq.l1BlockNumber, batchNums, batchHashes, seq.dataAvailabilityMessage)
+	if err == nil && len(data) != len(toRetrieve) {
+		err = fmt.Errorf("failed to retrieve all batch data. Expected %d, got %d", len(toRetrieve), len(data))
+	}
+	if err != nil {
+		log.Errorf("failed to retrieve data of batches %v from the data availability layer: %v", batchNums, err)
+		for _, batchReport := range toRetrieve {
+			batchReport.Error = err.Error()
+		}
+		return
+	}
+	for i, batchReport := range toRetrieve {
+		a.updateBatchData(ctx, batchReport, data[i], SourceDataAvailability)
+	}
+}
+
+func (a *Auditor) updateBatchData(ctx context.Context, batchReport *BatchReport, data []byte, source RepairSource) {
+	if checkData(data, batchReport.ExpectedHash) != StatusOK {
+		batchReport.Error = fmt.Sprintf("data from %s doesn't match the expected hash %s", source, batchReport.ExpectedHash.String())
+		return
+	}
+	if err := a.state.UpdateBatchL2Data(ctx, batchReport.BatchNumber, data, nil); err != nil {
+		log.Errorf("failed to update data of batch %d: %v", batchReport.BatchNumber, err)
+		batchReport.Error = err.Error()
+		return
+	}
+	log.Infof("repaired data of batch %d from %s", batchReport.BatchNumber, source)
+	batchReport.Repaired = true
+	batchReport.RepairSource = source
+	batchReport.Error = ""
+}
+
+func checkData(data []byte, expectedHash common.Hash) Status {
+	if crypto.Keccak256Hash(data) == expectedHash {
+		return StatusOK
+	}
+	if len(data) == 0 {
+		return StatusMissing
+	}
+	return StatusMismatch
+}
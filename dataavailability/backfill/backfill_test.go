@@ -0,0 +1,112 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditor(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		txA     = common.HexToHash("0xa")
+		txB     = common.HexToHash("0xb")
+		daMsg   = []byte{0xda}
+		data    = map[uint64][]byte{1: {1}, 2: {2}, 3: {3}, 4: {4}}
+		hashOf  = func(n uint64) common.Hash { return crypto.Keccak256Hash(data[n]) }
+		dbBatch = map[uint64][]byte{1: {1}, 2: nil, 3: {0xff}, 4: {4}}
+	)
+
+	newMocks := func() (*stateMock, *ethermanMock, *batchDataProviderMock) {
+		st := newStateMock(t)
+		eth := newEthermanMock(t)
+		da := new(batchDataProviderMock)
+
+		st.On("GetLastVirtualBatchNum", ctx, nil).Return(uint64(4), nil).Maybe()
+		for n := uint64(1); n <= 4; n++ {
+			txHash, blockNumber := txA, uint64(10)
+			if n == 4 {
+				txHash, blockNumber = txB, uint64(11)
+			}
+			st.On("GetVirtualBatch", ctx, n, nil).Return(&state.VirtualBatch{BatchNumber: n, TxHash: txHash, BlockNumber: blockNumber}, nil)
+		}
+		for n := uint64(1); n <= 3; n++ {
+			st.On("GetBatchByNumber", ctx, n, nil).Return(&state.Batch{BatchNumber: n, BatchL2Data: dbBatch[n]}, nil)
+		}
+		st.On("GetBatchL2DataByNumbersFromBackup", ctx, []uint64{2}, nil).Return(map[uint64][]byte{2: data[2]}, nil)
+		st.On("GetBatchL2DataByNumbersFromBackup", ctx, []uint64{3}, nil).Return(map[uint64][]byte{}, nil)
+
+		eth.On("GetSequencedBatchesDAInfo", ctx, txA).Return([]etherman.SequencedBatchDAInfo{
+			{BatchNumber: 1, TransactionsHash: hashOf(1)},
+			{BatchNumber: 2, TransactionsHash: hashOf(2)},
+			{BatchNumber: 3, TransactionsHash: hashOf(3)},
+		}, daMsg, nil)
+		eth.On("GetSequencedBatchesDAInfo", ctx, txB).Return([]etherman.SequencedBatchDAInfo{
+			{BatchNumber: 4, TransactionsHash: hashOf(4), IsForced: true},
+		}, []byte{}, nil)
+		return st, eth, da
+	}
+
+	t.Run("audit only", func(t *testing.T) {
+		st, eth, da := newMocks()
+		report, err := New(st, eth, da, false).Run(ctx, 0, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(1), report.FromBatch)
+		assert.Equal(t, uint64(4), report.ToBatch)
+		assert.Equal(t, uint64(3), report.Checked)
+		assert.Equal(t, uint64(1), report.Forced)
+		assert.Equal(t, uint64(1), report.Missing)
+		assert.Equal(t, uint64(1), report.Mismatched)
+		assert.Equal(t, uint64(0), report.Repaired)
+		assert.Equal(t, uint64(0), report.Failed)
+		require.Len(t, report.Batches, 2)
+		assert.Equal(t, StatusMissing, report.Batches[0].Status)
+		assert.Equal(t, StatusOK, report.Batches[0].BackupStatus)
+		assert.Equal(t, StatusMismatch, report.Batches[1].Status)
+		assert.Equal(t, StatusMissing, report.Batches[1].BackupStatus)
+		st.AssertNotCalled(t, "UpdateBatchL2Data", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		da.AssertExpectations(t)
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		st, eth, da := newMocks()
+		st.On("UpdateBatchL2Data", ctx, uint64(2), data[2], nil).Return(nil).Once()
+		st.On("UpdateBatchL2Data", ctx, uint64(3), data[3], nil).Return(nil).Once()
+		da.On("GetBatchL2Data", uint64(10), []uint64{3}, []common.Hash{hashOf(3)}, daMsg).Return([][]byte{data[3]}, nil).Once()
+
+		report, err := New(st, eth, da, true).Run(ctx, 1, 4)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(2), report.Repaired)
+		assert.Equal(t, uint64(0), report.Failed)
+		require.Len(t, report.Batches, 2)
+		assert.Equal(t, SourceBackup, report.Batches[0].RepairSource)
+		assert.Equal(t, SourceDataAvailability, report.Batches[1].RepairSource)
+		da.AssertExpectations(t)
+	})
+
+	t.Run("repair fails", func(t *testing.T) {
+		st, eth, da := newMocks()
+		st.On("UpdateBatchL2Data", ctx, uint64(2), data[2], nil).Return(nil).Once()
+		da.On("GetBatchL2Data", uint64(10), []uint64{3}, []common.Hash{hashOf(3)}, daMsg).Return(nil, errors.New("unavailable")).Once()
+
+		report, err := New(st, eth, da, true).Run(ctx, 1, 4)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(1), report.Repaired)
+		assert.Equal(t, uint64(1), report.Failed)
+		require.Len(t, report.Batches, 2)
+		assert.False(t, report.Batches[1].Repaired)
+		assert.Equal(t, "unavailable", report.Batches[1].Error)
+		da.AssertExpectations(t)
+	})
+}
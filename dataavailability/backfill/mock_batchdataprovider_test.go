@@ -0,0 +1,63 @@
+// Code generated by mockery v2.39.0. DO NOT EDIT.
+
+package backfill
+
+import (
+	common "github.com/ethereum/go-ethereum/common"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// batchDataProviderMock is an autogenerated mock type for the BatchDataProvider type
+type batchDataProviderMock struct {
+	mock.Mock
+}
+
+// GetBatchL2Data provides a mock function with given fields: l1BlockNumber, batchNum, batchHashes, dataAvailabilityMessage
+func (_m *batchDataProviderMock) GetBatchL2Data(l1BlockNumber uint64, batchNum []uint64, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
+	ret := _m.Called(l1BlockNumber, batchNum, batchHashes, dataAvailabilityMessage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchL2Data")
+	}
+
+	var r0 [][]byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, []uint64, []common.Hash, []byte) ([][]byte, error)); ok {
+		return rf(l1BlockNumber, batchNum, batchHashes, dataAvailabilityMessage)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, []uint64, []common.Hash, []byte) [][]byte); ok {
+		r0 = rf(l1BlockNumber, batchNum, batchHashes, dataAvailabilityMessage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, []uint64, []common.Hash, []byte) error); ok {
+		r1 = rf(l1BlockNumber, batchNum, batchHashes, dataAvailabilityMessage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reset provides a mock function with given fields: fromBlock
+func (_m *batchDataProviderMock) Reset(fromBlock uint64) {
+	_m.Called(fromBlock)
+}
+
+// newBatchDataProviderMock creates a new instance of batchDataProviderMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newBatchDataProviderMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *batchDataProviderMock {
+	mock := &batchDataProviderMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
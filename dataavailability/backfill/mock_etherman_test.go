@@ -0,0 +1,71 @@
+// Code generated by mockery v2.39.0. DO NOT EDIT.
+
+package backfill
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	etherman "github.com/0xPolygonHermez/zkevm-node/etherman"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ethermanMock is an autogenerated mock type for the ethermanInterface type
+type ethermanMock struct {
+	mock.Mock
+}
+
+// GetSequencedBatchesDAInfo provides a mock function with given fields: ctx, txHash
+func (_m *ethermanMock) GetSequencedBatchesDAInfo(ctx context.Context, txHash common.Hash) ([]etherman.SequencedBatchDAInfo, []byte, error) {
+	ret := _m.Called(ctx, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequencedBatchesDAInfo")
+	}
+
+	var r0 []etherman.SequencedBatchDAInfo
+	var r1 []byte
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) ([]etherman.SequencedBatchDAInfo, []byte, error)); ok {
+		return rf(ctx, txHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) []etherman.SequencedBatchDAInfo); ok {
+		r0 = rf(ctx, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]etherman.SequencedBatchDAInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) []byte); ok {
+		r1 = rf(ctx, txHash)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, common.Hash) error); ok {
+		r2 = rf(ctx, txHash)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// newEthermanMock creates a new instance of ethermanMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newEthermanMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ethermanMock {
+	mock := &ethermanMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -39,12 +39,27 @@ type DataAvailability struct {
 	isTrustedSequencer bool
 	state              stateInterface
 	zkEVMClient        ZKEVMClientTrustedBatchesGetter
-	backend            DABackender
+	backends           backendProvider
 	dataSourcePriority []DataSourcePriority
 	ctx                context.Context
 }
 
-// New creates a DataAvailability instance
+// staticBackend always provides the same backend, regardless of the protocol set on L1
+type staticBackend struct {
+	backend DABackender
+}
+
+func (s staticBackend) Backend(ctx context.Context) (DABackender, error) {
+	return s.backend, nil
+}
+
+func (s staticBackend) BackendAt(ctx context.Context, l1BlockNumber uint64) (DABackender, error) {
+	return s.backend, nil
+}
+
+func (s staticBackend) Reset(fromBlock uint64) {}
+
+// New creates a DataAvailability instance that always uses the provided backend
 func New(
 	isTrustedSequencer bool,
 	backend DABackender,
@@ -52,9 +67,33 @@ func New(
 	zkEVMClient ZKEVMClientTrustedBatchesGetter,
 	priority []DataSourcePriority,
 ) (*DataAvailability, error) {
+	da := newDataAvailability(isTrustedSequencer, staticBackend{backend: backend}, state, zkEVMClient, priority)
+	err := backend.Init()
+	return da, err
+}
+
+// NewWithRegistry creates a DataAvailability instance that uses the backend of the data availability
+// protocol set on L1 at the time each sequence was sent
+func NewWithRegistry(
+	isTrustedSequencer bool,
+	registry *Registry,
+	state stateInterface,
+	zkEVMClient ZKEVMClientTrustedBatchesGetter,
+	priority []DataSourcePriority,
+) (*DataAvailability, error) {
+	return newDataAvailability(isTrustedSequencer, registry, state, zkEVMClient, priority), nil
+}
+
+func newDataAvailability(
+	isTrustedSequencer bool,
+	backends backendProvider,
+	state stateInterface,
+	zkEVMClient ZKEVMClientTrustedBatchesGetter,
+	priority []DataSourcePriority,
+) *DataAvailability {
 	da := &DataAvailability{
 		isTrustedSequencer: isTrustedSequencer,
-		backend:            backend,
+		backends:           backends,
 		state:              state,
 		zkEVMClient:        zkEVMClient,
 		ctx:                context.Background(),
@@ -63,8 +102,7 @@ func New(
 	if len(da.dataSourcePriority) == 0 {
 		da.dataSourcePriority = DefaultPriority
 	}
-	err := da.backend.Init()
-	return da, err
+	return da
 }
 
 // PostSequence sends the sequence data to the data availability backend, and returns the dataAvailabilityMessage
@@ -77,14 +115,23 @@ func (d *DataAvailability) PostSequence(ctx context.Context, sequences []types.S
 			batchesData = append(batchesData, batch.BatchL2Data)
 		}
 	}
-	return d.backend.PostSequence(ctx, batchesData)
+	backend, err := d.backends.Backend(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return backend.PostSequence(ctx, batchesData)
+}
+
+// Reset drops the data availability protocol changes done from fromBlock on, which have been reorged out of L1
+func (d *DataAvailability) Reset(fromBlock uint64) {
+	d.backends.Reset(fromBlock)
 }
 
 // GetBatchL2Data tries to return the data from a batch, in the following priorities. batchNums should not include forced batches.
 // 1. From local DB
 // 2. From Trusted Sequencer (if not self)
-// 3. From DA backend
-func (d *DataAvailability) GetBatchL2Data(batchNums []uint64, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
+// 3. From DA backend that was active at l1BlockNumber
+func (d *DataAvailability) GetBatchL2Data(l1BlockNumber uint64, batchNums []uint64, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
 	if len(batchNums) != len(batchHashes) {
 		return nil, fmt.Errorf(invalidBatchRetrievalArgs, len(batchNums), len(batchHashes))
 	}
@@ -113,7 +160,11 @@ func (d *DataAvailability) GetBatchL2Data(batchNums []uint64, batchHashes []comm
 				}
 			}
 		case External:
-			return d.backend.GetSequence(d.ctx, batchHashes, dataAvailabilityMessage)
+			backend, err := d.backends.BackendAt(d.ctx, l1BlockNumber)
+			if err != nil {
+				return nil, err
+			}
+			return backend.GetSequence(d.ctx, batchHashes, dataAvailabilityMessage)
 		default:
 			log.Warnf("invalid data retrieval priority: %s", p)
 		}
@@ -0,0 +1,13 @@
+package datacommittee
+
+// Config represents the configuration of the data availability committee backend
+type Config struct {
+	// ErasureCoding makes the sequencer send each committee member a Reed-Solomon shard of every batch
+	// instead of the full data, and makes the synchronizer rebuild the batches from the shards.
+	// The members must support the sharded endpoints, and all the nodes of the network must use the same value
+	ErasureCoding bool `mapstructure:"ErasureCoding"`
+	// DataShards is the amount of shards needed to rebuild a batch when ErasureCoding is enabled. The rest of
+	// the members receive parity shards. It can't be greater than the amount of required signatures, so the
+	// members that signed a sequence are always enough to rebuild it. If 0, the amount of required signatures is used
+	DataShards uint64 `mapstructure:"DataShards"`
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/0xPolygon/cdk-data-availability/client"
 	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee/shard"
 	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygondatacommittee"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -37,9 +38,11 @@ type DataCommittee struct {
 
 // DataCommitteeBackend implements the DAC integration
 type DataCommitteeBackend struct {
+	cfg                        Config
 	dataCommitteeContract      *polygondatacommittee.Polygondatacommittee
 	privKey                    *ecdsa.PrivateKey
 	dataCommitteeClientFactory client.Factory
+	shardClientFactory         shard.ClientFactory
 
 	committeeMembers        []DataCommitteeMember
 	selectedCommitteeMember int
@@ -48,6 +51,7 @@ type DataCommitteeBackend struct {
 
 // New creates an instance of DataCommitteeBackend
 func New(
+	cfg Config,
 	l1RPCURL string,
 	dataCommitteeAddr common.Address,
 	privKey *ecdsa.PrivateKey,
@@ -63,9 +67,11 @@ func New(
 		return nil, err
 	}
 	return &DataCommitteeBackend{
+		cfg:                        cfg,
 		dataCommitteeContract:      dataCommittee,
 		privKey:                    privKey,
 		dataCommitteeClientFactory: dataCommitteeClientFactory,
+		shardClientFactory:         shard.NewClientFactory(),
 		ctx:                        context.Background(),
 	}, nil
 }
@@ -92,7 +98,15 @@ func (d *DataCommitteeBackend) GetSequence(ctx context.Context, hashes []common.
 	// TODO: optimize this on the DAC side by implementing a multi batch retrieve api
 	var batchData [][]byte
 	for _, h := range hashes {
-		data, err := d.GetBatchL2Data(h)
+		var (
+			data []byte
+			err  error
+		)
+		if d.cfg.ErasureCoding {
+			data, err = d.getShardedBatchL2Data(ctx, h)
+		} else {
+			data, err = d.GetBatchL2Data(h)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -144,6 +158,34 @@ func (d *DataCommitteeBackend) GetBatchL2Data(hash common.Hash) ([]byte, error)
 	return nil, fmt.Errorf("couldn't get the data from any committee member")
 }
 
+// getShardedBatchL2Data rebuilds the data of a batch from the shards stored by the committee members.
+// It checks that it matches with the expected hash
+func (d *DataCommitteeBackend) getShardedBatchL2Data(ctx context.Context, hash common.Hash) ([]byte, error) {
+	var shards []shard.Shard
+	for i := range d.committeeMembers {
+		member := d.committeeMembers[(d.selectedCommitteeMember+i)%len(d.committeeMembers)]
+		log.Infof("trying to get shard from %s at %s", member.Addr.Hex(), member.URL)
+		c := d.shardClientFactory.New(member.URL)
+		s, err := c.GetShard(ctx, hash)
+		if err != nil {
+			log.Warnf("error getting shard from DAC node %s at %s: %s", member.Addr.Hex(), member.URL, err)
+			continue
+		}
+		if err := s.Verify(); err != nil {
+			log.Warnf("error getting shard from DAC node %s at %s: %s", member.Addr.Hex(), member.URL, err)
+			continue
+		}
+		shards = append(shards, *s)
+		if data, err := shard.Decode(hash, shards); err == nil {
+			return data, nil
+		}
+	}
+	if err := d.Init(); err != nil {
+		return nil, fmt.Errorf("error loading data committee: %s", err)
+	}
+	return nil, fmt.Errorf("couldn't get enough shards from the committee members to rebuild batch %s", hash.Hex())
+}
+
 type signatureMsg struct {
 	addr      common.Address
 	signature []byte
@@ -158,6 +200,9 @@ func (s *DataCommitteeBackend) PostSequence(ctx context.Context, batchesData [][
 	if err != nil {
 		return nil, err
 	}
+	if s.cfg.ErasureCoding {
+		return s.postShardedSequence(ctx, committee, batchesData)
+	}
 
 	// Authenticate as trusted sequencer by signing the sequences
 	sequence := daTypes.Sequence{}
@@ -172,11 +217,54 @@ func (s *DataCommitteeBackend) PostSequence(ctx context.Context, batchesData [][
 	// Request signatures to all members in parallel
 	ch := make(chan signatureMsg, len(committee.Members))
 	signatureCtx, cancelSignatureCollection := context.WithCancel(ctx)
+	defer cancelSignatureCollection()
 	for _, member := range committee.Members {
-		go requestSignatureFromMember(signatureCtx, *signedSequence, member, ch)
+		c := s.dataCommitteeClientFactory.New(member.URL)
+		go requestSignatureFromMember(signatureCtx, c, *signedSequence, member, ch)
 	}
+	return collectSignatures(committee, ch)
+}
 
-	// Collect signatures
+// postShardedSequence sends each member its shard of every batch, and collects their signatures
+func (s *DataCommitteeBackend) postShardedSequence(ctx context.Context, committee *DataCommittee, batchesData [][]byte) ([]byte, error) {
+	dataShards := s.cfg.DataShards
+	if dataShards == 0 {
+		dataShards = committee.RequiredSignatures
+	}
+	if dataShards == 0 || dataShards > committee.RequiredSignatures {
+		return nil, fmt.Errorf("invalid amount of data shards %d for a committee that requires %d signatures",
+			dataShards, committee.RequiredSignatures)
+	}
+	shardsByMember, err := shard.Encode(batchesData, int(dataShards), len(committee.Members))
+	if err != nil {
+		return nil, err
+	}
+
+	// Authenticate as trusted sequencer by signing the sequences
+	batchHashes := make([]common.Hash, 0, len(batchesData))
+	for _, batchData := range batchesData {
+		batchHashes = append(batchHashes, crypto.Keccak256Hash(batchData))
+	}
+	hashToSign := shard.HashToSign(batchHashes)
+	signature, err := shard.Sign(hashToSign, s.privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Request signatures to all members in parallel
+	ch := make(chan signatureMsg, len(committee.Members))
+	signatureCtx, cancelSignatureCollection := context.WithCancel(ctx)
+	defer cancelSignatureCollection()
+	for i, member := range committee.Members {
+		c := s.shardClientFactory.New(member.URL)
+		signedShards := shard.SignedShards{Shards: shardsByMember[i], Signature: signature}
+		go requestShardsSignatureFromMember(signatureCtx, c, signedShards, member, ch)
+	}
+	return collectSignatures(committee, ch)
+}
+
+// collectSignatures waits until the required amount of signatures is received, and returns the dataAvailabilityMessage
+func collectSignatures(committee *DataCommittee, ch chan signatureMsg) ([]byte, error) {
 	msgs := []signatureMsg{}
 	var (
 		collectedSignatures uint64
@@ -188,7 +276,6 @@ func (s *DataCommitteeBackend) PostSequence(ctx context.Context, batchesData [][
 			log.Errorf("error when trying to get signature from %s: %s", msg.addr, msg.err)
 			failedToCollect++
 			if len(committee.Members)-int(failedToCollect) < int(committee.RequiredSignatures) {
-				cancelSignatureCollection()
 				return nil, errors.New("too many members failed to send their signature")
 			}
 		} else {
@@ -198,15 +285,11 @@ func (s *DataCommitteeBackend) PostSequence(ctx context.Context, batchesData [][
 		msgs = append(msgs, msg)
 	}
 
-	// Stop requesting as soon as we have N valid signatures
-	cancelSignatureCollection()
-
 	return buildSignaturesAndAddrs(signatureMsgs(msgs), committee.Members), nil
 }
 
-func requestSignatureFromMember(ctx context.Context, signedSequence daTypes.SignedSequence, member DataCommitteeMember, ch chan signatureMsg) {
+func requestSignatureFromMember(ctx context.Context, c client.Client, signedSequence daTypes.SignedSequence, member DataCommitteeMember, ch chan signatureMsg) {
 	// request
-	c := client.New(member.URL)
 	log.Infof("sending request to sign the sequence to %s at %s", member.Addr.Hex(), member.URL)
 	signature, err := c.SignSequence(signedSequence)
 	if err != nil {
@@ -239,6 +322,31 @@ func requestSignatureFromMember(ctx context.Context, signedSequence daTypes.Sign
 	}
 }
 
+func requestShardsSignatureFromMember(ctx context.Context, c shard.Client, signedShards shard.SignedShards,
+	member DataCommitteeMember, ch chan signatureMsg) {
+	log.Infof("sending request to sign the sharded sequence to %s at %s", member.Addr.Hex(), member.URL)
+	signatures, err := c.SignShards(ctx, signedShards)
+	if err != nil {
+		ch <- signatureMsg{
+			addr: member.Addr,
+			err:  err,
+		}
+		return
+	}
+	// verify returned signatures: the member must attest it holds its shards besides signing the batches
+	if err := signatures.Verify(signedShards, member.Addr); err != nil {
+		ch <- signatureMsg{
+			addr: member.Addr,
+			err:  err,
+		}
+		return
+	}
+	ch <- signatureMsg{
+		addr:      member.Addr,
+		signature: signatures.Signature,
+	}
+}
+
 func buildSignaturesAndAddrs(sigs signatureMsgs, members []DataCommitteeMember) []byte {
 	const (
 		sigLen  = 65
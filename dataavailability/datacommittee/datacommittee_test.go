@@ -1,9 +1,17 @@
 package datacommittee
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
 	"math/big"
+	"net/http/httptest"
+	"sort"
 	"testing"
 
+	"github.com/0xPolygon/cdk-data-availability/client"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee/localdac"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee/shard"
 	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygondatacommittee"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -53,6 +61,151 @@ func TestUpdateDataCommitteeEvent(t *testing.T) {
 	assert.Equal(t, expectedSetup, *actualSetup)
 }
 
+func TestPostAndGetSequenceWithLocalDAC(t *testing.T) {
+	dac, ethBackend, auth, da := newTestingEnv(t)
+
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	dac.privKey = sequencerKey
+	dac.dataCommitteeClientFactory = client.NewFactory()
+	sequencerAddr := crypto.PubkeyToAddress(sequencerKey.PublicKey)
+
+	// Start the local DAC nodes, sorted by address as required by the contract
+	const nMembers = 4
+	nodes := make([]*localdac.Node, 0, nMembers)
+	for i := 0; i < nMembers; i++ {
+		var memberKey *ecdsa.PrivateKey
+		memberKey, err = crypto.GenerateKey()
+		require.NoError(t, err)
+		nodes = append(nodes, localdac.New(memberKey, sequencerAddr, nil))
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return bytes.Compare(nodes[i].Addr().Bytes(), nodes[j].Addr().Bytes()) < 0
+	})
+	URLs := []string{}
+	addrsBytes := []byte{}
+	for _, node := range nodes {
+		srv := httptest.NewServer(node)
+		t.Cleanup(srv.Close)
+		URLs = append(URLs, srv.URL)
+		addrsBytes = append(addrsBytes, node.Addr().Bytes()...)
+	}
+	_, err = da.SetupCommittee(auth, big.NewInt(2), URLs, addrsBytes)
+	require.NoError(t, err)
+	ethBackend.Commit()
+	require.NoError(t, dac.Init())
+
+	batchesData := [][]byte{{1, 2, 3}, {4, 5, 6}}
+	hashes := []common.Hash{crypto.Keccak256Hash(batchesData[0]), crypto.Keccak256Hash(batchesData[1])}
+
+	// Too many faulty members
+	nodes[0].SetFaults(localdac.Faults{Drop: true})
+	nodes[1].SetFaults(localdac.Faults{BadSignature: true})
+	nodes[2].SetFaults(localdac.Faults{Drop: true})
+	_, err = dac.PostSequence(context.Background(), batchesData)
+	require.Error(t, err)
+
+	// Enough honest members
+	nodes[2].SetFaults(localdac.Faults{})
+	nodes[3].SetFaults(localdac.Faults{WrongData: true})
+	msg, err := dac.PostSequence(context.Background(), batchesData)
+	require.NoError(t, err)
+	const (
+		sigLen  = 65
+		addrLen = 20
+	)
+	assert.Equal(t, 2*sigLen+nMembers*addrLen, len(msg))
+
+	// Data is served by any member that stored it and returns it untampered
+	actual, err := dac.GetSequence(context.Background(), hashes, msg)
+	require.NoError(t, err)
+	assert.Equal(t, batchesData, actual)
+
+	nodes[1].SetFaults(localdac.Faults{Drop: true})
+	nodes[2].SetFaults(localdac.Faults{Drop: true})
+	_, err = dac.GetSequence(context.Background(), hashes, msg)
+	require.Error(t, err)
+}
+
+func TestPostAndGetShardedSequenceWithLocalDAC(t *testing.T) {
+	dac, ethBackend, auth, da := newTestingEnv(t)
+
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	dac.cfg = Config{ErasureCoding: true}
+	dac.privKey = sequencerKey
+	dac.shardClientFactory = shard.NewClientFactory()
+	sequencerAddr := crypto.PubkeyToAddress(sequencerKey.PublicKey)
+
+	// Start the local DAC nodes, sorted by address as required by the contract
+	const nMembers = 5
+	stores := make(map[common.Address]*localdac.MemoryStore, nMembers)
+	nodes := make([]*localdac.Node, 0, nMembers)
+	for i := 0; i < nMembers; i++ {
+		var memberKey *ecdsa.PrivateKey
+		memberKey, err = crypto.GenerateKey()
+		require.NoError(t, err)
+		store := localdac.NewMemoryStore()
+		node := localdac.New(memberKey, sequencerAddr, store)
+		stores[node.Addr()] = store
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return bytes.Compare(nodes[i].Addr().Bytes(), nodes[j].Addr().Bytes()) < 0
+	})
+	URLs := []string{}
+	addrsBytes := []byte{}
+	for _, node := range nodes {
+		srv := httptest.NewServer(node)
+		t.Cleanup(srv.Close)
+		URLs = append(URLs, srv.URL)
+		addrsBytes = append(addrsBytes, node.Addr().Bytes()...)
+	}
+	_, err = da.SetupCommittee(auth, big.NewInt(3), URLs, addrsBytes)
+	require.NoError(t, err)
+	ethBackend.Commit()
+	require.NoError(t, dac.Init())
+
+	batchesData := [][]byte{bytes.Repeat([]byte{1, 2, 3}, 100), {4, 5, 6}}
+	hashes := []common.Hash{crypto.Keccak256Hash(batchesData[0]), crypto.Keccak256Hash(batchesData[1])}
+
+	// More data shards than required signatures
+	dac.cfg.DataShards = 4
+	_, err = dac.PostSequence(context.Background(), batchesData)
+	require.ErrorContains(t, err, "invalid amount of data shards")
+
+	// Defaults to the required signatures
+	dac.cfg.DataShards = 0
+	nodes[0].SetFaults(localdac.Faults{Drop: true})
+	nodes[1].SetFaults(localdac.Faults{BadSignature: true})
+	msg, err := dac.PostSequence(context.Background(), batchesData)
+	require.NoError(t, err)
+	const (
+		sigLen  = 65
+		addrLen = 20
+	)
+	assert.Equal(t, 3*sigLen+nMembers*addrLen, len(msg))
+
+	// Members store a shard instead of the full batch
+	for _, node := range nodes[1:] {
+		s, err := stores[node.Addr()].GetShard(hashes[0])
+		require.NoError(t, err)
+		assert.Less(t, len(s.Data), len(batchesData[0]))
+		_, err = stores[node.Addr()].GetOffChainData(hashes[0])
+		require.ErrorIs(t, err, localdac.ErrNotFound)
+	}
+
+	// Any 3 untampered shards rebuild the data
+	nodes[2].SetFaults(localdac.Faults{WrongData: true})
+	actual, err := dac.GetSequence(context.Background(), hashes, msg)
+	require.NoError(t, err)
+	assert.Equal(t, batchesData, actual)
+
+	nodes[3].SetFaults(localdac.Faults{Drop: true})
+	_, err = dac.GetSequence(context.Background(), hashes, msg)
+	require.Error(t, err)
+}
+
 func init() {
 	log.Init(log.Config{
 		Level:   "debug",
@@ -126,6 +279,7 @@ func newSimulatedDacman(t *testing.T, auth *bind.TransactOpts) (
 
 	c := &DataCommitteeBackend{
 		dataCommitteeContract: da,
+		ctx:                   context.Background(),
 	}
 	return c, client, da, nil
 }
@@ -0,0 +1,137 @@
+// Package erasure implements a systematic Reed-Solomon code over GF(2^8). Data is split into
+// dataShards shards and extended with parity shards, so it can be rebuilt from any dataShards of them.
+package erasure
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrInvalidShardCount is returned when the amount of shards is not supported
+	ErrInvalidShardCount = errors.New("invalid shard count")
+	// ErrNotEnoughShards is returned when there are less shards than needed to rebuild the data
+	ErrNotEnoughShards = errors.New("not enough shards to rebuild the data")
+	// ErrInvalidShardSize is returned when the shards don't have the expected size
+	ErrInvalidShardSize = errors.New("invalid shard size")
+	// ErrSingularMatrix is returned when the decoding matrix can't be inverted
+	ErrSingularMatrix = errors.New("singular decoding matrix")
+)
+
+// Codec encodes and decodes data using a fixed amount of data and parity shards
+type Codec struct {
+	dataShards  int
+	totalShards int
+	// encoding has totalShards rows, the first dataShards of them being the identity matrix
+	encoding matrix
+}
+
+// New creates a Codec. The total amount of shards can't exceed 256
+func New(dataShards, parityShards int) (*Codec, error) {
+	if dataShards <= 0 || parityShards < 0 || dataShards+parityShards > fieldSize {
+		return nil, fmt.Errorf("%w: %d data shards and %d parity shards", ErrInvalidShardCount, dataShards, parityShards)
+	}
+	totalShards := dataShards + parityShards
+	v := vandermonde(totalShards, dataShards)
+	top, err := v[:dataShards].invert()
+	if err != nil {
+		return nil, err
+	}
+	return &Codec{
+		dataShards:  dataShards,
+		totalShards: totalShards,
+		encoding:    v.multiply(top),
+	}, nil
+}
+
+// DataShards returns the amount of shards needed to rebuild the data
+func (c *Codec) DataShards() int {
+	return c.dataShards
+}
+
+// TotalShards returns the amount of shards produced by Encode
+func (c *Codec) TotalShards() int {
+	return c.totalShards
+}
+
+// ShardSize returns the size of each shard produced when encoding size bytes
+func (c *Codec) ShardSize(size int) int {
+	return (size + c.dataShards - 1) / c.dataShards
+}
+
+// Encode splits data into the data shards, padding the last one with zeros, and computes the parity shards.
+// The original length of the data is needed to decode it
+func (c *Codec) Encode(data []byte) [][]byte {
+	shardSize := c.ShardSize(len(data))
+	padded := make([]byte, shardSize*c.dataShards)
+	copy(padded, data)
+
+	shards := make([][]byte, c.totalShards)
+	for i := 0; i < c.dataShards; i++ {
+		shards[i] = padded[i*shardSize : (i+1)*shardSize]
+	}
+	for i := c.dataShards; i < c.totalShards; i++ {
+		shards[i] = make([]byte, shardSize)
+		c.combine(c.encoding[i], shards[:c.dataShards], shards[i])
+	}
+	return shards
+}
+
+// Decode rebuilds the original size bytes of data from the provided shards, indexed by their position.
+// Only dataShards of them are used
+func (c *Codec) Decode(shards map[int][]byte, size int) ([]byte, error) {
+	shardSize := c.ShardSize(size)
+	indexes := make([]int, 0, len(shards))
+	for i, shard := range shards {
+		if i < 0 || i >= c.totalShards {
+			return nil, fmt.Errorf("%w: index %d out of range", ErrInvalidShardCount, i)
+		}
+		if len(shard) != shardSize {
+			return nil, fmt.Errorf("%w: shard %d has %d bytes, expected %d", ErrInvalidShardSize, i, len(shard), shardSize)
+		}
+		indexes = append(indexes, i)
+	}
+	if len(indexes) < c.dataShards {
+		return nil, fmt.Errorf("%w: got %d, need %d", ErrNotEnoughShards, len(indexes), c.dataShards)
+	}
+	// Prefer data shards, as they don't need to be decoded
+	sort.Ints(indexes)
+	indexes = indexes[:c.dataShards]
+
+	sub := newMatrix(c.dataShards, c.dataShards)
+	inputs := make([][]byte, c.dataShards)
+	for r, i := range indexes {
+		copy(sub[r], c.encoding[i])
+		inputs[r] = shards[i]
+	}
+	decoding, err := sub.invert()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, shardSize*c.dataShards)
+	for i := 0; i < c.dataShards; i++ {
+		if indexes[i] == i {
+			data = append(data, inputs[i]...)
+			continue
+		}
+		shard := make([]byte, shardSize)
+		c.combine(decoding[i], inputs, shard)
+		data = append(data, shard...)
+	}
+	return data[:size], nil
+}
+
+// combine sets out to the linear combination of inputs with the provided coefficients
+func (c *Codec) combine(coefficients []byte, inputs [][]byte, out []byte) {
+	for i, input := range inputs {
+		coefficient := coefficients[i]
+		if coefficient == 0 {
+			continue
+		}
+		for j, b := range input {
+			out[j] ^= galMul(coefficient, b)
+		}
+	}
+}
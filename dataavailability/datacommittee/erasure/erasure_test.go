@@ -0,0 +1,51 @@
+package erasure
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	codec, err := New(3, 2)
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, 2, 3, 100, 1000} {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		shards := codec.Encode(data)
+		require.Equal(t, codec.TotalShards(), len(shards))
+
+		// Any 3 shards rebuild the data
+		for a := 0; a < len(shards); a++ {
+			for b := a + 1; b < len(shards); b++ {
+				for c := b + 1; c < len(shards); c++ {
+					actual, err := codec.Decode(map[int][]byte{a: shards[a], b: shards[b], c: shards[c]}, size)
+					require.NoError(t, err)
+					assert.Equal(t, data, actual, "size %d shards %d %d %d", size, a, b, c)
+				}
+			}
+		}
+
+		if size > 0 {
+			_, err = codec.Decode(map[int][]byte{0: shards[0], 4: shards[4]}, size)
+			require.ErrorIs(t, err, ErrNotEnoughShards)
+			_, err = codec.Decode(map[int][]byte{0: shards[0], 1: shards[1], 2: shards[2][1:]}, size)
+			require.ErrorIs(t, err, ErrInvalidShardSize)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(0, 1)
+	require.ErrorIs(t, err, ErrInvalidShardCount)
+	_, err = New(200, 57)
+	require.ErrorIs(t, err, ErrInvalidShardCount)
+	codec, err := New(200, 56)
+	require.NoError(t, err)
+	assert.Equal(t, 256, codec.TotalShards())
+}
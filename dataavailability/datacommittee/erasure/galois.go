@@ -0,0 +1,132 @@
+package erasure
+
+// Arithmetic over GF(2^8) using the primitive polynomial x^8 + x^4 + x^3 + x^2 + 1
+
+const (
+	fieldSize           = 256
+	primitivePolynomial = 0x11d
+)
+
+var (
+	expTable [2 * fieldSize]byte
+	logTable [fieldSize]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < fieldSize-1; i++ {
+		expTable[i] = byte(x)
+		logTable[x] = byte(i)
+		x <<= 1
+		if x >= fieldSize {
+			x ^= primitivePolynomial
+		}
+	}
+	// Duplicate the table so the sum of two logs can be used as index without reducing it
+	for i := fieldSize - 1; i < len(expTable); i++ {
+		expTable[i] = expTable[i-(fieldSize-1)]
+	}
+}
+
+func galMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+func galDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+(fieldSize-1)-int(logTable[b])]
+}
+
+func galExp(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])*n)%(fieldSize-1)]
+}
+
+type matrix [][]byte
+
+func newMatrix(rows, cols int) matrix {
+	m := make(matrix, rows)
+	for i := range m {
+		m[i] = make([]byte, cols)
+	}
+	return m
+}
+
+// vandermonde returns a matrix whose rows are independent, so any square subset of them can be inverted
+func vandermonde(rows, cols int) matrix {
+	m := newMatrix(rows, cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			m[r][c] = galExp(byte(r), c)
+		}
+	}
+	return m
+}
+
+func (m matrix) multiply(other matrix) matrix {
+	res := newMatrix(len(m), len(other[0]))
+	for r := range res {
+		for c := range res[r] {
+			var v byte
+			for i := range other {
+				v ^= galMul(m[r][i], other[i][c])
+			}
+			res[r][c] = v
+		}
+	}
+	return res
+}
+
+// invert returns the inverse of a square matrix using Gauss-Jordan elimination
+func (m matrix) invert() (matrix, error) {
+	size := len(m)
+	work := newMatrix(size, 2*size)
+	for r := 0; r < size; r++ {
+		copy(work[r], m[r])
+		work[r][size+r] = 1
+	}
+	for c := 0; c < size; c++ {
+		if work[c][c] == 0 {
+			swapped := false
+			for r := c + 1; r < size; r++ {
+				if work[r][c] != 0 {
+					work[c], work[r] = work[r], work[c]
+					swapped = true
+					break
+				}
+			}
+			if !swapped {
+				return nil, ErrSingularMatrix
+			}
+		}
+		if pivot := work[c][c]; pivot != 1 {
+			for i := range work[c] {
+				work[c][i] = galDiv(work[c][i], pivot)
+			}
+		}
+		for r := 0; r < size; r++ {
+			if r == c || work[r][c] == 0 {
+				continue
+			}
+			factor := work[r][c]
+			for i := range work[r] {
+				work[r][i] ^= galMul(factor, work[c][i])
+			}
+		}
+	}
+	res := newMatrix(size, size)
+	for r := 0; r < size; r++ {
+		copy(res[r], work[r][size:])
+	}
+	return res, nil
+}
@@ -0,0 +1,273 @@
+// Package localdac implements an in-process stand-in for a Data Availability Committee node.
+// It serves the same JSON-RPC endpoints as a real DAC node (datacom_signSequence and
+// sync_getOffChainData) so the DAC backend can be exercised end to end without external services.
+// It also serves the sharded endpoints (datacom_signShards and sync_getShard) used when the
+// backend runs with erasure coding.
+package localdac
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee/shard"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// MethodSignSequence is the endpoint used by the sequencer to get the sequence signed
+	MethodSignSequence = "datacom_signSequence"
+	// MethodGetOffChainData is the endpoint used to retrieve batch data by its hash
+	MethodGetOffChainData = "sync_getOffChainData"
+
+	maxRequestContentLength = 1024 * 1024 * 50
+)
+
+// Faults configures the misbehaviour injected by a Node when answering requests
+type Faults struct {
+	// Drop makes the node fail every request at the HTTP level
+	Drop bool
+	// Delay is waited before answering every request
+	Delay time.Duration
+	// BadSignature makes the node sign sequences with a key that doesn't belong to it
+	BadSignature bool
+	// WrongData makes the node return data that doesn't match the requested hash
+	WrongData bool
+}
+
+// Node is a local DAC node backed by a Store
+type Node struct {
+	privKey       *ecdsa.PrivateKey
+	sequencerAddr common.Address
+	store         Store
+
+	mu     sync.RWMutex
+	faults Faults
+}
+
+// New creates a Node that signs with privKey. If sequencerAddr is not the zero address, only
+// sequences signed by it are accepted. If store is nil, an in-memory store is used
+func New(privKey *ecdsa.PrivateKey, sequencerAddr common.Address, store Store) *Node {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	return &Node{
+		privKey:       privKey,
+		sequencerAddr: sequencerAddr,
+		store:         store,
+	}
+}
+
+// Addr returns the address of the committee member run by this node
+func (n *Node) Addr() common.Address {
+	return crypto.PubkeyToAddress(n.privKey.PublicKey)
+}
+
+// SetFaults replaces the faults injected by the node
+func (n *Node) SetFaults(faults Faults) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.faults = faults
+}
+
+// Faults returns the faults currently injected by the node
+func (n *Node) Faults() Faults {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.faults
+}
+
+// ServeHTTP handles a single JSON-RPC request
+func (n *Node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	faults := n.Faults()
+
+	if faults.Delay > 0 {
+		select {
+		case <-time.After(faults.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if faults.Drop {
+		http.Error(w, "request dropped by fault injection", http.StatusServiceUnavailable)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestContentLength))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req rpc.Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		n.writeResponse(w, req, nil, rpc.NewRPCError(rpc.ParserErrorCode, "invalid json request"))
+		return
+	}
+
+	result, rpcErr := n.handle(req, faults)
+	n.writeResponse(w, req, result, rpcErr)
+}
+
+func (n *Node) handle(req rpc.Request, faults Faults) (interface{}, rpc.Error) {
+	switch req.Method {
+	case MethodSignSequence:
+		var signedSequence daTypes.SignedSequence
+		if err := decodeSingleParam(req.Params, &signedSequence); err != nil {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+		}
+		return n.signSequence(signedSequence, faults)
+	case MethodGetOffChainData:
+		var hash common.Hash
+		if err := decodeSingleParam(req.Params, &hash); err != nil {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+		}
+		return n.getOffChainData(hash, faults)
+	case shard.MethodSignShards:
+		var signedShards shard.SignedShards
+		if err := decodeSingleParam(req.Params, &signedShards); err != nil {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+		}
+		return n.signShards(signedShards, faults)
+	case shard.MethodGetShard:
+		var hash common.Hash
+		if err := decodeSingleParam(req.Params, &hash); err != nil {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+		}
+		return n.getShard(hash, faults)
+	default:
+		return nil, rpc.NewRPCError(rpc.NotFoundErrorCode, "the method %s does not exist/is not available", req.Method)
+	}
+}
+
+func (n *Node) signSequence(signedSequence daTypes.SignedSequence, faults Faults) (interface{}, rpc.Error) {
+	sender, err := signedSequence.Signer()
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to verify sender")
+	}
+	if n.sequencerAddr != (common.Address{}) && sender != n.sequencerAddr {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "unauthorized")
+	}
+
+	if err := n.store.StoreOffChainData(signedSequence.Sequence.OffChainData()); err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to store offchain data. Error: %v", err)
+	}
+
+	signingKey := n.privKey
+	if faults.BadSignature {
+		signingKey, err = crypto.GenerateKey()
+		if err != nil {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to generate key. Error: %v", err)
+		}
+	}
+	signedByMe, err := signedSequence.Sequence.Sign(signingKey)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to sign. Error: %v", err)
+	}
+	log.Debugf("local DAC node %s signed sequence of %d batches", n.Addr().Hex(), len(signedSequence.Sequence))
+	return signedByMe.Signature, nil
+}
+
+func (n *Node) getOffChainData(hash common.Hash, faults Faults) (interface{}, rpc.Error) {
+	data, err := n.store.GetOffChainData(hash)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested data. Error: %v", err)
+	}
+	if faults.WrongData {
+		data = append(data, 0xff) //nolint:gomnd
+	}
+	return daTypes.ArgBytes(data), nil
+}
+
+func (n *Node) signShards(signedShards shard.SignedShards, faults Faults) (interface{}, rpc.Error) {
+	sender, err := signedShards.Signer()
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to verify sender")
+	}
+	if n.sequencerAddr != (common.Address{}) && sender != n.sequencerAddr {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "unauthorized")
+	}
+	for _, s := range signedShards.Shards {
+		if err := s.Verify(); err != nil {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+		}
+	}
+
+	if err := n.store.StoreShards(signedShards.Shards); err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to store shards. Error: %v", err)
+	}
+
+	signingKey := n.privKey
+	if faults.BadSignature {
+		signingKey, err = crypto.GenerateKey()
+		if err != nil {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to generate key. Error: %v", err)
+		}
+	}
+	signatures, err := shard.SignShards(signedShards, signingKey)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to sign. Error: %v", err)
+	}
+	log.Debugf("local DAC node %s signed sharded sequence of %d batches", n.Addr().Hex(), len(signedShards.Shards))
+	return signatures, nil
+}
+
+func (n *Node) getShard(batchHash common.Hash, faults Faults) (interface{}, rpc.Error) {
+	s, err := n.store.GetShard(batchHash)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested shard. Error: %v", err)
+	}
+	if faults.WrongData {
+		s.Data = append(s.Data, 0xff) //nolint:gomnd
+	}
+	return s, nil
+}
+
+func (n *Node) writeResponse(w http.ResponseWriter, req rpc.Request, result interface{}, rpcErr rpc.Error) {
+	var reply []byte
+	if rpcErr == nil {
+		var err error
+		reply, err = json.Marshal(result)
+		if err != nil {
+			rpcErr = rpc.NewRPCError(rpc.DefaultErrorCode, "failed to marshal result. Error: %v", err)
+		}
+	}
+	res := rpc.NewResponse(req, reply, rpcErr)
+	resBytes, err := res.Bytes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(resBytes); err != nil {
+		log.Errorf("local DAC node failed to write response: %v", err)
+	}
+}
+
+func decodeSingleParam(params json.RawMessage, v interface{}) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(params, &raw); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	if len(raw) != 1 {
+		return fmt.Errorf("invalid params: expected 1 param, got %d", len(raw))
+	}
+	if err := json.Unmarshal(raw[0], v); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package localdac
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/client"
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode(t *testing.T) {
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	memberKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	node := New(memberKey, crypto.PubkeyToAddress(sequencerKey.PublicKey), nil)
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+	c := client.New(srv.URL)
+
+	sequence := daTypes.Sequence{daTypes.ArgBytes{1, 2, 3}}
+	hash := crypto.Keccak256Hash(sequence[0])
+
+	// Unknown sequencer
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signedByOther, err := sequence.Sign(otherKey)
+	require.NoError(t, err)
+	_, err = c.SignSequence(*signedByOther)
+	require.ErrorContains(t, err, "unauthorized")
+	_, err = c.GetOffChainData(context.Background(), hash)
+	require.Error(t, err)
+
+	// Trusted sequencer
+	signedSequence, err := sequence.Sign(sequencerKey)
+	require.NoError(t, err)
+	signature, err := c.SignSequence(*signedSequence)
+	require.NoError(t, err)
+	signedSequence.Signature = signature
+	signer, err := signedSequence.Signer()
+	require.NoError(t, err)
+	assert.Equal(t, node.Addr(), signer)
+
+	data, err := c.GetOffChainData(context.Background(), hash)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, data)
+
+	// Faults
+	node.SetFaults(Faults{BadSignature: true, WrongData: true})
+	signedSequence, err = sequence.Sign(sequencerKey)
+	require.NoError(t, err)
+	signature, err = c.SignSequence(*signedSequence)
+	require.NoError(t, err)
+	signedSequence.Signature = signature
+	signer, err = signedSequence.Signer()
+	require.NoError(t, err)
+	assert.NotEqual(t, node.Addr(), signer)
+
+	data, err = c.GetOffChainData(context.Background(), hash)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, crypto.Keccak256Hash(data))
+
+	node.SetFaults(Faults{Drop: true})
+	_, err = c.GetOffChainData(context.Background(), hash)
+	require.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package localdac
+
+import (
+	"errors"
+	"sync"
+
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee/shard"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNotFound is returned by a Store when the requested data is not stored
+var ErrNotFound = errors.New("offchain data not found")
+
+// Store is used by the local DAC node to persist the off-chain data it signs
+type Store interface {
+	// StoreOffChainData stores the provided data indexed by its hash
+	StoreOffChainData(data []daTypes.OffChainData) error
+	// GetOffChainData returns the data stored for the provided hash
+	GetOffChainData(hash common.Hash) ([]byte, error)
+	// StoreShards stores the provided shards indexed by the hash of their batch
+	StoreShards(shards []shard.Shard) error
+	// GetShard returns the shard stored for the batch with the provided hash
+	GetShard(batchHash common.Hash) (*shard.Shard, error)
+}
+
+// MemoryStore is an in-memory implementation of Store
+type MemoryStore struct {
+	mu     sync.RWMutex
+	data   map[common.Hash][]byte
+	shards map[common.Hash]shard.Shard
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		data:   make(map[common.Hash][]byte),
+		shards: make(map[common.Hash]shard.Shard),
+	}
+}
+
+// StoreOffChainData stores the provided data indexed by its hash
+func (m *MemoryStore) StoreOffChainData(data []daTypes.OffChainData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range data {
+		value := make([]byte, len(d.Value))
+		copy(value, d.Value)
+		m.data[d.Key] = value
+	}
+	return nil
+}
+
+// GetOffChainData returns the data stored for the provided hash
+func (m *MemoryStore) GetOffChainData(hash common.Hash) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	value, ok := m.data[hash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	res := make([]byte, len(value))
+	copy(res, value)
+	return res, nil
+}
+
+// StoreShards stores the provided shards indexed by the hash of their batch
+func (m *MemoryStore) StoreShards(shards []shard.Shard) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range shards {
+		m.shards[s.Commitment.BatchHash] = copyShard(s)
+	}
+	return nil
+}
+
+// GetShard returns the shard stored for the batch with the provided hash
+func (m *MemoryStore) GetShard(batchHash common.Hash) (*shard.Shard, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.shards[batchHash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	res := copyShard(s)
+	return &res, nil
+}
+
+func copyShard(s shard.Shard) shard.Shard {
+	res := s
+	res.Data = make([]byte, len(s.Data))
+	copy(res.Data, s.Data)
+	res.Commitment.ShardHashes = make([]common.Hash, len(s.Commitment.ShardHashes))
+	copy(res.Commitment.ShardHashes, s.Commitment.ShardHashes)
+	return res
+}
@@ -0,0 +1,76 @@
+package shard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// MethodSignShards is the endpoint used by the sequencer to get a sharded sequence signed
+	MethodSignShards = "datacom_signShards"
+	// MethodGetShard is the endpoint used to retrieve the shard of a batch by the batch hash
+	MethodGetShard = "sync_getShard"
+)
+
+// ClientFactory creates clients for the sharded endpoints of the committee members
+type ClientFactory interface {
+	New(url string) Client
+}
+
+// Client wraps the sharded endpoints of a committee member
+type Client interface {
+	// SignShards sends the shards of a sequence to the member and returns its signatures. The signatures
+	// should be validated after using this method
+	SignShards(ctx context.Context, signedShards SignedShards) (*Signatures, error)
+	// GetShard returns the shard of the batch stored by the member
+	GetShard(ctx context.Context, batchHash common.Hash) (*Shard, error)
+}
+
+type factory struct{}
+
+// NewClientFactory creates a ClientFactory that talks JSON-RPC over HTTP
+func NewClientFactory() ClientFactory {
+	return &factory{}
+}
+
+// New returns a client for the member at url
+func (f *factory) New(url string) Client {
+	return &client{url: url}
+}
+
+type client struct {
+	url string
+}
+
+// SignShards sends the shards of a sequence to the member and returns its signatures
+func (c *client) SignShards(ctx context.Context, signedShards SignedShards) (*Signatures, error) {
+	var result Signatures
+	if err := c.call(ctx, &result, MethodSignShards, signedShards); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetShard returns the shard of the batch stored by the member
+func (c *client) GetShard(ctx context.Context, batchHash common.Hash) (*Shard, error) {
+	var result Shard
+	if err := c.call(ctx, &result, MethodGetShard, batchHash); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *client) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, method, params...)
+	if err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+	return json.Unmarshal(response.Result, result)
+}
@@ -0,0 +1,269 @@
+// Package shard defines the erasure coded representation of batch data sent to the members of a Data
+// Availability Committee. Each member stores one shard of every batch together with a commitment to all
+// of them, and the batch can be rebuilt from any DataShards of the shards. Members sign the commitments of
+// the shards they hold, besides the accumulated batch hash verified by the smart contract.
+package shard
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee/erasure"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const signatureLen = 65
+
+var (
+	// ErrInvalidShard is returned when a shard doesn't match its commitment
+	ErrInvalidShard = errors.New("invalid shard")
+	// ErrInvalidSignature is returned when a signature can't be recovered
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Commitment binds the shards of a batch to the batch data
+type Commitment struct {
+	// BatchHash is the hash of the batch data
+	BatchHash common.Hash `json:"batchHash"`
+	// Size is the length of the batch data
+	Size uint64 `json:"size"`
+	// DataShards is the amount of shards needed to rebuild the batch
+	DataShards uint64 `json:"dataShards"`
+	// ShardHashes contains the hash of each shard, indexed by its position
+	ShardHashes []common.Hash `json:"shardHashes"`
+}
+
+// Hash returns the hash that identifies the commitment
+func (c Commitment) Hash() common.Hash {
+	const uint64Len = 8
+	buf := make([]byte, 0, common.HashLength+2*uint64Len+len(c.ShardHashes)*common.HashLength)
+	buf = append(buf, c.BatchHash.Bytes()...)
+	buf = binary.BigEndian.AppendUint64(buf, c.Size)
+	buf = binary.BigEndian.AppendUint64(buf, c.DataShards)
+	for _, h := range c.ShardHashes {
+		buf = append(buf, h.Bytes()...)
+	}
+	return crypto.Keccak256Hash(buf)
+}
+
+// Shard is the piece of a batch stored by a committee member
+type Shard struct {
+	Commitment Commitment       `json:"commitment"`
+	Index      uint64           `json:"index"`
+	Data       daTypes.ArgBytes `json:"data"`
+}
+
+// Verify checks that the shard data matches the hash in its commitment
+func (s Shard) Verify() error {
+	if s.Index >= uint64(len(s.Commitment.ShardHashes)) {
+		return fmt.Errorf("%w: index %d out of range", ErrInvalidShard, s.Index)
+	}
+	if crypto.Keccak256Hash(s.Data) != s.Commitment.ShardHashes[s.Index] {
+		return fmt.Errorf("%w: hash mismatch for shard %d of batch %s", ErrInvalidShard, s.Index, s.Commitment.BatchHash.Hex())
+	}
+	return nil
+}
+
+// SignedShards is sent to a committee member to get a sequence signed: it contains the member's shard
+// of every batch of the sequence, signed by the sequencer
+type SignedShards struct {
+	Shards    []Shard          `json:"shards"`
+	Signature daTypes.ArgBytes `json:"signature"`
+}
+
+// HashToSign returns the accumulated hash of the batches committed by the shards
+func (s SignedShards) HashToSign() common.Hash {
+	batchHashes := make([]common.Hash, 0, len(s.Shards))
+	for _, shard := range s.Shards {
+		batchHashes = append(batchHashes, shard.Commitment.BatchHash)
+	}
+	return HashToSign(batchHashes)
+}
+
+// Signer returns the address that signed the shards
+func (s SignedShards) Signer() (common.Address, error) {
+	return Signer(s.HashToSign(), s.Signature)
+}
+
+// CommitmentHash returns the hash of the commitments and the positions of the shards. A member signs it after
+// verifying its shards, attesting that it holds them
+func (s SignedShards) CommitmentHash() common.Hash {
+	const uint64Len = 8
+	buf := make([]byte, 0, len(s.Shards)*(common.HashLength+uint64Len))
+	for _, shard := range s.Shards {
+		buf = append(buf, shard.Commitment.Hash().Bytes()...)
+		buf = binary.BigEndian.AppendUint64(buf, shard.Index)
+	}
+	return crypto.Keccak256Hash(buf)
+}
+
+// Signatures is the response of a member to a sharded sequence. The L1 contract only verifies signatures of the
+// accumulated batch hash, so the member signs it for the data availability message, and it also signs the
+// commitment hash of its shards, which is checked by the sequencer as proof that the member holds its shards
+type Signatures struct {
+	// Signature is the signature of the accumulated batch hash, as verified by the smart contract
+	Signature daTypes.ArgBytes `json:"signature"`
+	// CommitmentSignature is the signature of the commitment hash of the member shards
+	CommitmentSignature daTypes.ArgBytes `json:"commitmentSignature"`
+}
+
+// SignShards returns the signatures of a member for its verified shards
+func SignShards(signedShards SignedShards, privKey *ecdsa.PrivateKey) (*Signatures, error) {
+	for _, s := range signedShards.Shards {
+		if err := s.Verify(); err != nil {
+			return nil, err
+		}
+	}
+	signature, err := Sign(signedShards.HashToSign(), privKey)
+	if err != nil {
+		return nil, err
+	}
+	commitmentSignature, err := Sign(signedShards.CommitmentHash(), privKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Signatures{Signature: signature, CommitmentSignature: commitmentSignature}, nil
+}
+
+// Verify checks that both signatures of the shards were produced by the member
+func (s Signatures) Verify(signedShards SignedShards, member common.Address) error {
+	for _, signed := range []struct {
+		name      string
+		hash      common.Hash
+		signature []byte
+	}{
+		{name: "batches", hash: signedShards.HashToSign(), signature: s.Signature},
+		{name: "shards commitment", hash: signedShards.CommitmentHash(), signature: s.CommitmentSignature},
+	} {
+		signer, err := Signer(signed.hash, signed.signature)
+		if err != nil {
+			return fmt.Errorf("%w of the %s", err, signed.name)
+		}
+		if signer != member {
+			return fmt.Errorf("invalid signer of the %s. Expected %s, actual %s", signed.name, member.Hex(), signer.Hex())
+		}
+	}
+	return nil
+}
+
+// HashToSign returns the accumulated hash of a sequence of batches, as computed by the smart contract.
+// It's the same hash signed by the committee members when they receive the full batch data
+func HashToSign(batchHashes []common.Hash) common.Hash {
+	currentHash := common.Hash{}
+	for _, h := range batchHashes {
+		currentHash = crypto.Keccak256Hash(currentHash.Bytes(), h.Bytes())
+	}
+	return currentHash
+}
+
+// Sign signs the hash in the format expected by the smart contract
+func Sign(hash common.Hash, privKey *ecdsa.PrivateKey) ([]byte, error) {
+	sig, err := crypto.Sign(hash.Bytes(), privKey)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// Signer returns the address that produced the signature of the hash
+func Signer(hash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != signatureLen {
+		return common.Address{}, ErrInvalidSignature
+	}
+	sig := make([]byte, signatureLen)
+	copy(sig, signature)
+	sig[64] -= 27
+	pubKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// Encode splits every batch into totalShards shards, dataShards of which are enough to rebuild it.
+// The result is indexed by shard position first, so res[i] holds the shards to be sent to the i-th member
+func Encode(batchesData [][]byte, dataShards, totalShards int) ([][]Shard, error) {
+	codec, err := erasure.New(dataShards, totalShards-dataShards)
+	if err != nil {
+		return nil, err
+	}
+	res := make([][]Shard, totalShards)
+	for _, batchData := range batchesData {
+		shardsData := codec.Encode(batchData)
+		commitment := Commitment{
+			BatchHash:   crypto.Keccak256Hash(batchData),
+			Size:        uint64(len(batchData)),
+			DataShards:  uint64(dataShards),
+			ShardHashes: make([]common.Hash, totalShards),
+		}
+		for i, data := range shardsData {
+			commitment.ShardHashes[i] = crypto.Keccak256Hash(data)
+		}
+		for i, data := range shardsData {
+			res[i] = append(res[i], Shard{
+				Commitment: commitment,
+				Index:      uint64(i),
+				Data:       data,
+			})
+		}
+	}
+	return res, nil
+}
+
+// Decode rebuilds the batch with the provided hash from its shards. Shards that don't match their commitment
+// are ignored, and only shards sharing a commitment are decoded together
+func Decode(batchHash common.Hash, shards []Shard) ([]byte, error) {
+	type group struct {
+		commitment Commitment
+		shards     map[int][]byte
+	}
+	groups := make(map[common.Hash]*group)
+	var lastErr error = erasure.ErrNotEnoughShards
+	for _, s := range shards {
+		if s.Commitment.BatchHash != batchHash || s.Verify() != nil {
+			continue
+		}
+		h := s.Commitment.Hash()
+		g, ok := groups[h]
+		if !ok {
+			g = &group{commitment: s.Commitment, shards: make(map[int][]byte)}
+			groups[h] = g
+		}
+		g.shards[int(s.Index)] = s.Data
+		if uint64(len(g.shards)) < g.commitment.DataShards {
+			continue
+		}
+
+		data, err := decodeGroup(g.commitment, g.shards)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return data, nil
+	}
+	return nil, lastErr
+}
+
+func decodeGroup(commitment Commitment, shards map[int][]byte) ([]byte, error) {
+	if commitment.DataShards > uint64(len(commitment.ShardHashes)) {
+		return nil, fmt.Errorf("%w: %d data shards out of %d", erasure.ErrInvalidShardCount, commitment.DataShards, len(commitment.ShardHashes))
+	}
+	codec, err := erasure.New(int(commitment.DataShards), len(commitment.ShardHashes)-int(commitment.DataShards))
+	if err != nil {
+		return nil, err
+	}
+	data, err := codec.Decode(shards, int(commitment.Size))
+	if err != nil {
+		return nil, err
+	}
+	// A dishonest sequencer could commit to shards that don't encode the batch
+	if actual := crypto.Keccak256Hash(data); actual != commitment.BatchHash {
+		return nil, fmt.Errorf("rebuilt data doesn't match batch hash. Expected %s, actual %s", commitment.BatchHash.Hex(), actual.Hex())
+	}
+	return data, nil
+}
@@ -0,0 +1,91 @@
+package shard
+
+import (
+	"testing"
+
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee/erasure"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	batchesData := [][]byte{{1, 2, 3, 4, 5}, {}, {6}}
+	shardsByMember, err := Encode(batchesData, 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(shardsByMember))
+
+	for b, batchData := range batchesData {
+		hash := crypto.Keccak256Hash(batchData)
+		shards := []Shard{}
+		for _, memberShards := range shardsByMember {
+			require.Equal(t, len(batchesData), len(memberShards))
+			require.NoError(t, memberShards[b].Verify())
+			shards = append(shards, memberShards[b])
+		}
+
+		// Any 2 shards are enough, tampered ones are ignored
+		tampered := shards[0]
+		tampered.Data = append(daTypes.ArgBytes{0xff}, tampered.Data...)
+		require.ErrorIs(t, tampered.Verify(), ErrInvalidShard)
+		actual, err := Decode(hash, []Shard{tampered, shards[1], shards[3]})
+		require.NoError(t, err)
+		assert.Equal(t, batchData, actual)
+
+		_, err = Decode(hash, []Shard{tampered, shards[2]})
+		require.ErrorIs(t, err, erasure.ErrNotEnoughShards)
+		_, err = Decode(common.Hash{}, shards)
+		require.ErrorIs(t, err, erasure.ErrNotEnoughShards)
+	}
+}
+
+func TestSignaturesMatchFullSequence(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sequence := daTypes.Sequence{{1, 2, 3}, {4, 5, 6}}
+	signedSequence, err := sequence.Sign(privKey)
+	require.NoError(t, err)
+
+	shardsByMember, err := Encode([][]byte{sequence[0], sequence[1]}, 1, 2)
+	require.NoError(t, err)
+	signedShards := SignedShards{Shards: shardsByMember[1]}
+	assert.Equal(t, common.BytesToHash(sequence.HashToSign()), signedShards.HashToSign())
+
+	signedShards.Signature, err = Sign(signedShards.HashToSign(), privKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(signedSequence.Signature), []byte(signedShards.Signature))
+	signer, err := signedShards.Signer()
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(privKey.PublicKey), signer)
+}
+
+func TestSignShards(t *testing.T) {
+	memberKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	member := crypto.PubkeyToAddress(memberKey.PublicKey)
+	shardsByMember, err := Encode([][]byte{{1, 2, 3}, {4, 5, 6}}, 2, 3)
+	require.NoError(t, err)
+	signedShards := SignedShards{Shards: shardsByMember[0]}
+
+	signatures, err := SignShards(signedShards, memberKey)
+	require.NoError(t, err)
+	require.NoError(t, signatures.Verify(signedShards, member))
+
+	// The batches signature is the same for every member, but the commitment signature is bound to the shards
+	otherShards := SignedShards{Shards: shardsByMember[1]}
+	assert.Equal(t, signedShards.HashToSign(), otherShards.HashToSign())
+	assert.NotEqual(t, signedShards.CommitmentHash(), otherShards.CommitmentHash())
+	assert.ErrorContains(t, signatures.Verify(otherShards, member), "shards commitment")
+
+	// Without a valid commitment signature the member doesn't prove it holds its shards
+	missing := Signatures{Signature: signatures.Signature}
+	require.Error(t, missing.Verify(signedShards, member))
+
+	// Members don't sign shards that don't match their commitment
+	tampered := SignedShards{Shards: append([]Shard{}, shardsByMember[0]...)}
+	tampered.Shards[0].Data = append(daTypes.ArgBytes{0xff}, tampered.Shards[0].Data...)
+	_, err = SignShards(tampered, memberKey)
+	require.ErrorIs(t, err, ErrInvalidShard)
+}
@@ -40,10 +40,27 @@ type stateInterface interface {
 	GetForcedBatchDataByNumbers(ctx context.Context, batchNumbers []uint64, dbTx pgx.Tx) (map[uint64][]byte, error)
 }
 
+type protocolProvider interface {
+	GetDAProtocolAddr() (common.Address, error)
+	GetDAProtocolAddrAtBlock(ctx context.Context, blockNumber uint64) (common.Address, error)
+	GetDAProtocolNameByAddr(protocolAddr common.Address) (string, error)
+	GetDAProtocolChanges(ctx context.Context, fromBlock, toBlock uint64) ([]ProtocolChange, error)
+	GetLatestBlockNumber(ctx context.Context) (uint64, error)
+}
+
+type backendProvider interface {
+	Backend(ctx context.Context) (DABackender, error)
+	BackendAt(ctx context.Context, l1BlockNumber uint64) (DABackender, error)
+	Reset(fromBlock uint64)
+}
+
 // BatchDataProvider is used to retrieve batch data
 type BatchDataProvider interface {
-	// GetBatchL2Data retrieve the data of a batch from the DA backend. The returned data must be the pre-image of the hash
-	GetBatchL2Data(batchNum []uint64, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error)
+	// GetBatchL2Data retrieve the data of a batch from the DA backend. The returned data must be the pre-image of the hash.
+	// l1BlockNumber is the L1 block in which the batches were sequenced, used to select the DA backend
+	GetBatchL2Data(l1BlockNumber uint64, batchNum []uint64, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error)
+	// Reset drops the L1 data of the blocks from fromBlock on, which have been reorged out of L1
+	Reset(fromBlock uint64)
 }
 
 // DataManager is an interface for components that send and retrieve batch data
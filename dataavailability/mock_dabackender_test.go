@@ -0,0 +1,108 @@
+// Code generated by mockery v2.39.0. DO NOT EDIT.
+
+package dataavailability
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// daBackenderMock is an autogenerated mock type for the DABackender type
+type daBackenderMock struct {
+	mock.Mock
+}
+
+// GetSequence provides a mock function with given fields: ctx, batchHashes, dataAvailabilityMessage
+func (_m *daBackenderMock) GetSequence(ctx context.Context, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
+	ret := _m.Called(ctx, batchHashes, dataAvailabilityMessage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequence")
+	}
+
+	var r0 [][]byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash, []byte) ([][]byte, error)); ok {
+		return rf(ctx, batchHashes, dataAvailabilityMessage)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash, []byte) [][]byte); ok {
+		r0 = rf(ctx, batchHashes, dataAvailabilityMessage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash, []byte) error); ok {
+		r1 = rf(ctx, batchHashes, dataAvailabilityMessage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Init provides a mock function with given fields:
+func (_m *daBackenderMock) Init() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Init")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PostSequence provides a mock function with given fields: ctx, batchesData
+func (_m *daBackenderMock) PostSequence(ctx context.Context, batchesData [][]byte) ([]byte, error) {
+	ret := _m.Called(ctx, batchesData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostSequence")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte) ([]byte, error)); ok {
+		return rf(ctx, batchesData)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte) []byte); ok {
+		r0 = rf(ctx, batchesData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, [][]byte) error); ok {
+		r1 = rf(ctx, batchesData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// newDaBackenderMock creates a new instance of daBackenderMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newDaBackenderMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *daBackenderMock {
+	mock := &daBackenderMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
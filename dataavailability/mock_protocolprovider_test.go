@@ -0,0 +1,176 @@
+// Code generated by mockery v2.39.0. DO NOT EDIT.
+
+package dataavailability
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// protocolProviderMock is an autogenerated mock type for the protocolProvider type
+type protocolProviderMock struct {
+	mock.Mock
+}
+
+// GetDAProtocolAddr provides a mock function with given fields:
+func (_m *protocolProviderMock) GetDAProtocolAddr() (common.Address, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDAProtocolAddr")
+	}
+
+	var r0 common.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (common.Address, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() common.Address); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDAProtocolAddrAtBlock provides a mock function with given fields: ctx, blockNumber
+func (_m *protocolProviderMock) GetDAProtocolAddrAtBlock(ctx context.Context, blockNumber uint64) (common.Address, error) {
+	ret := _m.Called(ctx, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDAProtocolAddrAtBlock")
+	}
+
+	var r0 common.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (common.Address, error)); ok {
+		return rf(ctx, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) common.Address); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDAProtocolChanges provides a mock function with given fields: ctx, fromBlock, toBlock
+func (_m *protocolProviderMock) GetDAProtocolChanges(ctx context.Context, fromBlock uint64, toBlock uint64) ([]ProtocolChange, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDAProtocolChanges")
+	}
+
+	var r0 []ProtocolChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]ProtocolChange, error)); ok {
+		return rf(ctx, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []ProtocolChange); ok {
+		r0 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ProtocolChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDAProtocolNameByAddr provides a mock function with given fields: protocolAddr
+func (_m *protocolProviderMock) GetDAProtocolNameByAddr(protocolAddr common.Address) (string, error) {
+	ret := _m.Called(protocolAddr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDAProtocolNameByAddr")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(common.Address) (string, error)); ok {
+		return rf(protocolAddr)
+	}
+	if rf, ok := ret.Get(0).(func(common.Address) string); ok {
+		r0 = rf(protocolAddr)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(protocolAddr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBlockNumber provides a mock function with given fields: ctx
+func (_m *protocolProviderMock) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBlockNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// newProtocolProviderMock creates a new instance of protocolProviderMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newProtocolProviderMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *protocolProviderMock {
+	mock := &protocolProviderMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
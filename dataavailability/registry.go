@@ -0,0 +1,217 @@
+package dataavailability
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProtocolChange is a change of the data availability protocol of the rollup done on L1
+type ProtocolChange struct {
+	BlockNumber  uint64
+	ProtocolAddr common.Address
+}
+
+// BackendFactory creates the backend for the data availability protocol deployed at protocolAddr
+type BackendFactory func(protocolAddr common.Address) (DABackender, error)
+
+// Registry keeps a backend for each data availability protocol used by the rollup, and selects
+// the one that was active on L1 when a sequence was sent.
+// The protocol changes are scanned from the SetDataAvailabilityProtocol logs, and must be reset after an L1 reorg
+type Registry struct {
+	l1        protocolProvider
+	factories map[DABackendType]BackendFactory
+
+	mu       sync.Mutex
+	backends map[common.Address]DABackender
+	changes  []ProtocolChange
+	// nextBlock is the first L1 block not yet scanned for protocol changes
+	nextBlock uint64
+	// initial is the protocol set before the first scanned change, read from the L1 state at initialBlock
+	initial      *common.Address
+	initialBlock uint64
+}
+
+// NewRegistry creates a Registry that looks for protocol changes on L1 starting at fromBlock,
+// which is usually the genesis block of the rollup
+func NewRegistry(l1 protocolProvider, fromBlock uint64) *Registry {
+	return &Registry{
+		l1:        l1,
+		factories: make(map[DABackendType]BackendFactory),
+		backends:  make(map[common.Address]DABackender),
+		nextBlock: fromBlock,
+	}
+}
+
+// Register sets the factory used to create the backends of the given type
+func (r *Registry) Register(backendType DABackendType, factory BackendFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[backendType] = factory
+}
+
+// Reset drops the protocol changes done at or after fromBlock, which have been reorged out of L1,
+// so they're scanned again
+func (r *Registry) Reset(fromBlock uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := sort.Search(len(r.changes), func(i int) bool { return r.changes[i].BlockNumber >= fromBlock })
+	r.changes = r.changes[:i]
+	if r.nextBlock > fromBlock {
+		r.nextBlock = fromBlock
+	}
+	if r.initial != nil && r.initialBlock >= fromBlock {
+		r.initial = nil
+	}
+}
+
+// Backend returns the backend of the data availability protocol currently set on L1
+func (r *Registry) Backend(ctx context.Context) (DABackender, error) {
+	protocolAddr, err := r.l1.GetDAProtocolAddr()
+	if err != nil {
+		return nil, fmt.Errorf("error getting data availability protocol address: %w", err)
+	}
+	return r.backend(protocolAddr)
+}
+
+// BackendAt returns the backend of the data availability protocol that was set on L1 at the given block.
+// A protocol change takes effect from the block it was done in
+func (r *Registry) BackendAt(ctx context.Context, l1BlockNumber uint64) (DABackender, error) {
+	if err := r.scan(ctx, l1BlockNumber); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	// Index of the first change done after l1BlockNumber
+	i := sort.Search(len(r.changes), func(i int) bool { return r.changes[i].BlockNumber > l1BlockNumber })
+	var protocolAddr common.Address
+	if i > 0 {
+		protocolAddr = r.changes[i-1].ProtocolAddr
+	}
+	r.mu.Unlock()
+
+	if i == 0 {
+		// The protocol was set before the first scanned block, and doesn't change until the first change
+		var err error
+		protocolAddr, err = r.initialProtocol(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r.backend(protocolAddr)
+}
+
+// scan looks for the protocol changes done up to toBlock that haven't been scanned yet
+func (r *Registry) scan(ctx context.Context, toBlock uint64) error {
+	for {
+		r.mu.Lock()
+		fromBlock := r.nextBlock
+		r.mu.Unlock()
+		if toBlock < fromBlock {
+			return nil
+		}
+
+		changes, err := r.l1.GetDAProtocolChanges(ctx, fromBlock, toBlock)
+		if err != nil {
+			return fmt.Errorf("error getting data availability protocol changes: %w", err)
+		}
+
+		r.mu.Lock()
+		// A concurrent scan or reset moved the next block meanwhile, so the changes are scanned again
+		if r.nextBlock != fromBlock {
+			r.mu.Unlock()
+			continue
+		}
+		for _, change := range changes {
+			log.Infof("data availability protocol changed to %s at L1 block %d", change.ProtocolAddr.String(), change.BlockNumber)
+		}
+		r.changes = append(r.changes, changes...)
+		r.nextBlock = toBlock + 1
+		r.mu.Unlock()
+		return nil
+	}
+}
+
+// initialProtocol returns the protocol set before the first scanned change, reading it from the L1 state once.
+// If the protocol hasn't changed since the first scanned block, it's read at the latest block, otherwise
+// it's read at the block before the first change, which requires an archive node if that block isn't recent
+func (r *Registry) initialProtocol(ctx context.Context) (common.Address, error) {
+	r.mu.Lock()
+	initial := r.initial
+	r.mu.Unlock()
+	if initial != nil {
+		return *initial, nil
+	}
+
+	latestBlock, err := r.l1.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting latest L1 block number: %w", err)
+	}
+	if err := r.scan(ctx, latestBlock); err != nil {
+		return common.Address{}, err
+	}
+
+	r.mu.Lock()
+	block := latestBlock
+	if len(r.changes) > 0 {
+		block = r.changes[0].BlockNumber - 1
+	}
+	r.mu.Unlock()
+
+	protocolAddr, err := r.l1.GetDAProtocolAddrAtBlock(ctx, block)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting data availability protocol address at L1 block %d: %w", block, err)
+	}
+
+	r.mu.Lock()
+	r.initial = &protocolAddr
+	r.initialBlock = block
+	r.mu.Unlock()
+	return protocolAddr, nil
+}
+
+// backend returns the backend for protocolAddr, creating it if needed
+func (r *Registry) backend(protocolAddr common.Address) (DABackender, error) {
+	r.mu.Lock()
+	backend, ok := r.backends[protocolAddr]
+	r.mu.Unlock()
+	if ok {
+		return backend, nil
+	}
+	if protocolAddr == (common.Address{}) {
+		return nil, fmt.Errorf("data availability protocol not set")
+	}
+
+	protocolName, err := r.l1.GetDAProtocolNameByAddr(protocolAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error getting name of data availability protocol %s: %w", protocolAddr.String(), err)
+	}
+	r.mu.Lock()
+	factory, ok := r.factories[DABackendType(protocolName)]
+	r.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unexpected / unsupported DA protocol: %s", protocolName)
+	}
+	backend, err = factory(protocolAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s backend for %s: %w", protocolName, protocolAddr.String(), err)
+	}
+	if err := backend.Init(); err != nil {
+		return nil, fmt.Errorf("error initializing %s backend for %s: %w", protocolName, protocolAddr.String(), err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// A concurrent call may have created the backend meanwhile, which is kept
+	if existing, ok := r.backends[protocolAddr]; ok {
+		return existing, nil
+	}
+	log.Infof("created %s backend for data availability protocol %s", protocolName, protocolAddr.String())
+	r.backends[protocolAddr] = backend
+	return backend, nil
+}
@@ -0,0 +1,204 @@
+package dataavailability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryBackendAt(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		addrA   = common.HexToAddress("0xa")
+		addrB   = common.HexToAddress("0xb")
+		addrC   = common.HexToAddress("0xc")
+		l1      = newProtocolProviderMock(t)
+		backend = map[common.Address]*daBackenderMock{
+			addrA: newDaBackenderMock(t),
+			addrB: newDaBackenderMock(t),
+		}
+	)
+	l1.On("GetDAProtocolChanges", ctx, uint64(0), uint64(10)).Return([]ProtocolChange{{BlockNumber: 5, ProtocolAddr: addrA}}, nil).Once()
+	l1.On("GetDAProtocolChanges", ctx, uint64(11), uint64(25)).Return([]ProtocolChange{{BlockNumber: 20, ProtocolAddr: addrB}}, nil).Once()
+	l1.On("GetDAProtocolChanges", ctx, uint64(26), uint64(30)).Return([]ProtocolChange{{BlockNumber: 30, ProtocolAddr: addrC}}, nil).Once()
+	l1.On("GetDAProtocolNameByAddr", addrA).Return(string(DataAvailabilityCommittee), nil).Once()
+	l1.On("GetDAProtocolNameByAddr", addrB).Return(string(DataAvailabilityCommittee), nil).Once()
+	l1.On("GetDAProtocolNameByAddr", addrC).Return("Unknown", nil).Once()
+	for _, b := range backend {
+		b.On("Init").Return(nil).Once()
+	}
+
+	registry := NewRegistry(l1, 0)
+	registry.Register(DataAvailabilityCommittee, func(protocolAddr common.Address) (DABackender, error) {
+		return backend[protocolAddr], nil
+	})
+
+	for _, tc := range []struct {
+		block    uint64
+		expected common.Address
+	}{
+		{block: 10, expected: addrA},
+		{block: 25, expected: addrB},
+		{block: 7, expected: addrA},
+		{block: 20, expected: addrB},
+		{block: 19, expected: addrA},
+	} {
+		actual, err := registry.BackendAt(ctx, tc.block)
+		require.NoError(t, err)
+		assert.Same(t, backend[tc.expected], actual, "block %d", tc.block)
+	}
+
+	_, err := registry.BackendAt(ctx, 30)
+	require.ErrorContains(t, err, "unsupported DA protocol: Unknown")
+}
+
+func TestRegistryBackendAtBeforeFirstChange(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		addrA   = common.HexToAddress("0xa")
+		addrB   = common.HexToAddress("0xb")
+		l1      = newProtocolProviderMock(t)
+		backend = map[common.Address]*daBackenderMock{
+			addrA: newDaBackenderMock(t),
+			addrB: newDaBackenderMock(t),
+		}
+	)
+	l1.On("GetDAProtocolNameByAddr", mock.Anything).Return(string(DataAvailabilityCommittee), nil)
+	for _, b := range backend {
+		b.On("Init").Return(nil).Once()
+	}
+	registry := NewRegistry(l1, 100)
+	registry.Register(DataAvailabilityCommittee, func(protocolAddr common.Address) (DABackender, error) {
+		return backend[protocolAddr], nil
+	})
+
+	// the protocol hasn't changed up to the latest block, so it's read at a recent block
+	l1.On("GetDAProtocolChanges", ctx, uint64(100), uint64(100)).Return(nil, nil).Once()
+	l1.On("GetLatestBlockNumber", ctx).Return(uint64(200), nil).Once()
+	l1.On("GetDAProtocolChanges", ctx, uint64(101), uint64(200)).Return(nil, nil).Once()
+	l1.On("GetDAProtocolAddrAtBlock", ctx, uint64(200)).Return(addrA, nil).Once()
+	actual, err := registry.BackendAt(ctx, 100)
+	require.NoError(t, err)
+	assert.Same(t, backend[addrA], actual)
+
+	// the initial protocol is cached
+	actual, err = registry.BackendAt(ctx, 150)
+	require.NoError(t, err)
+	assert.Same(t, backend[addrA], actual)
+
+	// a change reorged in resets the initial protocol read after it, which is then read before the change
+	registry.Reset(180)
+	l1.On("GetDAProtocolChanges", ctx, uint64(180), uint64(250)).Return([]ProtocolChange{{BlockNumber: 190, ProtocolAddr: addrB}}, nil).Once()
+	actual, err = registry.BackendAt(ctx, 250)
+	require.NoError(t, err)
+	assert.Same(t, backend[addrB], actual)
+
+	l1.On("GetLatestBlockNumber", ctx).Return(uint64(260), nil).Once()
+	l1.On("GetDAProtocolChanges", ctx, uint64(251), uint64(260)).Return(nil, nil).Once()
+	l1.On("GetDAProtocolAddrAtBlock", ctx, uint64(189)).Return(addrA, nil).Once()
+	actual, err = registry.BackendAt(ctx, 150)
+	require.NoError(t, err)
+	assert.Same(t, backend[addrA], actual)
+
+	l1.On("GetDAProtocolAddr").Return(addrB, nil).Once()
+	actual, err = registry.Backend(ctx)
+	require.NoError(t, err)
+	assert.Same(t, backend[addrB], actual)
+}
+
+func TestRegistryReset(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		addrA   = common.HexToAddress("0xa")
+		addrB   = common.HexToAddress("0xb")
+		addrC   = common.HexToAddress("0xc")
+		l1      = newProtocolProviderMock(t)
+		backend = map[common.Address]*daBackenderMock{
+			addrA: newDaBackenderMock(t),
+			addrB: newDaBackenderMock(t),
+			addrC: newDaBackenderMock(t),
+		}
+	)
+	l1.On("GetDAProtocolNameByAddr", mock.Anything).Return(string(DataAvailabilityCommittee), nil)
+	for _, b := range backend {
+		b.On("Init").Return(nil).Once()
+	}
+	registry := NewRegistry(l1, 0)
+	registry.Register(DataAvailabilityCommittee, func(protocolAddr common.Address) (DABackender, error) {
+		return backend[protocolAddr], nil
+	})
+
+	l1.On("GetDAProtocolChanges", ctx, uint64(0), uint64(30)).Return([]ProtocolChange{
+		{BlockNumber: 5, ProtocolAddr: addrA},
+		{BlockNumber: 20, ProtocolAddr: addrB},
+	}, nil).Once()
+	actual, err := registry.BackendAt(ctx, 30)
+	require.NoError(t, err)
+	assert.Same(t, backend[addrB], actual)
+
+	// the change at block 20 is reorged out and replaced by a change at block 25
+	registry.Reset(20)
+	l1.On("GetDAProtocolChanges", ctx, uint64(20), uint64(30)).Return([]ProtocolChange{{BlockNumber: 25, ProtocolAddr: addrC}}, nil).Once()
+	for _, tc := range []struct {
+		block    uint64
+		expected common.Address
+	}{
+		{block: 30, expected: addrC},
+		{block: 22, expected: addrA},
+		{block: 25, expected: addrC},
+	} {
+		actual, err = registry.BackendAt(ctx, tc.block)
+		require.NoError(t, err)
+		assert.Same(t, backend[tc.expected], actual, "block %d", tc.block)
+	}
+
+	// a reset after the scanned blocks doesn't drop anything
+	registry.Reset(40)
+	actual, err = registry.BackendAt(ctx, 30)
+	require.NoError(t, err)
+	assert.Same(t, backend[addrC], actual)
+}
+
+func TestGetBatchL2DataWithRegistry(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		addrA    = common.HexToAddress("0xa")
+		addrB    = common.HexToAddress("0xb")
+		l1       = newProtocolProviderMock(t)
+		backendA = newDaBackenderMock(t)
+		backendB = newDaBackenderMock(t)
+		hashes   = []common.Hash{common.HexToHash("0x1")}
+		daMsg    = []byte{0xda}
+	)
+	l1.On("GetDAProtocolChanges", ctx, uint64(0), uint64(15)).Return([]ProtocolChange{
+		{BlockNumber: 5, ProtocolAddr: addrA},
+		{BlockNumber: 10, ProtocolAddr: addrB},
+	}, nil).Once()
+	l1.On("GetDAProtocolNameByAddr", mock.Anything).Return(string(DataAvailabilityCommittee), nil)
+	backendA.On("Init").Return(nil).Once()
+	backendB.On("Init").Return(nil).Once()
+	backendA.On("GetSequence", ctx, hashes, daMsg).Return([][]byte{{0xa}}, nil).Once()
+	backendB.On("GetSequence", ctx, hashes, daMsg).Return([][]byte{{0xb}}, nil).Once()
+
+	registry := NewRegistry(l1, 0)
+	registry.Register(DataAvailabilityCommittee, func(protocolAddr common.Address) (DABackender, error) {
+		if protocolAddr == addrA {
+			return backendA, nil
+		}
+		return backendB, nil
+	})
+	da, err := NewWithRegistry(false, registry, nil, nil, []DataSourcePriority{External})
+	require.NoError(t, err)
+
+	data, err := da.GetBatchL2Data(15, []uint64{2}, hashes, daMsg)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0xb}}, data)
+
+	data, err = da.GetBatchL2Data(9, []uint64{1}, hashes, daMsg)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0xa}}, data)
+}
@@ -0,0 +1,10 @@
+-- +migrate Down
+DROP TABLE IF EXISTS pool.priority_lane CASCADE;
+
+-- +migrate Up
+CREATE TABLE pool.priority_lane
+(
+    lane    VARCHAR,
+    address VARCHAR,
+    PRIMARY KEY (lane, address)
+);
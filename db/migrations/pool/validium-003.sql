@@ -0,0 +1,23 @@
+-- +migrate Down
+DROP TABLE IF EXISTS pool.selector_filter CASCADE;
+DROP TABLE IF EXISTS pool.address_limit CASCADE;
+DELETE FROM pool.acl WHERE policy = 'call';
+DELETE FROM pool.policy WHERE name = 'call';
+
+-- +migrate Up
+INSERT INTO pool.policy (name, allow) VALUES ('call', false);
+
+CREATE TABLE pool.address_limit
+(
+    address   VARCHAR PRIMARY KEY,
+    max_gas   BIGINT,
+    max_value DECIMAL(78, 0)
+);
+
+CREATE TABLE pool.selector_filter
+(
+    address  VARCHAR,
+    selector VARCHAR,
+    allow    BOOLEAN NOT NULL,
+    PRIMARY KEY (address, selector)
+);
@@ -0,0 +1,9 @@
+-- +migrate Down
+DROP TABLE IF EXISTS pool.sponsor CASCADE;
+
+-- +migrate Up
+CREATE TABLE pool.sponsor
+(
+    name     VARCHAR PRIMARY KEY,
+    used_gas BIGINT NOT NULL DEFAULT 0
+);
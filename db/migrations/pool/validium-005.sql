@@ -0,0 +1,11 @@
+-- +migrate Down
+DROP TABLE IF EXISTS pool.pending_receipt CASCADE;
+
+-- +migrate Up
+CREATE TABLE pool.pending_receipt
+(
+    hash       VARCHAR PRIMARY KEY,
+    id         BIGSERIAL UNIQUE,
+    receipt    JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
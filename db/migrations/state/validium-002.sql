@@ -0,0 +1,17 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS state.pending_sequence
+(
+    monitored_id VARCHAR PRIMARY KEY,
+    from_batch   BIGINT  NOT NULL,
+    to_batch     BIGINT  NOT NULL,
+    from_addr    VARCHAR NOT NULL,
+    to_addr      VARCHAR,
+    da_message   BYTEA,
+    tx_data      BYTEA,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS state.pending_sequence;
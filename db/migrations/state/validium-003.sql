@@ -0,0 +1,17 @@
+-- +migrate Up
+
+ALTER TABLE state.monitored_txs
+    ADD COLUMN blob_sidecar   BYTEA,
+    ADD COLUMN blob_gas_price DECIMAL(78, 0);
+
+ALTER TABLE state.pending_sequence
+    ADD COLUMN blob_data BYTEA;
+
+-- +migrate Down
+
+ALTER TABLE state.pending_sequence
+    DROP COLUMN blob_data;
+
+ALTER TABLE state.monitored_txs
+    DROP COLUMN blob_sidecar,
+    DROP COLUMN blob_gas_price;
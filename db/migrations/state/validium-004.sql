@@ -0,0 +1,27 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS state.prover
+(
+    prover_id     VARCHAR PRIMARY KEY,
+    prover_name   VARCHAR NOT NULL,
+    addr          VARCHAR,
+    fork_ids      BIGINT[],
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS state.prover_proof_stats
+(
+    prover_id         VARCHAR NOT NULL REFERENCES state.prover (prover_id) ON DELETE CASCADE,
+    proof_type        VARCHAR NOT NULL,
+    proofs            BIGINT  NOT NULL DEFAULT 0,
+    failures          BIGINT  NOT NULL DEFAULT 0,
+    total_duration_ms BIGINT  NOT NULL DEFAULT 0,
+    last_duration_ms  BIGINT  NOT NULL DEFAULT 0,
+    PRIMARY KEY (prover_id, proof_type)
+);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS state.prover_proof_stats;
+DROP TABLE IF EXISTS state.prover;
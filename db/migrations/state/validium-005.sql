@@ -0,0 +1,13 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS state.sequencer_lease
+(
+    id         INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    holder     VARCHAR                  NOT NULL,
+    epoch      BIGINT                   NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS state.sequencer_lease;
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+ALTER TABLE state.monitored_txs
+    ADD COLUMN gas_tip_cap DECIMAL(78, 0);
+
+-- +migrate Down
+
+ALTER TABLE state.monitored_txs
+    DROP COLUMN gas_tip_cap;
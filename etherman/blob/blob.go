@@ -0,0 +1,148 @@
+// Package blob encodes data into EIP-4844 blobs, so it can be attached to L1 transactions as a blob sidecar.
+// Each field element of a blob must be lower than the BLS modulus, so only 31 of its 32 bytes are used,
+// leaving the most significant byte empty. The data is prefixed by its length to be able to remove the padding.
+package blob
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	usableBytesPerFieldElement = params.BlobTxBytesPerFieldElement - 1
+	usableBytesPerBlob         = usableBytesPerFieldElement * params.BlobTxFieldElementsPerBlob
+	lengthPrefixSize           = 4
+	batchHeaderSize            = 8 + 4
+
+	// MaxBlobsPerTx is the maximum amount of blobs that can be attached to a transaction
+	MaxBlobsPerTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+	// MaxDataSize is the maximum amount of bytes that can be encoded in the blobs of a transaction
+	MaxDataSize = MaxBlobsPerTx*usableBytesPerBlob - lengthPrefixSize
+)
+
+var (
+	// ErrDataTooBig is returned when the data doesn't fit in the blobs of a transaction
+	ErrDataTooBig = errors.New("data doesn't fit in the blobs of a transaction")
+	// ErrInvalidData is returned when the blobs don't contain valid encoded data
+	ErrInvalidData = errors.New("invalid blob data")
+)
+
+// Encode splits the data into the minimum amount of blobs needed to store it
+func Encode(data []byte) ([]kzg4844.Blob, error) {
+	if len(data) > MaxDataSize {
+		return nil, fmt.Errorf("%w: %d bytes, max %d", ErrDataTooBig, len(data), MaxDataSize)
+	}
+	payload := binary.BigEndian.AppendUint32(make([]byte, 0, lengthPrefixSize+len(data)), uint32(len(data)))
+	payload = append(payload, data...)
+
+	blobs := make([]kzg4844.Blob, (len(payload)+usableBytesPerBlob-1)/usableBytesPerBlob)
+	for i := range blobs {
+		chunk := payload[i*usableBytesPerBlob:]
+		if len(chunk) > usableBytesPerBlob {
+			chunk = chunk[:usableBytesPerBlob]
+		}
+		for fe := 0; fe*usableBytesPerFieldElement < len(chunk); fe++ {
+			start := fe * usableBytesPerFieldElement
+			end := start + usableBytesPerFieldElement
+			if end > len(chunk) {
+				end = len(chunk)
+			}
+			copy(blobs[i][fe*params.BlobTxBytesPerFieldElement+1:], chunk[start:end])
+		}
+	}
+	return blobs, nil
+}
+
+// Decode returns the data stored in the blobs by Encode
+func Decode(blobs []kzg4844.Blob) ([]byte, error) {
+	payload := make([]byte, 0, len(blobs)*usableBytesPerBlob)
+	for i := range blobs {
+		for fe := 0; fe < params.BlobTxFieldElementsPerBlob; fe++ {
+			offset := fe * params.BlobTxBytesPerFieldElement
+			if blobs[i][offset] != 0 {
+				return nil, fmt.Errorf("%w: field element %d of blob %d exceeds the usable bytes", ErrInvalidData, fe, i)
+			}
+			payload = append(payload, blobs[i][offset+1:offset+params.BlobTxBytesPerFieldElement]...)
+		}
+	}
+	if len(payload) < lengthPrefixSize {
+		return nil, fmt.Errorf("%w: missing length prefix", ErrInvalidData)
+	}
+	length := binary.BigEndian.Uint32(payload)
+	if uint64(length) > uint64(len(payload)-lengthPrefixSize) {
+		return nil, fmt.Errorf("%w: length %d exceeds the blobs size", ErrInvalidData, length)
+	}
+	return payload[lengthPrefixSize : lengthPrefixSize+length], nil
+}
+
+// NewSidecar computes the KZG commitments and proofs of the blobs
+func NewSidecar(blobs []kzg4844.Blob) (*types.BlobTxSidecar, error) {
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       blobs,
+		Commitments: make([]kzg4844.Commitment, 0, len(blobs)),
+		Proofs:      make([]kzg4844.Proof, 0, len(blobs)),
+	}
+	for _, blob := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(blob)
+		if err != nil {
+			return nil, err
+		}
+		proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+		if err != nil {
+			return nil, err
+		}
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+	}
+	return sidecar, nil
+}
+
+// Batch is the L2 data of a batch carried in blobs
+type Batch struct {
+	Number uint64
+	L2Data []byte
+}
+
+// BatchEncodedSize returns the amount of bytes used by a batch with the given L2 data length
+func BatchEncodedSize(l2DataLen int) int {
+	return batchHeaderSize + l2DataLen
+}
+
+// EncodeBatches concatenates the batches, each one prefixed by its number and the length of its L2 data
+func EncodeBatches(batches []Batch) []byte {
+	size := 0
+	for _, b := range batches {
+		size += BatchEncodedSize(len(b.L2Data))
+	}
+	data := make([]byte, 0, size)
+	for _, b := range batches {
+		data = binary.BigEndian.AppendUint64(data, b.Number)
+		data = binary.BigEndian.AppendUint32(data, uint32(len(b.L2Data)))
+		data = append(data, b.L2Data...)
+	}
+	return data
+}
+
+// DecodeBatches returns the batches encoded by EncodeBatches
+func DecodeBatches(data []byte) ([]Batch, error) {
+	batches := []Batch{}
+	for len(data) > 0 {
+		if len(data) < batchHeaderSize {
+			return nil, fmt.Errorf("%w: truncated batch header", ErrInvalidData)
+		}
+		number := binary.BigEndian.Uint64(data)
+		length := binary.BigEndian.Uint32(data[8:])
+		data = data[batchHeaderSize:]
+		if uint64(length) > uint64(len(data)) {
+			return nil, fmt.Errorf("%w: truncated L2 data of batch %d", ErrInvalidData, number)
+		}
+		batches = append(batches, Batch{Number: number, L2Data: data[:length]})
+		data = data[length:]
+	}
+	return batches, nil
+}
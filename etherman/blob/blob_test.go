@@ -0,0 +1,54 @@
+package blob
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, size := range []int{0, 1, usableBytesPerBlob - lengthPrefixSize, usableBytesPerBlob, 2*usableBytesPerBlob + 10, MaxDataSize} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i*7 + 3)
+		}
+		blobs, err := Encode(data)
+		require.NoError(t, err)
+		assert.Equal(t, (size+lengthPrefixSize+usableBytesPerBlob-1)/usableBytesPerBlob, len(blobs))
+		actual, err := Decode(blobs)
+		require.NoError(t, err)
+		assert.Equal(t, data, actual)
+	}
+
+	_, err := Encode(make([]byte, MaxDataSize+1))
+	require.ErrorIs(t, err, ErrDataTooBig)
+
+	blobs, err := Encode([]byte{1, 2, 3})
+	require.NoError(t, err)
+	blobs[0][32] = 1
+	_, err = Decode(blobs)
+	require.ErrorIs(t, err, ErrInvalidData)
+}
+
+func TestNewSidecar(t *testing.T) {
+	blobs, err := Encode([]byte("batch data"))
+	require.NoError(t, err)
+	sidecar, err := NewSidecar(blobs)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(sidecar.BlobHashes()))
+	assert.NoError(t, kzg4844.VerifyBlobProof(sidecar.Blobs[0], sidecar.Commitments[0], sidecar.Proofs[0]))
+}
+
+func TestEncodeDecodeBatches(t *testing.T) {
+	batches := []Batch{{Number: 5, L2Data: []byte{1, 2, 3}}, {Number: 6, L2Data: []byte{}}, {Number: 7, L2Data: []byte{4}}}
+	data := EncodeBatches(batches)
+	assert.Equal(t, BatchEncodedSize(3)+BatchEncodedSize(0)+BatchEncodedSize(1), len(data))
+	actual, err := DecodeBatches(data)
+	require.NoError(t, err)
+	assert.Equal(t, batches, actual)
+
+	_, err = DecodeBatches(data[:len(data)-1])
+	require.ErrorIs(t, err, ErrInvalidData)
+}
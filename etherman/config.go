@@ -7,7 +7,7 @@ type Config struct {
 	// URL is the URL of the Ethereum node for L1
 	URL string `mapstructure:"URL"`
 
-	// ForkIDChunkSize is the max interval for each call to L1 provider to get the forkIDs
+	// ForkIDChunkSize is the max interval for each call to L1 provider to get the forkIDs and the data availability protocol changes
 	ForkIDChunkSize uint64 `mapstructure:"ForkIDChunkSize"`
 
 	// allow that L1 gas price calculation use multiples sources
@@ -1,8 +1,15 @@
 package etherman
 
 import (
+	"bytes"
 	"errors"
 	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygondatacommittee"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonzkevm"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 var (
@@ -22,6 +29,8 @@ var (
 	ErrNoSigner = errors.New("no signer to authorize the transaction with")
 	// ErrMissingTrieNode means that a node is missing on the trie
 	ErrMissingTrieNode = errors.New("missing trie node")
+	// ErrBlobsNotSupported is returned when blob transactions aren't enabled on L1
+	ErrBlobsNotSupported = errors.New("blob transactions are not supported by L1")
 
 	errorsCache = map[string]error{
 		ErrGasRequiredExceedsAllowance.Error():             ErrGasRequiredExceedsAllowance,
@@ -46,3 +55,37 @@ func tryParseError(err error) (error, bool) {
 	}
 	return parsedError, exists
 }
+
+// customErrorsABIs contains the ABIs of the contracts whose custom errors can be decoded from the revert data
+var customErrorsABIs = []string{
+	polygonzkevm.PolygonzkevmABI,
+	polygondatacommittee.PolygondatacommitteeABI,
+}
+
+// tryDecodeCustomError returns the name of the smart contract custom error contained in the revert data of err
+func tryDecodeCustomError(err error) (string, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return "", false
+	}
+	encodedData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return "", false
+	}
+	data, decodeErr := hexutil.Decode(encodedData)
+	if decodeErr != nil || len(data) < 4 { //nolint:gomnd
+		return "", false
+	}
+	for _, abiJSON := range customErrorsABIs {
+		contractABI, abiErr := abi.JSON(strings.NewReader(abiJSON))
+		if abiErr != nil {
+			continue
+		}
+		for name, customErr := range contractABI.Errors {
+			if bytes.Equal(customErr.ID[:4], data[:4]) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
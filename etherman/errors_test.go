@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,3 +37,27 @@ func TestTryParseWithNonExistingErr(t *testing.T) {
 	assert.Nil(t, actualErr)
 	assert.False(t, ok)
 }
+
+type revertDataError struct {
+	data interface{}
+}
+
+func (e revertDataError) Error() string          { return "execution reverted" }
+func (e revertDataError) ErrorData() interface{} { return e.data }
+
+func TestTryDecodeCustomError(t *testing.T) {
+	selector := crypto.Keccak256([]byte("SequencedTimestampInvalid()"))[:4]
+	name, ok := tryDecodeCustomError(fmt.Errorf("call failed: %w", revertDataError{data: hexutil.Encode(selector)}))
+	assert.True(t, ok)
+	assert.Equal(t, "SequencedTimestampInvalid", name)
+
+	selector = crypto.Keccak256([]byte("UnexpectedCommitteeHash()"))[:4]
+	name, ok = tryDecodeCustomError(revertDataError{data: hexutil.Encode(selector)})
+	assert.True(t, ok)
+	assert.Equal(t, "UnexpectedCommitteeHash", name)
+
+	_, ok = tryDecodeCustomError(revertDataError{data: "0x01020304"})
+	assert.False(t, ok)
+	_, ok = tryDecodeCustomError(fmt.Errorf("execution reverted"))
+	assert.False(t, ok)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -78,6 +79,9 @@ var (
 	initialSequenceBatchesSignatureHash = crypto.Keccak256Hash([]byte("InitialSequenceBatches(bytes,bytes32,address)"))
 	updateEtrogSequenceSignatureHash    = crypto.Keccak256Hash([]byte("UpdateEtrogSequence(uint64,bytes,bytes32,address)"))
 
+	// Events Validium
+	setDataAvailabilityProtocolSignatureHash = crypto.Keccak256Hash([]byte("SetDataAvailabilityProtocol(address)"))
+
 	// Extra RollupManager
 	initializedSignatureHash               = crypto.Keccak256Hash([]byte("Initialized(uint64)"))                       // Initializable. Used in RollupBase as well
 	roleAdminChangedSignatureHash          = crypto.Keccak256Hash([]byte("RoleAdminChanged(bytes32,bytes32,bytes32)")) // IAccessControlUpgradeable
@@ -161,6 +165,7 @@ type ethereumClient interface {
 	ethereum.ContractCaller
 	ethereum.GasEstimator
 	ethereum.GasPricer
+	ethereum.GasPricer1559
 	ethereum.LogFilterer
 	ethereum.TransactionReader
 	ethereum.TransactionSender
@@ -561,6 +566,8 @@ func (etherMan *Client) processEvent(ctx context.Context, vLog types.Log, blocks
 	case setTrustedSequencerURLSignatureHash:
 		log.Debug("SetTrustedSequencerURL event detected. Ignoring...")
 		return nil
+	case setDataAvailabilityProtocolSignatureHash:
+		return etherMan.setDataAvailabilityProtocolEvent(vLog)
 	case setTrustedSequencerSignatureHash:
 		log.Debug("SetTrustedSequencer event detected. Ignoring...")
 		return nil
@@ -664,6 +671,18 @@ func (etherMan *Client) processEvent(ctx context.Context, vLog types.Log, blocks
 	return nil
 }
 
+func (etherMan *Client) setDataAvailabilityProtocolEvent(vLog types.Log) error {
+	dap, err := etherMan.ZkEVM.ParseSetDataAvailabilityProtocol(vLog)
+	if err != nil {
+		log.Error("error parsing SetDataAvailabilityProtocol event. Error: ", err)
+		return err
+	}
+	// The DA backend is selected per sequence from the protocol changes, so nothing needs to be stored
+	log.Infof("SetDataAvailabilityProtocol event detected. Sequences from L1 block %d use the data availability protocol %s",
+		vLog.BlockNumber, dap.NewDataAvailabilityProtocol.String())
+	return nil
+}
+
 func (etherMan *Client) updateZkevmVersion(ctx context.Context, vLog types.Log, blocks *[]Block, blocksOrder *map[common.Hash][]Order) error {
 	log.Debug("UpdateZkEVMVersion event detected")
 	zkevmVersion, err := etherMan.OldZkEVM.ParseUpdateZkEVMVersion(vLog)
@@ -1186,13 +1205,13 @@ func (etherMan *Client) sequencedBatchesEvent(ctx context.Context, vLog types.Lo
 		log.Debugf("MethodId: %s", common.Bytes2Hex(methodId))
 		if bytes.Equal(methodId, methodIDSequenceBatchesEtrog) ||
 			bytes.Equal(methodId, methodIDSequenceBatchesValidiumEtrog) {
-			sequences, err = decodeSequencesEtrog(tx.Data(), sb.NumBatch, msg.From, vLog.TxHash, msg.Nonce, sb.L1InfoRoot, etherMan.da, etherMan.state)
+			sequences, err = decodeSequencesEtrog(tx.Data(), sb.NumBatch, msg.From, vLog.TxHash, msg.Nonce, sb.L1InfoRoot, vLog.BlockNumber, etherMan.da, etherMan.state)
 			if err != nil {
 				return fmt.Errorf("error decoding the sequences (etrog): %v", err)
 			}
 		} else if bytes.Equal(methodId, methodIDSequenceBatchesElderberry) ||
 			bytes.Equal(methodId, methodIDSequenceBatchesValidiumElderberry) {
-			sequences, err = decodeSequencesElderberry(tx.Data(), sb.NumBatch, msg.From, vLog.TxHash, msg.Nonce, sb.L1InfoRoot, etherMan.da, etherMan.state)
+			sequences, err = decodeSequencesElderberry(tx.Data(), sb.NumBatch, msg.From, vLog.TxHash, msg.Nonce, sb.L1InfoRoot, vLog.BlockNumber, etherMan.da, etherMan.state)
 			if err != nil {
 				return fmt.Errorf("error decoding the sequences (elderberry): %v", err)
 			}
@@ -1279,7 +1298,7 @@ func (etherMan *Client) sequencedBatchesPreEtrogEvent(ctx context.Context, vLog
 }
 
 func decodeSequencesElderberry(txData []byte, lastBatchNumber uint64, sequencer common.Address, txHash common.Hash, nonce uint64,
-	l1InfoRoot common.Hash, da dataavailability.BatchDataProvider, st stateProvider) ([]SequencedBatch, error) {
+	l1InfoRoot common.Hash, l1BlockNumber uint64, da dataavailability.BatchDataProvider, st stateProvider) ([]SequencedBatch, error) {
 	// Extract coded txs.
 	// Load contract ABI
 	smcAbi, err := abi.JSON(strings.NewReader(polygonzkevm.PolygonzkevmABI))
@@ -1287,11 +1306,11 @@ func decodeSequencesElderberry(txData []byte, lastBatchNumber uint64, sequencer
 		return nil, err
 	}
 
-	return decodeSequencedBatches(smcAbi, txData, state.FORKID_ELDERBERRY, lastBatchNumber, sequencer, txHash, nonce, l1InfoRoot, da, st)
+	return decodeSequencedBatches(smcAbi, txData, state.FORKID_ELDERBERRY, lastBatchNumber, sequencer, txHash, nonce, l1InfoRoot, l1BlockNumber, da, st)
 }
 
 func decodeSequencesEtrog(txData []byte, lastBatchNumber uint64, sequencer common.Address, txHash common.Hash, nonce uint64, l1InfoRoot common.Hash,
-	da dataavailability.BatchDataProvider, st stateProvider) ([]SequencedBatch, error) {
+	l1BlockNumber uint64, da dataavailability.BatchDataProvider, st stateProvider) ([]SequencedBatch, error) {
 	// Extract coded txs.
 	// Load contract ABI
 	smcAbi, err := abi.JSON(strings.NewReader(etrogpolygonzkevm.EtrogpolygonzkevmABI))
@@ -1299,12 +1318,12 @@ func decodeSequencesEtrog(txData []byte, lastBatchNumber uint64, sequencer commo
 		return nil, err
 	}
 
-	return decodeSequencedBatches(smcAbi, txData, state.FORKID_ETROG, lastBatchNumber, sequencer, txHash, nonce, l1InfoRoot, da, st)
+	return decodeSequencedBatches(smcAbi, txData, state.FORKID_ETROG, lastBatchNumber, sequencer, txHash, nonce, l1InfoRoot, l1BlockNumber, da, st)
 }
 
 // decodeSequencedBatches decodes provided data, based on the funcName, whether it is rollup or validium data and returns sequenced batches
 func decodeSequencedBatches(smcAbi abi.ABI, txData []byte, forkID uint64, lastBatchNumber uint64,
-	sequencer common.Address, txHash common.Hash, nonce uint64, l1InfoRoot common.Hash,
+	sequencer common.Address, txHash common.Hash, nonce uint64, l1InfoRoot common.Hash, l1BlockNumber uint64,
 	da dataavailability.BatchDataProvider, st stateProvider) ([]SequencedBatch, error) {
 	// Recover Method from signature and ABI
 	method, err := smcAbi.MethodById(txData[:4])
@@ -1401,7 +1420,7 @@ func decodeSequencedBatches(smcAbi abi.ABI, txData []byte, forkID uint64, lastBa
 			batchInfos = append(batchInfos, batchInfo{num: bn, hash: h, isForced: forced})
 		}
 
-		batchData, err := retrieveBatchData(da, st, batchInfos, dataAvailabilityMsg)
+		batchData, err := retrieveBatchData(da, st, l1BlockNumber, batchInfos, dataAvailabilityMsg)
 		if err != nil {
 			return nil, err
 		}
@@ -1440,14 +1459,102 @@ func decodeSequencedBatches(smcAbi abi.ABI, txData []byte, forkID uint64, lastBa
 	return nil, fmt.Errorf("unexpected method called in sequence batches transaction: %s", method.RawName)
 }
 
+// GetSequencedBatchesDAInfo returns the hash of the L2 data of each batch sequenced by the provided sequenceBatchesValidium L1 tx,
+// together with the dataAvailabilityMessage sent along with them
+func (etherMan *Client) GetSequencedBatchesDAInfo(ctx context.Context, txHash common.Hash) ([]SequencedBatchDAInfo, []byte, error) {
+	receipt, err := etherMan.EthClient.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	var lastBatchNumber uint64
+	found := false
+	for _, vLog := range receipt.Logs {
+		if len(vLog.Topics) == 0 || vLog.Topics[0] != sequenceBatchesSignatureHash {
+			continue
+		}
+		sb, err := etherMan.ZkEVM.ParseSequenceBatches(*vLog)
+		if err != nil {
+			return nil, nil, err
+		}
+		lastBatchNumber = sb.NumBatch
+		found = true
+		break
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("no SequenceBatches event found in tx %s", txHash.String())
+	}
+
+	tx, _, err := etherMan.EthClient.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tx.Data()) < 4 { //nolint:gomnd
+		return nil, nil, fmt.Errorf("invalid data in tx %s", txHash.String())
+	}
+	methodId := tx.Data()[:4]
+	var forkID uint64
+	var smcAbi abi.ABI
+	if bytes.Equal(methodId, methodIDSequenceBatchesValidiumEtrog) {
+		forkID = state.FORKID_ETROG
+		smcAbi, err = abi.JSON(strings.NewReader(etrogpolygonzkevm.EtrogpolygonzkevmABI))
+	} else if bytes.Equal(methodId, methodIDSequenceBatchesValidiumElderberry) {
+		forkID = state.FORKID_ELDERBERRY
+		smcAbi, err = abi.JSON(strings.NewReader(polygonzkevm.PolygonzkevmABI))
+	} else {
+		return nil, nil, fmt.Errorf("tx %s is not a sequenceBatchesValidium call: methodId %s", txHash.String(), common.Bytes2Hex(methodId))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return decodeSequencedBatchesDAInfo(smcAbi, tx.Data(), forkID, lastBatchNumber)
+}
+
+// decodeSequencedBatchesDAInfo decodes the batches hashes and the dataAvailabilityMessage of a sequenceBatchesValidium call
+func decodeSequencedBatchesDAInfo(smcAbi abi.ABI, txData []byte, forkID uint64, lastBatchNumber uint64) ([]SequencedBatchDAInfo, []byte, error) {
+	method, err := smcAbi.MethodById(txData[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := method.Inputs.Unpack(txData[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+	bytedata, err := json.Marshal(data[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	var sequencesValidium []polygonzkevm.PolygonValidiumEtrogValidiumBatchData
+	if err := json.Unmarshal(bytedata, &sequencesValidium); err != nil {
+		return nil, nil, err
+	}
+
+	var dataAvailabilityMsg []byte
+	switch forkID {
+	case state.FORKID_ETROG:
+		dataAvailabilityMsg = data[2].([]byte)
+	case state.FORKID_ELDERBERRY:
+		dataAvailabilityMsg = data[4].([]byte)
+	}
+
+	infos := make([]SequencedBatchDAInfo, 0, len(sequencesValidium))
+	for i, d := range sequencesValidium {
+		infos = append(infos, SequencedBatchDAInfo{
+			BatchNumber:      lastBatchNumber - uint64(len(sequencesValidium)-(i+1)),
+			TransactionsHash: d.TransactionsHash,
+			IsForced:         d.ForcedTimestamp > 0,
+		})
+	}
+	return infos, dataAvailabilityMsg, nil
+}
+
 type batchInfo struct {
 	num      uint64
 	hash     common.Hash
 	isForced bool
 }
 
-func retrieveBatchData(da dataavailability.BatchDataProvider, st stateProvider, batchInfos []batchInfo, daMessage []byte) ([][]byte, error) {
-	validiumData, err := getBatchL2Data(da, batchInfos, daMessage)
+func retrieveBatchData(da dataavailability.BatchDataProvider, st stateProvider, l1BlockNumber uint64, batchInfos []batchInfo, daMessage []byte) ([][]byte, error) {
+	validiumData, err := getBatchL2Data(da, l1BlockNumber, batchInfos, daMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -1467,7 +1574,7 @@ func retrieveBatchData(da dataavailability.BatchDataProvider, st stateProvider,
 	return data, nil
 }
 
-func getBatchL2Data(da dataavailability.BatchDataProvider, batchInfos []batchInfo, daMessage []byte) (map[uint64][]byte, error) {
+func getBatchL2Data(da dataavailability.BatchDataProvider, l1BlockNumber uint64, batchInfos []batchInfo, daMessage []byte) (map[uint64][]byte, error) {
 	var batchNums []uint64
 	var batchHashes []common.Hash
 	for _, info := range batchInfos {
@@ -1480,7 +1587,7 @@ func getBatchL2Data(da dataavailability.BatchDataProvider, batchInfos []batchInf
 		return nil, nil
 	}
 
-	batchL2Data, err := da.GetBatchL2Data(batchNums, batchHashes, daMessage)
+	batchL2Data, err := da.GetBatchL2Data(l1BlockNumber, batchNums, batchHashes, daMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -1934,6 +2041,24 @@ func (etherMan *Client) SuggestedGasPrice(ctx context.Context) (*big.Int, error)
 	return suggestedGasPrice, nil
 }
 
+// SuggestedGasTipCap returns the suggested gas tip cap for dynamic fee txs
+func (etherMan *Client) SuggestedGasTipCap(ctx context.Context) (*big.Int, error) {
+	return etherMan.EthClient.SuggestGasTipCap(ctx)
+}
+
+// SuggestedBlobGasPrice returns the blob gas price of the next L1 block, or ErrBlobsNotSupported
+// if blob transactions aren't enabled on L1
+func (etherMan *Client) SuggestedBlobGasPrice(ctx context.Context) (*big.Int, error) {
+	header, err := etherMan.EthClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.ExcessBlobGas == nil || header.BlobGasUsed == nil {
+		return nil, ErrBlobsNotSupported
+	}
+	return eip4844.CalcBlobFee(eip4844.CalcExcessBlobGas(*header.ExcessBlobGas, *header.BlobGasUsed)), nil
+}
+
 // EstimateGas returns the estimated gas for the tx
 func (etherMan *Client) EstimateGas(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) (uint64, error) {
 	return etherMan.EthClient.EstimateGas(ctx, ethereum.CallMsg{
@@ -1994,6 +2119,9 @@ func (etherMan *Client) GetRevertMessage(ctx context.Context, tx *types.Transact
 	if receipt.Status == types.ReceiptStatusFailed {
 		revertMessage, err := operations.RevertReason(ctx, etherMan.EthClient, tx, receipt.BlockNumber)
 		if err != nil {
+			if customErr, ok := tryDecodeCustomError(err); ok {
+				return customErr, nil
+			}
 			return "", err
 		}
 		return revertMessage, nil
@@ -2090,6 +2218,60 @@ func (etherMan *Client) GetDAProtocolName() (string, error) {
 	return etherMan.DAProtocol.GetProcotolName(&bind.CallOpts{Pending: false})
 }
 
+// GetDAProtocolAddrAtBlock returns the address of the data availability protocol set at the given L1 block.
+// Unless the block is recent, it requires the L1 node to keep the historical state
+func (etherMan *Client) GetDAProtocolAddrAtBlock(ctx context.Context, blockNumber uint64) (common.Address, error) {
+	return etherMan.ZkEVM.DataAvailabilityProtocol(&bind.CallOpts{Pending: false, Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNumber)})
+}
+
+// GetDAProtocolNameByAddr returns the name of the data availability protocol deployed at the given address
+func (etherMan *Client) GetDAProtocolNameByAddr(protocolAddr common.Address) (string, error) {
+	dap, err := dataavailabilityprotocol.NewDataavailabilityprotocolCaller(protocolAddr, etherMan.EthClient)
+	if err != nil {
+		return "", err
+	}
+	return dap.GetProcotolName(&bind.CallOpts{Pending: false})
+}
+
+// ResetDataAvailability drops the data availability protocol changes done from fromBlock on, after an L1 reorg
+func (etherMan *Client) ResetDataAvailability(fromBlock uint64) {
+	if etherMan.da != nil {
+		etherMan.da.Reset(fromBlock)
+	}
+}
+
+// GetDAProtocolChanges returns the changes of the data availability protocol done in the L1 blocks [fromBlock, toBlock]
+func (etherMan *Client) GetDAProtocolChanges(ctx context.Context, fromBlock, toBlock uint64) ([]dataavailability.ProtocolChange, error) {
+	var changes []dataavailability.ProtocolChange
+	for i := fromBlock; i <= toBlock; i = i + etherMan.cfg.ForkIDChunkSize + 1 {
+		final := i + etherMan.cfg.ForkIDChunkSize
+		if final > toBlock {
+			final = toBlock
+		}
+		query := ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(i),
+			ToBlock:   new(big.Int).SetUint64(final),
+			Addresses: etherMan.SCAddresses,
+			Topics:    [][]common.Hash{{setDataAvailabilityProtocolSignatureHash}},
+		}
+		logs, err := etherMan.EthClient.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range logs {
+			dap, err := etherMan.ZkEVM.ParseSetDataAvailabilityProtocol(l)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, dataavailability.ProtocolChange{
+				BlockNumber:  l.BlockNumber,
+				ProtocolAddr: dap.NewDataAvailabilityProtocol,
+			})
+		}
+	}
+	return changes, nil
+}
+
 // SetDataAvailabilityProtocol sets the address for the new data availability protocol
 func (etherMan *Client) SetDataAvailabilityProtocol(from, daAddress common.Address) (*types.Transaction, error) {
 	auth, err := etherMan.getAuthByAddress(from)
@@ -169,7 +169,7 @@ func TestSequencedBatchesEvent(t *testing.T) {
 	batchHashes := []common.Hash{txsHash, txsHash}
 	batchData := [][]byte{data, data}
 	daMessage, _ := hex.DecodeString("0x123456789123456789")
-	da.Mock.On("GetBatchL2Data", batchNums, batchHashes, daMessage).Return(batchData, nil)
+	da.Mock.On("GetBatchL2Data", currentBlockNumber+1, batchNums, batchHashes, daMessage).Return(batchData, nil)
 	_, err = etherman.ZkEVM.SequenceBatchesValidium(auth, sequences, uint64(time.Now().Unix()), uint64(1), auth.From, daMessage)
 	require.NoError(t, err)
 
@@ -194,6 +194,57 @@ func TestSequencedBatchesEvent(t *testing.T) {
 	assert.Equal(t, 0, order[blocks[2].BlockHash][0].Pos)
 }
 
+func TestGetSequencedBatchesDAInfo(t *testing.T) {
+	// Set up testing environment
+	etherman, ethBackend, auth, _, _, _, _ := newTestingEnv(t)
+	ctx := context.Background()
+
+	hashes := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+	sequences := []polygonzkevm.PolygonValidiumEtrogValidiumBatchData{
+		{TransactionsHash: hashes[0]},
+		{TransactionsHash: hashes[1]},
+	}
+	// The committee has no members, so the message must be empty to be accepted
+	daMessage := []byte{}
+	tx, err := etherman.ZkEVM.SequenceBatchesValidium(auth, sequences, uint64(time.Now().Unix()), uint64(1), auth.From, daMessage)
+	require.NoError(t, err)
+	ethBackend.Commit()
+
+	infos, actualDAMessage, err := etherman.GetSequencedBatchesDAInfo(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, daMessage, actualDAMessage)
+	assert.Equal(t, []SequencedBatchDAInfo{
+		{BatchNumber: 2, TransactionsHash: hashes[0]},
+		{BatchNumber: 3, TransactionsHash: hashes[1]},
+	}, infos)
+}
+
+func TestGetDAProtocolChanges(t *testing.T) {
+	// Set up testing environment
+	etherman, ethBackend, auth, _, _, _, _ := newTestingEnv(t)
+	ctx := context.Background()
+
+	initialProtocol, err := etherman.GetDAProtocolAddr()
+	require.NoError(t, err)
+	newProtocol := common.HexToAddress("0x1234")
+	_, err = etherman.ZkEVM.SetDataAvailabilityProtocol(auth, newProtocol)
+	require.NoError(t, err)
+	ethBackend.Commit()
+
+	finalBlock, err := etherman.EthClient.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	changes, err := etherman.GetDAProtocolChanges(ctx, 0, finalBlock.NumberU64())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(changes))
+	assert.Equal(t, initialProtocol, changes[0].ProtocolAddr)
+	assert.Equal(t, newProtocol, changes[1].ProtocolAddr)
+	assert.Equal(t, finalBlock.NumberU64(), changes[1].BlockNumber)
+
+	protocolAddr, err := etherman.GetDAProtocolAddrAtBlock(ctx, finalBlock.NumberU64()-1)
+	require.NoError(t, err)
+	assert.Equal(t, initialProtocol, protocolAddr)
+}
+
 func TestVerifyBatchEvent(t *testing.T) {
 	// Set up testing environment
 	etherman, ethBackend, auth, _, _, da, _ := newTestingEnv(t)
@@ -211,7 +262,7 @@ func TestVerifyBatchEvent(t *testing.T) {
 	daMessage, _ := hex.DecodeString("0x1234")
 	_, err = etherman.ZkEVM.SequenceBatchesValidium(auth, []polygonzkevm.PolygonValidiumEtrogValidiumBatchData{tx}, uint64(time.Now().Unix()), uint64(1), auth.From, daMessage)
 	require.NoError(t, err)
-	da.Mock.On("GetBatchL2Data", []uint64{2}, []common.Hash{crypto.Keccak256Hash(common.Hex2Bytes(rawTxs))}, daMessage).Return([][]byte{common.Hex2Bytes(rawTxs)}, nil)
+	da.Mock.On("GetBatchL2Data", initBlock.NumberU64()+1, []uint64{2}, []common.Hash{crypto.Keccak256Hash(common.Hex2Bytes(rawTxs))}, daMessage).Return([][]byte{common.Hex2Bytes(rawTxs)}, nil)
 
 	// Mine the tx in a block
 	ethBackend.Commit()
@@ -328,7 +379,7 @@ func TestSendSequences(t *testing.T) {
 	lastL2BlockTStamp := tx1.Time().Unix()
 	tx, err := etherman.sequenceBatches(*auth, []ethmanTypes.Sequence{sequence}, uint64(lastL2BlockTStamp), uint64(1), auth.From, daMessage)
 	require.NoError(t, err)
-	da.Mock.On("GetBatchL2Data", []uint64{2}, []common.Hash{crypto.Keccak256Hash(batchL2Data)}, daMessage).Return([][]byte{batchL2Data}, nil)
+	da.Mock.On("GetBatchL2Data", initBlock.NumberU64()+2, []uint64{2}, []common.Hash{crypto.Keccak256Hash(batchL2Data)}, daMessage).Return([][]byte{batchL2Data}, nil)
 
 	log.Debug("TX: ", tx.Hash())
 	ethBackend.Commit()
@@ -8,7 +8,8 @@ import (
 )
 
 type dataAvailabilityProvider interface {
-	GetBatchL2Data(batchNum []uint64, hash []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error)
+	GetBatchL2Data(l1BlockNumber uint64, batchNum []uint64, hash []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error)
+	Reset(fromBlock uint64)
 }
 
 type stateProvider interface {
@@ -13,9 +13,9 @@ type daMock struct {
 	mock.Mock
 }
 
-// GetBatchL2Data provides a mock function with given fields: batchNum, hash, dataAvailabilityMessage
-func (_m *daMock) GetBatchL2Data(batchNum []uint64, hash []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
-	ret := _m.Called(batchNum, hash, dataAvailabilityMessage)
+// GetBatchL2Data provides a mock function with given fields: l1BlockNumber, batchNum, hash, dataAvailabilityMessage
+func (_m *daMock) GetBatchL2Data(l1BlockNumber uint64, batchNum []uint64, hash []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
+	ret := _m.Called(l1BlockNumber, batchNum, hash, dataAvailabilityMessage)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBatchL2Data")
@@ -23,19 +23,19 @@ func (_m *daMock) GetBatchL2Data(batchNum []uint64, hash []common.Hash, dataAvai
 
 	var r0 [][]byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]uint64, []common.Hash, []byte) ([][]byte, error)); ok {
-		return rf(batchNum, hash, dataAvailabilityMessage)
+	if rf, ok := ret.Get(0).(func(uint64, []uint64, []common.Hash, []byte) ([][]byte, error)); ok {
+		return rf(l1BlockNumber, batchNum, hash, dataAvailabilityMessage)
 	}
-	if rf, ok := ret.Get(0).(func([]uint64, []common.Hash, []byte) [][]byte); ok {
-		r0 = rf(batchNum, hash, dataAvailabilityMessage)
+	if rf, ok := ret.Get(0).(func(uint64, []uint64, []common.Hash, []byte) [][]byte); ok {
+		r0 = rf(l1BlockNumber, batchNum, hash, dataAvailabilityMessage)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([][]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]uint64, []common.Hash, []byte) error); ok {
-		r1 = rf(batchNum, hash, dataAvailabilityMessage)
+	if rf, ok := ret.Get(1).(func(uint64, []uint64, []common.Hash, []byte) error); ok {
+		r1 = rf(l1BlockNumber, batchNum, hash, dataAvailabilityMessage)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -43,6 +43,11 @@ func (_m *daMock) GetBatchL2Data(batchNum []uint64, hash []common.Hash, dataAvai
 	return r0, r1
 }
 
+// Reset provides a mock function with given fields: fromBlock
+func (_m *daMock) Reset(fromBlock uint64) {
+	_m.Called(fromBlock)
+}
+
 // newDaMock creates a new instance of daMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newDaMock(t interface {
@@ -56,6 +56,13 @@ type SequencedBatch struct {
 	*SequencedBatchElderberryData
 }
 
+// SequencedBatchDAInfo is the data needed to retrieve the L2 data of a batch sequenced through sequenceBatchesValidium
+type SequencedBatchDAInfo struct {
+	BatchNumber      uint64
+	TransactionsHash common.Hash
+	IsForced         bool
+}
+
 // UpdateEtrogSequence represents the first etrog sequence
 type UpdateEtrogSequence struct {
 	BatchNumber   uint64
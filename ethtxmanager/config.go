@@ -48,4 +48,12 @@ type Config struct {
 	// max gas price limit: 110
 	// tx gas price = 110
 	MaxGasPriceLimit uint64 `mapstructure:"MaxGasPriceLimit"`
+
+	// BlobGasPriceMarginFactor is used to multiply the blob gas price of the next L1 block
+	// to set the blob gas fee cap of the blob transactions, default value is 1.
+	BlobGasPriceMarginFactor float64 `mapstructure:"BlobGasPriceMarginFactor"`
+
+	// MaxBlobGasPriceLimit helps avoiding blob transactions to be sent over an specified
+	// blob gas price amount, default value is 0, which means no limit.
+	MaxBlobGasPriceLimit uint64 `mapstructure:"MaxBlobGasPriceLimit"`
 }
@@ -22,6 +22,10 @@ import (
 const (
 	failureIntervalInSeconds = 5
 	// maxHistorySize           = 10
+
+	// blobTxPriceBumpFactor is the minimum factor the fee caps of a blob tx must be increased by
+	// to replace it, as required by the blob pool of the L1 nodes
+	blobTxPriceBumpFactor = 2
 )
 
 var (
@@ -33,6 +37,9 @@ var (
 	// ErrExecutionReverted returned when trying to get the revert message
 	// but the call fails without revealing the revert reason
 	ErrExecutionReverted = errors.New("execution reverted")
+
+	// ErrBlobTxWithoutReceiver is returned when adding a blob tx without receiver, as blob txs can't create contracts
+	ErrBlobTxWithoutReceiver = errors.New("blob txs must have a receiver")
 )
 
 // Client for eth tx manager
@@ -60,6 +67,19 @@ func New(cfg Config, ethMan ethermanInterface, storage storageInterface, state s
 
 // Add a transaction to be sent and monitored
 func (c *Client) Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, dbTx pgx.Tx) error {
+	return c.add(ctx, owner, id, from, to, value, data, gasOffset, nil, dbTx)
+}
+
+// AddWithBlobs adds a blob transaction (EIP-4844) to be sent and monitored. The blob gas price
+// is bumped along with the gas price while the tx is not mined
+func (c *Client) AddWithBlobs(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, blobSidecar *types.BlobTxSidecar, dbTx pgx.Tx) error {
+	if to == nil {
+		return ErrBlobTxWithoutReceiver
+	}
+	return c.add(ctx, owner, id, from, to, value, data, gasOffset, blobSidecar, dbTx)
+}
+
+func (c *Client) add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, blobSidecar *types.BlobTxSidecar, dbTx pgx.Tx) error {
 	// get next nonce
 	nonce, err := c.etherman.CurrentNonce(ctx, from)
 	if err != nil {
@@ -87,11 +107,29 @@ func (c *Client) Add(ctx context.Context, owner, id string, from common.Address,
 		return err
 	}
 
+	// get gas tip cap and blob gas price
+	var gasTipCap, blobGasPrice *big.Int
+	if blobSidecar != nil {
+		gasTipCap, err = c.suggestedGasTipCap(ctx, gasPrice)
+		if err != nil {
+			err := fmt.Errorf("failed to get suggested gas tip cap: %w", err)
+			log.Errorf(err.Error())
+			return err
+		}
+		blobGasPrice, err = c.suggestedBlobGasPrice(ctx)
+		if err != nil {
+			err := fmt.Errorf("failed to get suggested blob gas price: %w", err)
+			log.Errorf(err.Error())
+			return err
+		}
+	}
+
 	// create monitored tx
 	mTx := monitoredTx{
 		owner: owner, id: id, from: from, to: to,
 		nonce: nonce, value: value, data: data,
-		gas: gas, gasOffset: gasOffset, gasPrice: gasPrice,
+		gas: gas, gasOffset: gasOffset, gasPrice: gasPrice, gasTipCap: gasTipCap,
+		blobSidecar: blobSidecar, blobGasPrice: blobGasPrice,
 		status: MonitoredTxStatusCreated,
 	}
 
@@ -145,7 +183,7 @@ func (c *Client) Result(ctx context.Context, owner, id string, dbTx pgx.Tx) (Mon
 // SetStatusDone sets the status of a monitored tx to MonitoredStatusDone.
 // this method is provided to the callers to decide when a monitored tx should be
 // considered done, so they can start to ignore it when querying it by Status.
-func (c *Client) setStatusDone(ctx context.Context, owner, id string, dbTx pgx.Tx) error {
+func (c *Client) SetStatusDone(ctx context.Context, owner, id string, dbTx pgx.Tx) error {
 	mTx, err := c.storage.Get(ctx, owner, id, nil)
 	if err != nil {
 		return err
@@ -532,6 +570,10 @@ func (c *Client) reviewMonitoredTx(ctx context.Context, mTx *monitoredTx, mTxLog
 		return err
 	}
 
+	if mTx.isBlobTx() {
+		return c.reviewMonitoredBlobTxPrices(ctx, mTx, gasPrice, mTxLogger)
+	}
+
 	// check gas price
 	if gasPrice.Cmp(mTx.gasPrice) == 1 {
 		mTxLogger.Infof("monitored tx gas price updated from %v to %v", mTx.gasPrice.String(), gasPrice.String())
@@ -540,6 +582,56 @@ func (c *Client) reviewMonitoredTx(ctx context.Context, mTx *monitoredTx, mTxLog
 	return nil
 }
 
+// reviewMonitoredBlobTxPrices increases the gas price, the gas tip cap and the blob gas price of a blob
+// tx if any of them is below the suggested one. A blob tx is only replaced if all its fee caps and its tip
+// are bumped by blobTxPriceBumpFactor, so all of them are raised to at least that factor
+func (c *Client) reviewMonitoredBlobTxPrices(ctx context.Context, mTx *monitoredTx, gasPrice *big.Int, mTxLogger *log.Logger) error {
+	blobGasPrice, err := c.suggestedBlobGasPrice(ctx)
+	if err != nil {
+		err := fmt.Errorf("failed to get suggested blob gas price: %w", err)
+		mTxLogger.Errorf(err.Error())
+		return err
+	}
+	gasTipCap, err := c.suggestedGasTipCap(ctx, gasPrice)
+	if err != nil {
+		err := fmt.Errorf("failed to get suggested gas tip cap: %w", err)
+		mTxLogger.Errorf(err.Error())
+		return err
+	}
+
+	currentGasTipCap := mTx.gasTipCap
+	if currentGasTipCap == nil {
+		currentGasTipCap = big.NewInt(0)
+	}
+	if gasPrice.Cmp(mTx.gasPrice) <= 0 && gasTipCap.Cmp(currentGasTipCap) <= 0 && blobGasPrice.Cmp(mTx.blobGasPrice) <= 0 {
+		return nil
+	}
+
+	minGasPrice := new(big.Int).Mul(mTx.gasPrice, big.NewInt(blobTxPriceBumpFactor))
+	if gasPrice.Cmp(minGasPrice) < 0 {
+		gasPrice = minGasPrice
+	}
+	minGasTipCap := new(big.Int).Mul(currentGasTipCap, big.NewInt(blobTxPriceBumpFactor))
+	if gasTipCap.Cmp(minGasTipCap) < 0 {
+		gasTipCap = minGasTipCap
+	}
+	// the tip can't be above the fee cap, which has been bumped by the same factor
+	if gasTipCap.Cmp(gasPrice) > 0 {
+		gasTipCap = new(big.Int).Set(gasPrice)
+	}
+	minBlobGasPrice := new(big.Int).Mul(mTx.blobGasPrice, big.NewInt(blobTxPriceBumpFactor))
+	if blobGasPrice.Cmp(minBlobGasPrice) < 0 {
+		blobGasPrice = minBlobGasPrice
+	}
+
+	mTxLogger.Infof("monitored tx gas price updated from %v to %v, gas tip cap from %v to %v and blob gas price from %v to %v",
+		mTx.gasPrice.String(), gasPrice.String(), currentGasTipCap.String(), gasTipCap.String(), mTx.blobGasPrice.String(), blobGasPrice.String())
+	mTx.gasPrice = gasPrice
+	mTx.gasTipCap = gasTipCap
+	mTx.blobGasPrice = blobGasPrice
+	return nil
+}
+
 // reviewMonitoredTxNonce checks if the nonce needs to be updated accordingly to
 // the current nonce of the sender account.
 //
@@ -587,6 +679,47 @@ func (c *Client) suggestedGasPrice(ctx context.Context) (*big.Int, error) {
 	return adjustedGasPrice, nil
 }
 
+// suggestedGasTipCap returns the suggested gas tip cap, limited to the gas price used as fee cap
+func (c *Client) suggestedGasTipCap(ctx context.Context, gasPrice *big.Int) (*big.Int, error) {
+	gasTipCap, err := c.etherman.SuggestedGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if gasTipCap.Cmp(gasPrice) > 0 {
+		gasTipCap = new(big.Int).Set(gasPrice)
+	}
+	return gasTipCap, nil
+}
+
+func (c *Client) suggestedBlobGasPrice(ctx context.Context) (*big.Int, error) {
+	// get blob gas price
+	blobGasPrice, err := c.etherman.SuggestedBlobGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// adjust the blob gas price by the margin factor
+	marginFactor := big.NewFloat(0).SetFloat64(c.cfg.BlobGasPriceMarginFactor)
+	fBlobGasPrice := big.NewFloat(0).SetInt(blobGasPrice)
+	adjustedBlobGasPrice, _ := big.NewFloat(0).Mul(fBlobGasPrice, marginFactor).Int(big.NewInt(0))
+
+	// the blob gas price can't be lower than the minimum accepted by the network
+	if adjustedBlobGasPrice.Cmp(blobGasPrice) < 0 {
+		adjustedBlobGasPrice.Set(blobGasPrice)
+	}
+
+	// if there is a max blob gas price limit configured and the current
+	// adjusted blob gas price is over this limit, set the blob gas price as the limit
+	if c.cfg.MaxBlobGasPriceLimit > 0 {
+		maxBlobGasPrice := big.NewInt(0).SetUint64(c.cfg.MaxBlobGasPriceLimit)
+		if adjustedBlobGasPrice.Cmp(maxBlobGasPrice) == 1 {
+			adjustedBlobGasPrice.Set(maxBlobGasPrice)
+		}
+	}
+
+	return adjustedBlobGasPrice, nil
+}
+
 // logErrorAndWait used when an error is detected before trying again
 func (c *Client) logErrorAndWait(msg string, err error) {
 	log.Errorf(msg, err)
@@ -629,7 +762,7 @@ func (c *Client) ProcessPendingMonitoredTxs(ctx context.Context, owner string, r
 
 			// if the result is confirmed, we set it as done do stop looking into this monitored tx
 			if result.Status == MonitoredTxStatusConfirmed {
-				err := c.setStatusDone(ctx, owner, result.ID, dbTx)
+				err := c.SetStatusDone(ctx, owner, result.ID, dbTx)
 				if err != nil {
 					mTxResultLogger.Errorf("failed to set monitored tx as done, err: %v", err)
 					// if something goes wrong at this point, we skip this result and move to the next.
@@ -937,3 +937,60 @@ func TestFailedToEstimateTxWithForcedGasGetMined(t *testing.T) {
 	require.Equal(t, receipt, result.Txs[signedTx.Hash()].Receipt)
 	require.Equal(t, "", result.Txs[signedTx.Hash()].RevertMessage)
 }
+
+func TestReviewMonitoredBlobTxPrices(t *testing.T) {
+	ctx := context.Background()
+	to := common.HexToAddress("0x2")
+
+	testCases := []struct {
+		name                  string
+		suggestedGasPrice     int64
+		suggestedGasTipCap    int64
+		suggestedBlobGasPrice int64
+		expectedGasPrice      int64
+		expectedGasTipCap     int64
+		expectedBlobGasPrice  int64
+	}{
+		{name: "prices unchanged", suggestedGasPrice: 10, suggestedGasTipCap: 2, suggestedBlobGasPrice: 20, expectedGasPrice: 10, expectedGasTipCap: 2, expectedBlobGasPrice: 20},
+		{name: "gas price increased", suggestedGasPrice: 11, suggestedGasTipCap: 2, suggestedBlobGasPrice: 20, expectedGasPrice: 20, expectedGasTipCap: 4, expectedBlobGasPrice: 40},
+		{name: "gas tip cap increased", suggestedGasPrice: 10, suggestedGasTipCap: 3, suggestedBlobGasPrice: 20, expectedGasPrice: 20, expectedGasTipCap: 4, expectedBlobGasPrice: 40},
+		{name: "blob gas price increased", suggestedGasPrice: 10, suggestedGasTipCap: 2, suggestedBlobGasPrice: 21, expectedGasPrice: 20, expectedGasTipCap: 4, expectedBlobGasPrice: 40},
+		{name: "all prices over the bump", suggestedGasPrice: 30, suggestedGasTipCap: 5, suggestedBlobGasPrice: 50, expectedGasPrice: 30, expectedGasTipCap: 5, expectedBlobGasPrice: 50},
+		{name: "gas tip cap above the gas price", suggestedGasPrice: 30, suggestedGasTipCap: 40, suggestedBlobGasPrice: 50, expectedGasPrice: 30, expectedGasTipCap: 30, expectedBlobGasPrice: 50},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			etherman := newEthermanMock(t)
+			ethTxManagerClient := New(defaultEthTxmanagerConfigForTests, etherman, nil, nil)
+
+			etherman.
+				On("EstimateGas", ctx, common.Address{}, &to, (*big.Int)(nil), []byte(nil)).
+				Return(uint64(1), nil).
+				Once()
+			etherman.
+				On("SuggestedGasPrice", ctx).
+				Return(big.NewInt(tc.suggestedGasPrice), nil).
+				Once()
+			etherman.
+				On("SuggestedBlobGasPrice", ctx).
+				Return(big.NewInt(tc.suggestedBlobGasPrice), nil).
+				Once()
+			etherman.
+				On("SuggestedGasTipCap", ctx).
+				Return(big.NewInt(tc.suggestedGasTipCap), nil).
+				Once()
+
+			mTx := &monitoredTx{
+				to: &to, gas: 1,
+				gasPrice: big.NewInt(10), gasTipCap: big.NewInt(2), blobGasPrice: big.NewInt(20),
+				blobSidecar: &ethTypes.BlobTxSidecar{},
+			}
+			err := ethTxManagerClient.reviewMonitoredTx(ctx, mTx, createMonitoredTxLogger(*mTx))
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(tc.expectedGasPrice), mTx.gasPrice)
+			require.Equal(t, big.NewInt(tc.expectedGasTipCap), mTx.gasTipCap)
+			require.Equal(t, big.NewInt(tc.expectedBlobGasPrice), mTx.blobGasPrice)
+		})
+	}
+}
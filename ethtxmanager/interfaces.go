@@ -18,6 +18,8 @@ type ethermanInterface interface {
 	SendTx(ctx context.Context, tx *types.Transaction) error
 	CurrentNonce(ctx context.Context, account common.Address) (uint64, error)
 	SuggestedGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestedGasTipCap(ctx context.Context) (*big.Int, error)
+	SuggestedBlobGasPrice(ctx context.Context) (*big.Int, error)
 	EstimateGas(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) (uint64, error)
 	CheckTxWasMined(ctx context.Context, txHash common.Hash) (bool, *types.Receipt, error)
 	SignTx(ctx context.Context, sender common.Address, tx *types.Transaction) (*types.Transaction, error)
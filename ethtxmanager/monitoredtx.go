@@ -7,6 +7,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
 )
 
 const (
@@ -76,9 +78,18 @@ type monitoredTx struct {
 	// tx gas offset
 	gasOffset uint64
 
-	// tx gas price
+	// tx gas price, used as gas fee cap for blob txs
 	gasPrice *big.Int
 
+	// gasTipCap is the gas tip cap of blob txs, it's never above the gas price
+	gasTipCap *big.Int
+
+	// blobSidecar contains the blobs of the tx, if nil the tx is a legacy tx
+	blobSidecar *types.BlobTxSidecar
+
+	// blobGasPrice is the blob gas fee cap of the tx
+	blobGasPrice *big.Int
+
 	// status of this monitoring
 	status MonitoredTxStatus
 
@@ -101,6 +112,10 @@ type monitoredTx struct {
 
 // Tx uses the current information to build a tx
 func (mTx monitoredTx) Tx() *types.Transaction {
+	if mTx.isBlobTx() {
+		return mTx.blobTx()
+	}
+
 	tx := types.NewTx(&types.LegacyTx{
 		To:       mTx.to,
 		Nonce:    mTx.nonce,
@@ -113,6 +128,40 @@ func (mTx monitoredTx) Tx() *types.Transaction {
 	return tx
 }
 
+// isBlobTx returns true if the monitored tx carries blobs
+func (mTx monitoredTx) isBlobTx() bool {
+	return mTx.blobSidecar != nil
+}
+
+// blobTx builds a blob tx. The chain id is set when the tx is signed
+func (mTx monitoredTx) blobTx() *types.Transaction {
+	var to common.Address
+	if mTx.to != nil {
+		to = *mTx.to
+	}
+	value := new(uint256.Int)
+	if mTx.value != nil {
+		value.SetFromBig(mTx.value)
+	}
+	gasTipCap := new(uint256.Int)
+	if mTx.gasTipCap != nil {
+		gasTipCap.SetFromBig(mTx.gasTipCap)
+	}
+	return types.NewTx(&types.BlobTx{
+		ChainID:    new(uint256.Int),
+		Nonce:      mTx.nonce,
+		GasTipCap:  gasTipCap,
+		GasFeeCap:  uint256.MustFromBig(mTx.gasPrice),
+		Gas:        mTx.gas + mTx.gasOffset,
+		To:         to,
+		Value:      value,
+		Data:       mTx.data,
+		BlobFeeCap: uint256.MustFromBig(mTx.blobGasPrice),
+		BlobHashes: mTx.blobSidecar.BlobHashes(),
+		Sidecar:    mTx.blobSidecar,
+	})
+}
+
 // AddHistory adds a transaction to the monitoring history
 func (mTx monitoredTx) AddHistory(tx *types.Transaction) error {
 	if _, found := mTx.history[tx.Hash()]; found {
@@ -170,6 +219,34 @@ func (mTx *monitoredTx) historyHashSlice() []common.Hash {
 	return history
 }
 
+// blobSidecarBytes returns the current blobSidecar field RLP encoded
+func (mTx *monitoredTx) blobSidecarBytes() ([]byte, error) {
+	if mTx.blobSidecar == nil {
+		return nil, nil
+	}
+	return rlp.EncodeToBytes(mTx.blobSidecar)
+}
+
+// gasTipCapU64Ptr returns the current gasTipCap field as a uint64 pointer
+func (mTx *monitoredTx) gasTipCapU64Ptr() *uint64 {
+	var gasTipCap *uint64
+	if mTx.gasTipCap != nil {
+		tmp := mTx.gasTipCap.Uint64()
+		gasTipCap = &tmp
+	}
+	return gasTipCap
+}
+
+// blobGasPriceU64Ptr returns the current blobGasPrice field as a uint64 pointer
+func (mTx *monitoredTx) blobGasPriceU64Ptr() *uint64 {
+	var blobGasPrice *uint64
+	if mTx.blobGasPrice != nil {
+		tmp := mTx.blobGasPrice.Uint64()
+		blobGasPrice = &tmp
+	}
+	return blobGasPrice
+}
+
 // blockNumberU64Ptr returns the current blockNumber as a uint64 pointer
 func (mTx *monitoredTx) blockNumberU64Ptr() *uint64 {
 	var blockNumber *uint64
@@ -1,11 +1,22 @@
 package ethtxmanager
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygonHermez/zkevm-node/etherman/blob"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTx(t *testing.T) {
@@ -36,3 +47,107 @@ func TestTx(t *testing.T) {
 	assert.Equal(t, gas+gasOffset, tx.Gas())
 	assert.Equal(t, gasPrice, tx.GasPrice())
 }
+
+func TestBlobTx(t *testing.T) {
+	to := common.HexToAddress("0x2")
+	gasPrice := big.NewInt(5)
+	gasTipCap := big.NewInt(1)
+	blobGasPrice := big.NewInt(6)
+	sidecar := newTestBlobSidecar(t, []byte("batch data"))
+
+	mTx := monitoredTx{
+		to:           &to,
+		nonce:        1,
+		value:        big.NewInt(2),
+		data:         []byte("data"),
+		gas:          3,
+		gasOffset:    4,
+		gasPrice:     gasPrice,
+		gasTipCap:    gasTipCap,
+		blobSidecar:  sidecar,
+		blobGasPrice: blobGasPrice,
+	}
+
+	tx := mTx.Tx()
+
+	assert.Equal(t, uint8(types.BlobTxType), tx.Type())
+	assert.Equal(t, &to, tx.To())
+	assert.Equal(t, uint64(7), tx.Gas())
+	assert.Equal(t, gasPrice, tx.GasFeeCap())
+	assert.Equal(t, gasTipCap, tx.GasTipCap())
+	assert.Equal(t, blobGasPrice, tx.BlobGasFeeCap())
+	assert.Equal(t, sidecar.BlobHashes(), tx.BlobHashes())
+	assert.Equal(t, sidecar, tx.BlobTxSidecar())
+}
+
+func TestBlobTxAcceptedBySimulatedBackend(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	balance, _ := new(big.Int).SetString("10000000000000000000000000", 10)
+
+	// the simulated beacon can't mine blocks with blobs, but the tx pool validates and accepts them
+	chainConfig := *params.AllDevChainProtocolChanges
+	cancunTime := uint64(0)
+	chainConfig.CancunTime = &cancunTime
+	backend := simulated.NewBackend(core.GenesisAlloc{from: {Balance: balance}}, func(_ *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis.Config = &chainConfig
+	})
+	defer backend.Close()
+	client := backend.Client()
+	ctx := context.Background()
+
+	header, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.NotNil(t, header.ExcessBlobGas)
+	blobGasPrice := eip4844.CalcBlobFee(*header.ExcessBlobGas)
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	gasTipCap, err := client.SuggestGasTipCap(ctx)
+	require.NoError(t, err)
+
+	to := common.HexToAddress("0x2")
+	mTx := monitoredTx{
+		from:         from,
+		to:           &to,
+		nonce:        0,
+		value:        big.NewInt(0),
+		data:         []byte("data"),
+		gas:          params.TxGas + 1000,
+		gasPrice:     gasPrice,
+		gasTipCap:    gasTipCap,
+		blobSidecar:  newTestBlobSidecar(t, []byte("batch data")),
+		blobGasPrice: blobGasPrice,
+	}
+
+	signer := types.LatestSignerForChainID(chainConfig.ChainID)
+	signedTx, err := types.SignTx(mTx.Tx(), signer, privateKey)
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(ctx, signedTx))
+
+	_, isPending, err := client.TransactionByHash(ctx, signedTx.Hash())
+	require.NoError(t, err)
+	assert.True(t, isPending)
+
+	// a replacement that doesn't double the fee caps is rejected by the blob pool
+	mTx.gasPrice = new(big.Int).Add(gasPrice, big.NewInt(1))
+	underpricedTx, err := types.SignTx(mTx.Tx(), signer, privateKey)
+	require.NoError(t, err)
+	require.Error(t, client.SendTransaction(ctx, underpricedTx))
+
+	// doubling the fee caps and the tip replaces it
+	mTx.gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(blobTxPriceBumpFactor))
+	mTx.gasTipCap = new(big.Int).Mul(gasTipCap, big.NewInt(blobTxPriceBumpFactor))
+	mTx.blobGasPrice = new(big.Int).Mul(blobGasPrice, big.NewInt(blobTxPriceBumpFactor))
+	replacementTx, err := types.SignTx(mTx.Tx(), signer, privateKey)
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(ctx, replacementTx))
+}
+
+func newTestBlobSidecar(t *testing.T, data []byte) *types.BlobTxSidecar {
+	blobs, err := blob.Encode(data)
+	require.NoError(t, err)
+	sidecar, err := blob.NewSidecar(blobs)
+	require.NoError(t, err)
+	return sidecar
+}
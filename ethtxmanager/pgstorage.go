@@ -9,6 +9,8 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -36,13 +38,19 @@ func NewPostgresStorage(dbCfg db.Config) (*PostgresStorage, error) {
 func (s *PostgresStorage) Add(ctx context.Context, mTx monitoredTx, dbTx pgx.Tx) error {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at)
-                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,    $11,       $12,     $13,        $14,        $15)`
+        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, blob_sidecar, blob_gas_price, status, block_num, history, created_at, updated_at)
+                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,         $11,          $12,            $13,    $14,       $15,     $16,        $17,        $18)`
 
-	_, err := conn.Exec(ctx, cmd, mTx.owner,
+	blobSidecar, err := mTx.blobSidecarBytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx, cmd, mTx.owner,
 		mTx.id, mTx.from.String(), mTx.toStringPtr(),
 		mTx.nonce, mTx.valueU64Ptr(), mTx.dataStringPtr(),
-		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), string(mTx.status), mTx.blockNumberU64Ptr(),
+		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), mTx.gasTipCapU64Ptr(), blobSidecar, mTx.blobGasPriceU64Ptr(),
+		string(mTx.status), mTx.blockNumberU64Ptr(),
 		mTx.historyStringSlice(), time.Now().UTC().Round(time.Microsecond),
 		time.Now().UTC().Round(time.Microsecond))
 
@@ -61,7 +69,7 @@ func (s *PostgresStorage) Add(ctx context.Context, mTx monitoredTx, dbTx pgx.Tx)
 func (s *PostgresStorage) Get(ctx context.Context, owner, id string, dbTx pgx.Tx) (monitoredTx, error) {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, blob_sidecar, blob_gas_price, status, block_num, history, created_at, updated_at
           FROM state.monitored_txs
          WHERE owner = $1 
            AND id = $2`
@@ -85,7 +93,7 @@ func (s *PostgresStorage) GetByStatus(ctx context.Context, owner *string, status
 
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, blob_sidecar, blob_gas_price, status, block_num, history, created_at, updated_at
           FROM state.monitored_txs
          WHERE (owner = $1 OR $1 IS NULL)`
 	if hasStatusToFilter {
@@ -128,7 +136,7 @@ func (s *PostgresStorage) GetByStatus(ctx context.Context, owner *string, status
 func (s *PostgresStorage) GetByBlock(ctx context.Context, fromBlock, toBlock *uint64, dbTx pgx.Tx) ([]monitoredTx, error) {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, blob_sidecar, blob_gas_price, status, block_num, history, created_at, updated_at
           FROM state.monitored_txs
          WHERE (block_num >= $1 OR $1 IS NULL)
            AND (block_num <= $2 OR $2 IS NULL)
@@ -184,10 +192,13 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
              , gas = $8
              , gas_offset = $9
              , gas_price = $10
-             , status = $11
-             , block_num = $12
-             , history = $13
-             , updated_at = $14
+             , gas_tip_cap = $11
+             , blob_sidecar = $12
+             , blob_gas_price = $13
+             , status = $14
+             , block_num = $15
+             , history = $16
+             , updated_at = $17
          WHERE owner = $1
            AND id = $2`
 
@@ -197,10 +208,16 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
 		bn = &tmp
 	}
 
-	_, err := conn.Exec(ctx, cmd, mTx.owner,
+	blobSidecar, err := mTx.blobSidecarBytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx, cmd, mTx.owner,
 		mTx.id, mTx.from.String(), mTx.toStringPtr(),
 		mTx.nonce, mTx.valueU64Ptr(), mTx.dataStringPtr(),
-		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), string(mTx.status), bn,
+		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), mTx.gasTipCapU64Ptr(), blobSidecar, mTx.blobGasPriceU64Ptr(),
+		string(mTx.status), bn,
 		mTx.historyStringSlice(), time.Now().UTC().Round(time.Microsecond))
 
 	if err != nil {
@@ -213,20 +230,34 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
 // scanMtx scans a row and fill the provided instance of monitoredTx with
 // the row data
 func (s *PostgresStorage) scanMtx(row pgx.Row, mTx *monitoredTx) error {
-	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, status, history, created_at, updated_at
+	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, gas_tip_cap, blob_sidecar, blob_gas_price, status, history, created_at, updated_at
 	var from, status string
 	var to, data *string
 	var history []string
-	var value, blockNumber *uint64
+	var value, blockNumber, gasTipCap, blobGasPrice *uint64
 	var gasPrice uint64
+	var blobSidecar []byte
 
 	err := row.Scan(&mTx.owner, &mTx.id, &from, &to, &mTx.nonce, &value,
-		&data, &mTx.gas, &mTx.gasOffset, &gasPrice, &status, &blockNumber, &history,
+		&data, &mTx.gas, &mTx.gasOffset, &gasPrice, &gasTipCap, &blobSidecar, &blobGasPrice, &status, &blockNumber, &history,
 		&mTx.createdAt, &mTx.updatedAt)
 	if err != nil {
 		return err
 	}
 
+	if blobSidecar != nil {
+		mTx.blobSidecar = &types.BlobTxSidecar{}
+		if err := rlp.DecodeBytes(blobSidecar, mTx.blobSidecar); err != nil {
+			return err
+		}
+	}
+	if gasTipCap != nil {
+		mTx.gasTipCap = big.NewInt(0).SetUint64(*gasTipCap)
+	}
+	if blobGasPrice != nil {
+		mTx.blobGasPrice = big.NewInt(0).SetUint64(*blobGasPrice)
+	}
+
 	mTx.from = common.HexToAddress(from)
 	mTx.gasPrice = big.NewInt(0).SetUint64(gasPrice)
 	mTx.status = MonitoredTxStatus(status)
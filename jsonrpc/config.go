@@ -59,12 +59,20 @@ type Config struct {
 	// native block hashes in a single call to the state, if zero it means no limit
 	MaxNativeBlockHashBlockRange uint64 `mapstructure:"MaxNativeBlockHashBlockRange"`
 
+	// MaxTxPoolContentSize is a configuration to set the max number of txs that can be returned
+	// by txpool_content, txpool_contentFrom and txpool_inspect, if zero it means no limit. The
+	// txpool_content and txpool_inspect requests fail once the pool has more pending txs
+	MaxTxPoolContentSize uint64 `mapstructure:"MaxTxPoolContentSize"`
+
 	// EnableHttpLog allows the user to enable or disable the logs related to the HTTP
 	// requests to be captured by the server.
 	EnableHttpLog bool `mapstructure:"EnableHttpLog"`
 
 	// ZKCountersLimits defines the ZK Counter limits
 	ZKCountersLimits ZKCountersLimits
+
+	// PendingReceipts configuration
+	PendingReceipts PendingReceiptsConfig `mapstructure:"PendingReceipts"`
 }
 
 // ZKCountersLimits defines the ZK Counter limits
@@ -93,3 +101,14 @@ type WebSocketsConfig struct {
 	// ReadLimit defines the maximum size of a message read from the client (in bytes)
 	ReadLimit int64 `mapstructure:"ReadLimit"`
 }
+
+// PendingReceiptsConfig has parameters to config the receipts of the txs added to the wip L2 block
+// published by the sequencer
+type PendingReceiptsConfig struct {
+	// Enabled defines if the pending receipts can be requested with the pending block tag
+	// and subscribed to. It requires the sequencer to publish them in the pool
+	Enabled bool `mapstructure:"Enabled"`
+
+	// PollInterval is the interval to check for new pending receipts to notify to the subscriptions
+	PollInterval types.Duration `mapstructure:"PollInterval"`
+}
@@ -27,6 +27,8 @@ import (
 const (
 	// maxTopics is the max number of topics a log can have
 	maxTopics = 4
+	// apiKeyHeader is the header with the API key whose quota the sent txs consume
+	apiKeyHeader = "X-Api-Key"
 )
 
 // EthEndpoints contains implementations for the "eth" RPC endpoints
@@ -44,6 +46,11 @@ func NewEthEndpoints(cfg Config, chainID uint64, p types.PoolInterface, s types.
 	e := &EthEndpoints{cfg: cfg, chainID: chainID, pool: p, state: s, etherman: etherman, storage: storage}
 	s.RegisterNewL2BlockEventHandler(e.onNewL2Block)
 
+	// The pending receipts are only notified by the nodes with access to the pool of the sequencer
+	if cfg.PendingReceipts.Enabled && cfg.SequencerNodeURI == "" {
+		go state.InfiniteSafeRun(e.pollPendingReceipts, "failed to poll the pending receipts", time.Second)
+	}
+
 	return e
 }
 
@@ -834,10 +841,16 @@ func (e *EthEndpoints) getBlockTransactionCountByNumberFromSequencerNode(number
 }
 
 // GetTransactionReceipt returns a transaction receipt by his hash
-func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, types.Error) {
+func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash, blockTag *types.BlockNumber) (interface{}, types.Error) {
 	ctx := context.Background()
+	// The pending receipts of the txs added to the wip L2 block are returned if requested with the pending tag
+	pending := blockTag != nil && *blockTag == types.PendingBlockNumber && e.cfg.PendingReceipts.Enabled
+
 	tx, err := e.state.GetTransactionByHash(ctx, hash.Hash(), nil)
 	if errors.Is(err, state.ErrNotFound) {
+		if pending {
+			return e.getPendingReceipt(ctx, hash.Hash())
+		}
 		return nil, nil
 	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx from state", err, true)
@@ -845,6 +858,9 @@ func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, t
 
 	r, err := e.state.GetTransactionReceipt(ctx, hash.Hash(), nil)
 	if errors.Is(err, state.ErrNotFound) {
+		if pending {
+			return e.getPendingReceipt(ctx, hash.Hash())
+		}
 		return nil, nil
 	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx receipt from state", err, true)
@@ -858,6 +874,48 @@ func (e *EthEndpoints) GetTransactionReceipt(hash types.ArgHash) (interface{}, t
 	return receipt, nil
 }
 
+// getPendingReceipt returns the receipt of a tx added to the wip L2 block published by the sequencer
+func (e *EthEndpoints) getPendingReceipt(ctx context.Context, hash common.Hash) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_getTransactionReceipt", hash.String(), types.Pending)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the pending receipt from the sequencer node", err, true)
+		}
+		if res.Error != nil {
+			return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+		}
+		return json.RawMessage(res.Result), nil
+	}
+
+	r, err := e.pool.GetPendingReceipt(ctx, hash)
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get the pending receipt from the pool", err, true)
+	}
+
+	receipt, err := e.newPendingReceipt(ctx, r)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+	}
+
+	return receipt, nil
+}
+
+// newPendingReceipt builds the response of a pending receipt with the tx of the pool
+func (e *EthEndpoints) newPendingReceipt(ctx context.Context, r *ethTypes.Receipt) (*types.Receipt, error) {
+	poolTx, err := e.pool.GetTransactionByHash(ctx, r.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := types.NewReceipt(poolTx.Transaction, r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
 // NewBlockFilter creates a filter in the node, to notify when
 // a new block arrives. To check if the state has changed,
 // call eth_getFilterChanges.
@@ -941,7 +999,7 @@ func (e *EthEndpoints) SendRawTransaction(httpRequest *http.Request, input strin
 			ip = strings.Split(ips, ",")[0]
 		}
 
-		return e.tryToAddTxToPool(input, ip)
+		return e.tryToAddTxToPool(input, ip, httpRequest.Header.Get(apiKeyHeader))
 	}
 }
 
@@ -960,16 +1018,20 @@ func (e *EthEndpoints) relayTxToSequencerNode(input string) (interface{}, types.
 	return txHash, nil
 }
 
-func (e *EthEndpoints) tryToAddTxToPool(input, ip string) (interface{}, types.Error) {
+func (e *EthEndpoints) tryToAddTxToPool(input, ip, apiKey string) (interface{}, types.Error) {
 	tx, err := hexToTx(input)
 	if err != nil {
 		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
 	}
+	ctx := context.Background()
+	if apiKey != "" {
+		ctx = pool.WithAPIKey(ctx, apiKey)
+	}
 	log.Infof("adding TX to the pool: %v", tx.Hash().Hex())
-	if err := e.pool.AddTx(context.Background(), *tx, ip); err != nil {
+	if err := e.pool.AddTx(ctx, *tx, ip); err != nil {
 		// it's not needed to log the error here, because we check and log if needed
 		// for each specific case during the "pool.AddTx" internal steps
-		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+		return RPCErrorResponse(poolErrorCode(err), err.Error(), nil, false)
 	}
 	log.Infof("TX added to the pool: %v", tx.Hash().Hex())
 
@@ -1101,6 +1163,8 @@ func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilte
 		return e.newFilter(ctx, wsConn, lf, nil)
 	case "pendingTransactions", "newPendingTransactions":
 		return e.newPendingTransactionFilter(wsConn)
+	case "pendingReceipts":
+		return e.newPendingReceiptFilter(wsConn)
 	case "syncing":
 		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
 	default:
@@ -1108,6 +1172,20 @@ func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilte
 	}
 }
 
+// internal
+func (e *EthEndpoints) newPendingReceiptFilter(wsConn *concurrentWsConn) (interface{}, types.Error) {
+	if !e.cfg.PendingReceipts.Enabled || e.cfg.SequencerNodeURI != "" {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "pending receipts are not enabled")
+	}
+
+	id, err := e.storage.NewPendingReceiptFilter(wsConn)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new pending receipt filter", err, true)
+	}
+
+	return id, nil
+}
+
 // Unsubscribe uninstalls the filter based on the provided filterID
 func (e *EthEndpoints) Unsubscribe(wsConn *concurrentWsConn, filterID string) (interface{}, types.Error) {
 	return e.UninstallFilter(filterID)
@@ -1203,6 +1281,58 @@ func (e *EthEndpoints) notifyNewLogs(wg *sync.WaitGroup, event state.NewL2BlockE
 	log.Debugf("[notifyNewLogs] new l2 block event for block %v took %v to send all the messages for log filters", event.Block.NumberU64(), time.Since(start))
 }
 
+// pollPendingReceipts checks periodically the pending receipts published by the sequencer
+// and notifies the new ones to the pending receipt filters. The receipts published before
+// the first poll are not notified
+func (e *EthEndpoints) pollPendingReceipts() {
+	ctx := context.Background()
+	var lastID uint64
+	first := true
+	for {
+		time.Sleep(e.cfg.PendingReceipts.PollInterval.Duration)
+
+		receipts, err := e.pool.GetPendingReceiptsFrom(ctx, lastID)
+		if err != nil {
+			log.Errorf("failed to get pending receipts from the pool: %v", err)
+		} else {
+			if len(receipts) > 0 {
+				lastID = receipts[len(receipts)-1].ID
+			}
+			if !first {
+				e.notifyPendingReceipts(ctx, receipts)
+			}
+			first = false
+		}
+	}
+}
+
+func (e *EthEndpoints) notifyPendingReceipts(ctx context.Context, receipts []pool.PendingReceipt) {
+	if len(receipts) == 0 {
+		return
+	}
+
+	filters := e.storage.GetAllPendingReceiptFiltersWithWSConn()
+	if len(filters) == 0 {
+		return
+	}
+
+	for _, r := range receipts {
+		receipt, err := e.newPendingReceipt(ctx, r.Receipt)
+		if err != nil {
+			log.Errorf("failed to build pending receipt response of tx %s to subscription: %v", r.Receipt.TxHash.String(), err)
+			continue
+		}
+		data, err := json.Marshal(receipt)
+		if err != nil {
+			log.Errorf("failed to marshal pending receipt response to subscription: %v", err)
+			continue
+		}
+		for _, filter := range filters {
+			filter.EnqueueSubscriptionDataToBeSent(data)
+		}
+	}
+}
+
 // shouldSkipLogFilter checks if the log filter can be skipped while notifying new logs.
 // it checks the log filter information against the block in the event to decide if the
 // information in the event is required by the filter or can be ignored to save resources.
@@ -11,9 +11,11 @@ import (
 	"testing"
 	"time"
 
+	configTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/encoding"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
@@ -2967,6 +2969,82 @@ func TestSendRawTransactionJSONRPCCall(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			Name: "Send TX rejected by the call policy",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+
+				txBinary, err := tx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = types.NewRPCError(types.AccessDeniedCode, pool.ErrContractDisallowedCall.Error())
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					Return(pool.ErrContractDisallowedCall).
+					Once()
+			},
+		},
+		{
+			Name: "Send TX exceeding the value limit of the sender",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+
+				txBinary, err := tx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = types.NewRPCError(types.LimitExceededCode, pool.ErrValueExceedsPolicyLimit.Error())
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					Return(pool.ErrValueExceedsPolicyLimit).
+					Once()
+			},
+		},
+		{
+			Name: "Send TX exceeding the quota of the sender",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+
+				txBinary, err := tx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = types.NewRPCError(types.QuotaExceededCode, "quota exceeded: sender")
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					Return(fmt.Errorf("%w: %s", pool.ErrQuotaExceeded, pool.SenderQuota)).
+					Once()
+			},
+		},
+		{
+			Name: "Send TX with an unknown API key",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+
+				txBinary, err := tx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = types.NewRPCError(types.AccessDeniedCode, "unknown API key")
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					Return(pool.ErrUnknownAPIKey).
+					Once()
+			},
+		},
 		{
 			Name: "Send invalid tx input",
 			Prepare: func(t *testing.T, tc *testCase) {
@@ -4809,3 +4887,65 @@ func TestSendRawTransactionJSONRPCCallWithPolicyApplied(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTransactionReceiptPending(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.PendingReceipts = PendingReceiptsConfig{Enabled: true, PollInterval: configTypes.NewDuration(time.Hour)}
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	tx := newSignedPoolTx(t, newTestAuth(t), 0, 1)
+	pendingReceipt := &ethTypes.Receipt{
+		Status:            ethTypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		GasUsed:           21000,
+		TxHash:            tx.Hash(),
+		TransactionIndex:  1,
+		Logs:              []*ethTypes.Log{},
+	}
+
+	m.State.On("GetTransactionByHash", context.Background(), tx.Hash(), nil).Return(nil, state.ErrNotFound).Twice()
+
+	// the pending receipts are only returned with the pending tag
+	res, err := s.JSONRPCCall("eth_getTransactionReceipt", tx.Hash().String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+
+	m.Pool.On("GetPendingReceipt", context.Background(), tx.Hash()).Return(pendingReceipt, nil).Once()
+	m.Pool.On("GetTransactionByHash", context.Background(), tx.Hash()).Return(&tx, nil).Once()
+	res, err = s.JSONRPCCall("eth_getTransactionReceipt", tx.Hash().String(), "pending")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var receipt types.Receipt
+	require.NoError(t, json.Unmarshal(res.Result, &receipt))
+	assert.Equal(t, tx.Hash(), receipt.TxHash)
+	assert.Equal(t, types.ArgUint64(1), receipt.TxIndex)
+	assert.Equal(t, types.ArgUint64(21000), receipt.GasUsed)
+	assert.Equal(t, types.ArgUint64(ethTypes.ReceiptStatusSuccessful), receipt.Status)
+	assert.Equal(t, tx.To(), receipt.ToAddr)
+}
+
+func TestNotifyPendingReceipts(t *testing.T) {
+	m := mocks.NewPoolMock(t)
+	storage := newStorageMock(t)
+	e := &EthEndpoints{pool: m, storage: storage}
+
+	tx := newSignedPoolTx(t, newTestAuth(t), 0, 1)
+	filter := &Filter{wsQueue: state.NewQueue[[]byte](), wsQueueSignal: sync.NewCond(&sync.Mutex{})}
+	storage.On("GetAllPendingReceiptFiltersWithWSConn").Return([]*Filter{filter}).Once()
+	m.On("GetTransactionByHash", context.Background(), tx.Hash()).Return(&tx, nil).Once()
+
+	e.notifyPendingReceipts(context.Background(), nil)
+	e.notifyPendingReceipts(context.Background(), []pool.PendingReceipt{
+		{ID: 1, Receipt: &ethTypes.Receipt{TxHash: tx.Hash(), GasUsed: 21000, Logs: []*ethTypes.Log{}}},
+	})
+
+	require.Equal(t, 1, filter.wsQueue.Len())
+	data, err := filter.wsQueue.Pop()
+	require.NoError(t, err)
+	var receipt types.Receipt
+	require.NoError(t, json.Unmarshal(data, &receipt))
+	assert.Equal(t, tx.Hash(), receipt.TxHash)
+}
@@ -1,39 +1,366 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const (
+	// txReady is the readiness reason of the txs the sequencer can add to a batch
+	txReady = "ready"
+	// txNonceTooLow is the readiness reason of the txs with a nonce already used by the sender
+	txNonceTooLow = "nonce too low"
+	// txNonceGap is the readiness reason of the txs waiting for a tx with a lower nonce
+	txNonceGap = "nonce gap"
+	// txInsufficientBalance is the readiness reason of the txs whose cost plus the cost of the previous txs of the
+	// sender exceeds its balance
+	txInsufficientBalance = "insufficient balance"
+	// txReplaced is the readiness reason of the txs with the same nonce than a tx of the sender with a higher gas price
+	txReplaced = "replaced"
+)
+
 // TxPoolEndpoints is the txpool jsonrpc endpoint
-type TxPoolEndpoints struct{}
+type TxPoolEndpoints struct {
+	cfg   Config
+	pool  types.PoolInterface
+	state types.StateInterface
+}
+
+// NewTxPoolEndpoints returns TxPoolEndpoints
+func NewTxPoolEndpoints(cfg Config, pool types.PoolInterface, state types.StateInterface) *TxPoolEndpoints {
+	return &TxPoolEndpoints{
+		cfg:   cfg,
+		pool:  pool,
+		state: state,
+	}
+}
 
 type contentResponse struct {
-	Pending map[common.Address]map[uint64]*txPoolTransaction `json:"pending"`
-	Queued  map[common.Address]map[uint64]*txPoolTransaction `json:"queued"`
+	Pending map[common.Address]map[uint64]*types.Transaction `json:"pending"`
+	Queued  map[common.Address]map[uint64]*types.Transaction `json:"queued"`
+}
+
+type contentFromResponse struct {
+	Pending map[uint64]*types.Transaction `json:"pending"`
+	Queued  map[uint64]*types.Transaction `json:"queued"`
+}
+
+type statusResponse struct {
+	Pending types.ArgUint64 `json:"pending"`
+	Queued  types.ArgUint64 `json:"queued"`
 }
 
-type txPoolTransaction struct {
-	Nonce       types.ArgUint64 `json:"nonce"`
-	GasPrice    types.ArgBig    `json:"gasPrice"`
-	Gas         types.ArgUint64 `json:"gas"`
-	To          *common.Address `json:"to"`
-	Value       types.ArgBig    `json:"value"`
-	Input       types.ArgBytes  `json:"input"`
-	Hash        common.Hash     `json:"hash"`
-	From        common.Address  `json:"from"`
-	BlockHash   common.Hash     `json:"blockHash"`
-	BlockNumber interface{}     `json:"blockNumber"`
-	TxIndex     interface{}     `json:"transactionIndex"`
+type inspectResponse struct {
+	Pending map[common.Address]map[uint64]string `json:"pending"`
+	Queued  map[common.Address]map[uint64]string `json:"queued"`
 }
 
-// Content creates a response for txpool_content request.
+// Content creates a response for txpool_content request, returning the pending txs that are ready to be added to a
+// batch as pending and the rest as queued, grouped by sender and nonce.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
 func (e *TxPoolEndpoints) Content() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("txpool_content")
+	}
+
+	pending, queued, rpcErr := e.poolContent(context.Background())
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	var err error
 	resp := contentResponse{
-		Pending: make(map[common.Address]map[uint64]*txPoolTransaction),
-		Queued:  make(map[common.Address]map[uint64]*txPoolTransaction),
+		Pending: make(map[common.Address]map[uint64]*types.Transaction, len(pending)),
+		Queued:  make(map[common.Address]map[uint64]*types.Transaction, len(queued)),
+	}
+	for from, txs := range pending {
+		if resp.Pending[from], err = rpcPoolTxs(txs); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the txs response", err, true)
+		}
+	}
+	for from, txs := range queued {
+		if resp.Queued[from], err = rpcPoolTxs(txs); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the txs response", err, true)
+		}
 	}
+	return resp, nil
+}
 
+// ContentFrom creates a response for txpool_contentFrom request, returning the pending and queued txs of the sender
+// by nonce.
+// See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-txpool#txpool-contentfrom.
+func (e *TxPoolEndpoints) ContentFrom(address types.ArgAddress) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("txpool_contentFrom", address.Address().String())
+	}
+
+	ctx := context.Background()
+	txs, err := e.pool.GetTxsByFromAndStatus(ctx, address.Address(), pool.TxStatusPending)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load the txs of the sender", err, true)
+	}
+	lastL2Block, err := e.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load the last l2 block", err, true)
+	}
+	pending, queued, err := e.senderContent(ctx, lastL2Block.Root(), address.Address(), txs)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load the txs of the sender", err, true)
+	}
+	if rpcErr := e.checkContentSize(len(pending) + len(queued)); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	resp := contentFromResponse{}
+	if resp.Pending, err = rpcPoolTxs(pending); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to build the txs response", err, true)
+	}
+	if resp.Queued, err = rpcPoolTxs(queued); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to build the txs response", err, true)
+	}
+	return resp, nil
+}
+
+// checkContentSize returns an error if the number of txs exceeds the max size of the txpool content responses
+func (e *TxPoolEndpoints) checkContentSize(size int) types.Error {
+	if e.cfg.MaxTxPoolContentSize > 0 && uint64(size) > e.cfg.MaxTxPoolContentSize {
+		return types.NewRPCError(types.LimitExceededCode, "txpool content exceeds the max of %d txs", e.cfg.MaxTxPoolContentSize)
+	}
+	return nil
+}
+
+// rpcPoolTxs returns the pool txs by nonce in the jsonrpc format
+func rpcPoolTxs(txs map[uint64]pool.Transaction) (map[uint64]*types.Transaction, error) {
+	res := make(map[uint64]*types.Transaction, len(txs))
+	for nonce, tx := range txs {
+		rpcTx, err := types.NewTransaction(tx.Transaction, nil, false, nil)
+		if err != nil {
+			return nil, err
+		}
+		res[nonce] = rpcTx
+	}
+	return res, nil
+}
+
+// Status creates a response for txpool_status request, counting the pending txs that are ready to be added to a
+// batch as pending and the rest as queued, like txpool_content splits them. The max txpool content size doesn't
+// apply, as only the counts are returned.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_status.
+func (e *TxPoolEndpoints) Status() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("txpool_status")
+	}
+
+	ctx := context.Background()
+	txs, err := e.pool.GetPendingTxs(ctx, 0)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load the txs of the pool", err, true)
+	}
+	pending, queued, rpcErr := e.splitPoolTxs(ctx, txs)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	resp := statusResponse{}
+	for _, txs := range pending {
+		resp.Pending += types.ArgUint64(len(txs))
+	}
+	for _, txs := range queued {
+		resp.Queued += types.ArgUint64(len(txs))
+	}
 	return resp, nil
 }
+
+// Inspect creates a response for txpool_inspect request, summarizing the pending and queued txs of each sender.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
+func (e *TxPoolEndpoints) Inspect() (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayToSequencerNode("txpool_inspect")
+	}
+
+	pending, queued, rpcErr := e.poolContent(context.Background())
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	summarize := func(content map[common.Address]map[uint64]pool.Transaction) map[common.Address]map[uint64]string {
+		summaries := make(map[common.Address]map[uint64]string, len(content))
+		for from, txs := range content {
+			summaries[from] = make(map[uint64]string, len(txs))
+			for nonce, tx := range txs {
+				to := "contract creation"
+				if tx.To() != nil {
+					to = tx.To().Hex()
+				}
+				summaries[from][nonce] = fmt.Sprintf("%s: %d wei + %d gas × %d wei", to, tx.Value(), tx.Gas(), tx.GasPrice())
+			}
+		}
+		return summaries
+	}
+	return inspectResponse{Pending: summarize(pending), Queued: summarize(queued)}, nil
+}
+
+// poolContent returns the pending txs of the pool of each sender by nonce, split in the ones that are ready to be
+// added to a batch and the ones that are queued. The txs that can't be ever added to a batch are left out. The max
+// txpool content size is applied to the pending txs loaded, so the pool isn't loaded whole when it's exceeded
+func (e *TxPoolEndpoints) poolContent(ctx context.Context) (pending, queued map[common.Address]map[uint64]pool.Transaction, rpcErr types.Error) {
+	limit := uint64(0)
+	if e.cfg.MaxTxPoolContentSize > 0 {
+		limit = e.cfg.MaxTxPoolContentSize + 1
+	}
+	txs, err := e.pool.GetPendingTxs(ctx, limit)
+	if err != nil {
+		_, rpcErr = RPCErrorResponse(types.DefaultErrorCode, "failed to load the txs of the pool", err, true)
+		return nil, nil, rpcErr
+	}
+	if rpcErr = e.checkContentSize(len(txs)); rpcErr != nil {
+		return nil, nil, rpcErr
+	}
+	return e.splitPoolTxs(ctx, txs)
+}
+
+// splitPoolTxs groups the pending txs of the pool by sender and nonce, split in the ones that are ready to be added to
+// a batch and the ones that are queued. All the senders are checked against the state of the last L2 block
+func (e *TxPoolEndpoints) splitPoolTxs(ctx context.Context, txs []pool.Transaction) (pending, queued map[common.Address]map[uint64]pool.Transaction, rpcErr types.Error) {
+	txsBySender := make(map[common.Address][]pool.Transaction)
+	for _, tx := range txs {
+		from, err := state.GetSender(tx.Transaction)
+		if err != nil {
+			_, rpcErr = RPCErrorResponse(types.DefaultErrorCode, "failed to load the txs of the pool", err, true)
+			return nil, nil, rpcErr
+		}
+		txsBySender[from] = append(txsBySender[from], tx)
+	}
+
+	pending = make(map[common.Address]map[uint64]pool.Transaction)
+	queued = make(map[common.Address]map[uint64]pool.Transaction)
+	if len(txsBySender) == 0 {
+		return pending, queued, nil
+	}
+	lastL2Block, err := e.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		_, rpcErr = RPCErrorResponse(types.DefaultErrorCode, "failed to load the last l2 block", err, true)
+		return nil, nil, rpcErr
+	}
+	for from, txs := range txsBySender {
+		senderPending, senderQueued, err := e.senderContent(ctx, lastL2Block.Root(), from, txs)
+		if err != nil {
+			_, rpcErr = RPCErrorResponse(types.DefaultErrorCode, "failed to load the txs of the pool", err, true)
+			return nil, nil, rpcErr
+		}
+		if len(senderPending) > 0 {
+			pending[from] = senderPending
+		}
+		if len(senderQueued) > 0 {
+			queued[from] = senderQueued
+		}
+	}
+	return pending, queued, nil
+}
+
+// senderContent returns the pending txs of the sender by nonce, split in the ones that are ready to be added to a
+// batch and the ones that are queued with the state at root. The txs that can't be ever added to a batch are left out
+func (e *TxPoolEndpoints) senderContent(ctx context.Context, root common.Hash, from common.Address, txs []pool.Transaction) (pending, queued map[uint64]pool.Transaction, err error) {
+	readiness, err := senderTxsReadiness(ctx, e.state, root, from, txs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending = make(map[uint64]pool.Transaction)
+	queued = make(map[uint64]pool.Transaction)
+	for _, r := range readiness {
+		switch r.reason {
+		case txReady:
+			pending[r.tx.Nonce()] = r.tx
+		case txNonceTooLow, txReplaced:
+			continue
+		default:
+			queued[r.tx.Nonce()] = r.tx
+		}
+	}
+	return pending, queued, nil
+}
+
+func (e *TxPoolEndpoints) relayToSequencerNode(method string, parameters ...interface{}) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, method, parameters...)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay the request to the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return json.RawMessage(res.Result), nil
+}
+
+// txReadiness is the reason why a pending tx is ready or not to be added to a batch
+type txReadiness struct {
+	tx     pool.Transaction
+	reason string
+}
+
+// senderTxsReadiness returns the readiness of the pending txs of the sender, checking them against its nonce and
+// balance at the state root like the sequencer does
+func senderTxsReadiness(ctx context.Context, st types.StateInterface, root common.Hash, from common.Address, txs []pool.Transaction) ([]txReadiness, error) {
+	nonce, err := st.GetNonce(ctx, from, root)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := st.GetBalance(ctx, from, root)
+	if err != nil {
+		return nil, err
+	}
+	return txsReadiness(txs, nonce, balance), nil
+}
+
+// txsReadiness returns the readiness of the pending txs of a sender with the nonce and the balance. The txs with
+// consecutive nonces from the nonce of the sender are ready while the sender can pay for all of them
+func txsReadiness(txs []pool.Transaction, nonce uint64, balance *big.Int) []txReadiness {
+	// the tx of each nonce with the highest gas price replaces the rest
+	byNonce := make(map[uint64]pool.Transaction, len(txs))
+	readiness := make([]txReadiness, 0, len(txs))
+	for _, tx := range txs {
+		if tx.Nonce() < nonce {
+			readiness = append(readiness, txReadiness{tx: tx, reason: txNonceTooLow})
+			continue
+		}
+		if current, found := byNonce[tx.Nonce()]; found {
+			if current.GasPrice().Cmp(tx.GasPrice()) >= 0 {
+				readiness = append(readiness, txReadiness{tx: tx, reason: txReplaced})
+				continue
+			}
+			readiness = append(readiness, txReadiness{tx: current, reason: txReplaced})
+		}
+		byNonce[tx.Nonce()] = tx
+	}
+
+	cost := new(big.Int)
+	reason := txReady
+	for next := nonce; len(byNonce) > 0; next++ {
+		tx, found := byNonce[next]
+		if !found {
+			// the rest of the txs are waiting for the missing nonce
+			for _, tx := range byNonce {
+				readiness = append(readiness, txReadiness{tx: tx, reason: txNonceGap})
+			}
+			break
+		}
+		delete(byNonce, next)
+
+		cost.Add(cost, tx.Cost())
+		if reason == txReady && cost.Cmp(balance) > 0 {
+			reason = txInsufficientBalance
+		}
+		readiness = append(readiness, txReadiness{tx: tx, reason: reason})
+	}
+	return readiness
+}
@@ -0,0 +1,175 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSignedPoolTx returns a pending pool tx with the nonce and gas price signed by the auth
+func newSignedPoolTx(t *testing.T, auth *bind.TransactOpts, nonce uint64, gasPrice int64) pool.Transaction {
+	tx := ethTypes.NewTransaction(nonce, common.HexToAddress("0x111"), big.NewInt(10), 21000, big.NewInt(gasPrice), nil)
+	signedTx, err := auth.Signer(auth.From, tx)
+	require.NoError(t, err)
+	return *pool.NewTransaction(*signedTx, "", false)
+}
+
+func newTestAuth(t *testing.T) *bind.TransactOpts {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
+	require.NoError(t, err)
+	return auth
+}
+
+func TestTxsReadiness(t *testing.T) {
+	auth := newTestAuth(t)
+	used := newSignedPoolTx(t, auth, 1, 1)
+	ready := newSignedPoolTx(t, auth, 2, 1)
+	replaced := newSignedPoolTx(t, auth, 3, 1)
+	replacement := newSignedPoolTx(t, auth, 3, 2)
+	unpaid := newSignedPoolTx(t, auth, 4, 1)
+	gap := newSignedPoolTx(t, auth, 6, 1)
+
+	txs := []pool.Transaction{used, ready, replaced, replacement, unpaid, gap}
+	// enough balance for the first two ready txs
+	balance := new(big.Int).Add(ready.Cost(), replacement.Cost())
+
+	reasons := make(map[common.Hash]string)
+	for _, r := range txsReadiness(txs, 2, balance) {
+		reasons[r.tx.Hash()] = r.reason
+	}
+	assert.Equal(t, map[common.Hash]string{
+		used.Hash():        txNonceTooLow,
+		ready.Hash():       txReady,
+		replaced.Hash():    txReplaced,
+		replacement.Hash(): txReady,
+		unpaid.Hash():      txInsufficientBalance,
+		gap.Hash():         txNonceGap,
+	}, reasons)
+}
+
+func TestTxPoolStatusAndInspect(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	auth := newTestAuth(t)
+	ready := newSignedPoolTx(t, auth, 0, 1)
+	queued := newSignedPoolTx(t, auth, 2, 1)
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1), Root: blockRoot}))
+
+	setupMocks := func() {
+		m.Pool.On("GetPendingTxs", context.Background(), uint64(0)).Return([]pool.Transaction{ready, queued}, nil).Once()
+		m.State.On("GetLastL2Block", context.Background(), nil).Return(block, nil).Once()
+		m.State.On("GetNonce", context.Background(), auth.From, blockRoot).Return(uint64(0), nil).Once()
+		m.State.On("GetBalance", context.Background(), auth.From, blockRoot).Return(big.NewInt(1000000), nil).Once()
+	}
+
+	// the status splits the txs like the content, checking all the senders against the last L2 block
+	otherAuth := newTestAuth(t)
+	unpaid := newSignedPoolTx(t, otherAuth, 0, 1)
+	m.Pool.On("GetPendingTxs", context.Background(), uint64(0)).Return([]pool.Transaction{ready, queued, unpaid}, nil).Once()
+	m.State.On("GetLastL2Block", context.Background(), nil).Return(block, nil).Once()
+	m.State.On("GetNonce", context.Background(), auth.From, blockRoot).Return(uint64(0), nil).Once()
+	m.State.On("GetBalance", context.Background(), auth.From, blockRoot).Return(big.NewInt(1000000), nil).Once()
+	m.State.On("GetNonce", context.Background(), otherAuth.From, blockRoot).Return(uint64(0), nil).Once()
+	m.State.On("GetBalance", context.Background(), otherAuth.From, blockRoot).Return(big.NewInt(0), nil).Once()
+	res, err := s.JSONRPCCall("txpool_status")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	var status statusResponse
+	require.NoError(t, json.Unmarshal(res.Result, &status))
+	assert.Equal(t, statusResponse{Pending: 1, Queued: 2}, status)
+
+	setupMocks()
+	res, err = s.JSONRPCCall("txpool_inspect")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	var inspect inspectResponse
+	require.NoError(t, json.Unmarshal(res.Result, &inspect))
+	assert.Equal(t, inspectResponse{
+		Pending: map[common.Address]map[uint64]string{auth.From: {0: "0x0000000000000000000000000000000000000111: 10 wei + 21000 gas × 1 wei"}},
+		Queued:  map[common.Address]map[uint64]string{auth.From: {2: "0x0000000000000000000000000000000000000111: 10 wei + 21000 gas × 1 wei"}},
+	}, inspect)
+}
+
+func TestTxPoolContent(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	auth := newTestAuth(t)
+	ready := newSignedPoolTx(t, auth, 0, 1)
+	queued := newSignedPoolTx(t, auth, 2, 1)
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1), Root: blockRoot}))
+
+	setupStateMocks := func() {
+		m.State.On("GetLastL2Block", context.Background(), nil).Return(block, nil).Once()
+		m.State.On("GetNonce", context.Background(), auth.From, blockRoot).Return(uint64(0), nil).Once()
+		m.State.On("GetBalance", context.Background(), auth.From, blockRoot).Return(big.NewInt(1000000), nil).Once()
+	}
+
+	m.Pool.On("GetPendingTxs", context.Background(), uint64(0)).Return([]pool.Transaction{ready, queued}, nil).Once()
+	setupStateMocks()
+	res, err := s.JSONRPCCall("txpool_content")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	var content contentResponse
+	require.NoError(t, json.Unmarshal(res.Result, &content))
+	require.Contains(t, content.Pending, auth.From)
+	require.Contains(t, content.Queued, auth.From)
+	assert.Equal(t, ready.Hash(), content.Pending[auth.From][0].Hash)
+	assert.Equal(t, auth.From, content.Pending[auth.From][0].From)
+	assert.Nil(t, content.Pending[auth.From][0].BlockHash)
+	assert.Equal(t, queued.Hash(), content.Queued[auth.From][2].Hash)
+
+	m.Pool.On("GetTxsByFromAndStatus", context.Background(), auth.From, pool.TxStatusPending).Return([]pool.Transaction{ready, queued}, nil).Once()
+	setupStateMocks()
+	res, err = s.JSONRPCCall("txpool_contentFrom", auth.From.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	var contentFrom contentFromResponse
+	require.NoError(t, json.Unmarshal(res.Result, &contentFrom))
+	require.Len(t, contentFrom.Pending, 1)
+	require.Len(t, contentFrom.Queued, 1)
+	assert.Equal(t, ready.Hash(), contentFrom.Pending[0].Hash)
+	assert.Equal(t, queued.Hash(), contentFrom.Queued[2].Hash)
+}
+
+func TestTxPoolContentLimit(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.MaxTxPoolContentSize = 1
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	// one tx more than the max is loaded to know it's exceeded, without checking the readiness of the txs
+	auth := newTestAuth(t)
+	for _, method := range []string{"txpool_content", "txpool_inspect"} {
+		m.Pool.On("GetPendingTxs", context.Background(), uint64(2)).Return([]pool.Transaction{newSignedPoolTx(t, auth, 0, 1), newSignedPoolTx(t, auth, 1, 1)}, nil).Once()
+		res, err := s.JSONRPCCall(method)
+		require.NoError(t, err)
+		require.NotNil(t, res.Error)
+		assert.Equal(t, types.LimitExceededCode, res.Error.Code, method)
+	}
+}
+
+func TestTxPoolContentSize(t *testing.T) {
+	e := NewTxPoolEndpoints(Config{MaxTxPoolContentSize: 2}, nil, nil)
+	assert.Nil(t, e.checkContentSize(2))
+	rpcErr := e.checkContentSize(3)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, types.LimitExceededCode, rpcErr.ErrorCode())
+
+	e = NewTxPoolEndpoints(Config{}, nil, nil)
+	assert.Nil(t, e.checkContentSize(1000000))
+}
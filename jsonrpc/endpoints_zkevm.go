@@ -447,6 +447,89 @@ func (z *ZKEVMEndpoints) getTransactionByL2HashFromSequencerNode(hash common.Has
 	return tx, nil
 }
 
+// GetTxPoolStatus returns the status of a tx in the pool, telling why a pending tx isn't ready to be added to a batch
+// and the position of a ready one in the queue of the sequencer
+func (z *ZKEVMEndpoints) GetTxPoolStatus(hash types.ArgHash) (interface{}, types.Error) {
+	if z.cfg.SequencerNodeURI != "" {
+		return z.getTxPoolStatusFromSequencerNode(hash.Hash())
+	}
+
+	ctx := context.Background()
+	poolTx, err := z.pool.GetTransactionByHash(ctx, hash.Hash())
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction from pool", err, true)
+	}
+
+	from, err := state.GetSender(poolTx.Transaction)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get the sender of the transaction", err, true)
+	}
+
+	res := types.TxPoolStatus{
+		Hash:         poolTx.Hash(),
+		From:         from,
+		Nonce:        types.ArgUint64(poolTx.Nonce()),
+		Status:       poolTx.Status.String(),
+		FailedReason: poolTx.FailedReason,
+		IsWIP:        poolTx.IsWIP,
+		ReceivedAt:   types.ArgUint64(poolTx.ReceivedAt.Unix()),
+	}
+	if poolTx.Status != pool.TxStatusPending {
+		return res, nil
+	}
+
+	senderTxs, err := z.pool.GetTxsByFromAndStatus(ctx, from, pool.TxStatusPending)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load the pending transactions of the sender from pool", err, true)
+	}
+	lastL2Block, err := z.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load the last l2 block", err, true)
+	}
+	readiness, err := senderTxsReadiness(ctx, z.state, lastL2Block.Root(), from, senderTxs)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to check the readiness of the transaction", err, true)
+	}
+	for _, r := range readiness {
+		if r.tx.Hash() != poolTx.Hash() {
+			continue
+		}
+		ready := r.reason == txReady
+		res.Ready = &ready
+		res.ReadinessReason = r.reason
+	}
+
+	if res.Ready != nil && *res.Ready {
+		position, err := z.pool.GetTxQueuePosition(ctx, poolTx.Hash())
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the queue position of the transaction", err, true)
+		}
+		res.QueuePosition = types.ArgUint64Ptr(types.ArgUint64(position))
+	}
+
+	return res, nil
+}
+
+func (z *ZKEVMEndpoints) getTxPoolStatusFromSequencerNode(hash common.Hash) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_getTxPoolStatus", hash.String())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx pool status from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var status *types.TxPoolStatus
+	err = json.Unmarshal(res.Result, &status)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read tx pool status from sequencer node", err, true)
+	}
+	return status, nil
+}
+
 // GetExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
 func (z *ZKEVMEndpoints) GetExitRootsByGER(globalExitRoot common.Hash) (interface{}, types.Error) {
 	ctx := context.Background()
@@ -2204,3 +2204,94 @@ func TestClient_BatchesByNumbers(t *testing.T) {
 		require.Equal(t, hex.EncodeToHex(batchesDataMap[batchNum.Uint64()]), result[i].BatchL2Data.Hex())
 	}
 }
+
+func TestGetTxPoolStatus(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	auth := newTestAuth(t)
+	ready := newSignedPoolTx(t, auth, 0, 1)
+	queued := newSignedPoolTx(t, auth, 2, 1)
+	failed := newSignedPoolTx(t, auth, 0, 1)
+	failed.Status = pool.TxStatusFailed
+	failedReason := "insufficient funds"
+	failed.FailedReason = &failedReason
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1), Root: blockRoot}))
+
+	setupStateMocks := func() {
+		m.Pool.On("GetTxsByFromAndStatus", context.Background(), auth.From, pool.TxStatusPending).Return([]pool.Transaction{ready, queued}, nil).Once()
+		m.State.On("GetLastL2Block", context.Background(), nil).Return(block, nil).Once()
+		m.State.On("GetNonce", context.Background(), auth.From, blockRoot).Return(uint64(0), nil).Once()
+		m.State.On("GetBalance", context.Background(), auth.From, blockRoot).Return(big.NewInt(1000000), nil).Once()
+	}
+
+	testCases := []struct {
+		Name           string
+		Tx             pool.Transaction
+		ExpectedResult *types.TxPoolStatus
+		SetupMocks     func()
+	}{
+		{
+			Name: "ready tx",
+			Tx:   ready,
+			ExpectedResult: &types.TxPoolStatus{
+				Hash: ready.Hash(), From: auth.From, Nonce: 0, Status: "pending", ReceivedAt: types.ArgUint64(ready.ReceivedAt.Unix()),
+				Ready: state.Ptr(true), ReadinessReason: txReady, QueuePosition: types.ArgUint64Ptr(3),
+			},
+			SetupMocks: func() {
+				m.Pool.On("GetTransactionByHash", context.Background(), ready.Hash()).Return(&ready, nil).Once()
+				setupStateMocks()
+				m.Pool.On("GetTxQueuePosition", context.Background(), ready.Hash()).Return(uint64(3), nil).Once()
+			},
+		},
+		{
+			Name: "queued tx",
+			Tx:   queued,
+			ExpectedResult: &types.TxPoolStatus{
+				Hash: queued.Hash(), From: auth.From, Nonce: 2, Status: "pending", ReceivedAt: types.ArgUint64(queued.ReceivedAt.Unix()),
+				Ready: state.Ptr(false), ReadinessReason: txNonceGap,
+			},
+			SetupMocks: func() {
+				m.Pool.On("GetTransactionByHash", context.Background(), queued.Hash()).Return(&queued, nil).Once()
+				setupStateMocks()
+			},
+		},
+		{
+			Name: "failed tx",
+			Tx:   failed,
+			ExpectedResult: &types.TxPoolStatus{
+				Hash: failed.Hash(), From: auth.From, Nonce: 0, Status: "failed", FailedReason: &failedReason, ReceivedAt: types.ArgUint64(failed.ReceivedAt.Unix()),
+			},
+			SetupMocks: func() {
+				m.Pool.On("GetTransactionByHash", context.Background(), failed.Hash()).Return(&failed, nil).Once()
+			},
+		},
+		{
+			Name:           "tx not found",
+			Tx:             newSignedPoolTx(t, auth, 5, 1),
+			ExpectedResult: nil,
+			SetupMocks: func() {
+				m.Pool.On("GetTransactionByHash", context.Background(), common.Hash{}).Return(nil, pool.ErrNotFound).Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks()
+
+			hash := tc.Tx.Hash()
+			if tc.ExpectedResult == nil {
+				hash = common.Hash{}
+			}
+			res, err := s.JSONRPCCall("zkevm_getTxPoolStatus", hash.String())
+			require.NoError(t, err)
+			require.Nil(t, res.Error)
+
+			var result *types.TxPoolStatus
+			require.NoError(t, json.Unmarshal(res.Result, &result))
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
@@ -4,10 +4,12 @@ package jsonrpc
 type storageInterface interface {
 	GetAllBlockFiltersWithWSConn() []*Filter
 	GetAllLogFiltersWithWSConn() []*Filter
+	GetAllPendingReceiptFiltersWithWSConn() []*Filter
 	GetFilter(filterID string) (*Filter, error)
 	NewBlockFilter(wsConn *concurrentWsConn) (string, error)
 	NewLogFilter(wsConn *concurrentWsConn, filter LogFilter) (string, error)
 	NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error)
+	NewPendingReceiptFilter(wsConn *concurrentWsConn) (string, error)
 	UninstallFilter(filterID string) error
 	UninstallFilterByWSConn(wsConn *concurrentWsConn) error
 	UpdateFilterLastPoll(filterID string) error
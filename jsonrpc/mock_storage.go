@@ -49,6 +49,26 @@ func (_m *storageMock) GetAllLogFiltersWithWSConn() []*Filter {
 	return r0
 }
 
+// GetAllPendingReceiptFiltersWithWSConn provides a mock function with given fields:
+func (_m *storageMock) GetAllPendingReceiptFiltersWithWSConn() []*Filter {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPendingReceiptFiltersWithWSConn")
+	}
+
+	var r0 []*Filter
+	if rf, ok := ret.Get(0).(func() []*Filter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Filter)
+		}
+	}
+
+	return r0
+}
+
 // GetFilter provides a mock function with given fields: filterID
 func (_m *storageMock) GetFilter(filterID string) (*Filter, error) {
 	ret := _m.Called(filterID)
@@ -135,6 +155,34 @@ func (_m *storageMock) NewLogFilter(wsConn *concurrentWsConn, filter LogFilter)
 	return r0, r1
 }
 
+// NewPendingReceiptFilter provides a mock function with given fields: wsConn
+func (_m *storageMock) NewPendingReceiptFilter(wsConn *concurrentWsConn) (string, error) {
+	ret := _m.Called(wsConn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewPendingReceiptFilter")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn) (string, error)); ok {
+		return rf(wsConn)
+	}
+	if rf, ok := ret.Get(0).(func(*concurrentWsConn) string); ok {
+		r0 = rf(wsConn)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*concurrentWsConn) error); ok {
+		r1 = rf(wsConn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPendingTransactionFilter provides a mock function with given fields: wsConn
 func (_m *storageMock) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string, error) {
 	ret := _m.Called(wsConn)
@@ -70,34 +70,6 @@ func (_m *PoolMock) CalculateEffectiveGasPrice(rawTx []byte, txGasPrice *big.Int
 	return r0, r1
 }
 
-// CalculateEffectiveGasPricePercentage provides a mock function with given fields: gasPrice, effectiveGasPrice
-func (_m *PoolMock) CalculateEffectiveGasPricePercentage(gasPrice *big.Int, effectiveGasPrice *big.Int) (uint8, error) {
-	ret := _m.Called(gasPrice, effectiveGasPrice)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CalculateEffectiveGasPricePercentage")
-	}
-
-	var r0 uint8
-	var r1 error
-	if rf, ok := ret.Get(0).(func(*big.Int, *big.Int) (uint8, error)); ok {
-		return rf(gasPrice, effectiveGasPrice)
-	}
-	if rf, ok := ret.Get(0).(func(*big.Int, *big.Int) uint8); ok {
-		r0 = rf(gasPrice, effectiveGasPrice)
-	} else {
-		r0 = ret.Get(0).(uint8)
-	}
-
-	if rf, ok := ret.Get(1).(func(*big.Int, *big.Int) error); ok {
-		r1 = rf(gasPrice, effectiveGasPrice)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CheckPolicy provides a mock function with given fields: ctx, policy, address
 func (_m *PoolMock) CheckPolicy(ctx context.Context, policy pool.PolicyName, address common.Address) (bool, error) {
 	ret := _m.Called(ctx, policy, address)
@@ -228,6 +200,66 @@ func (_m *PoolMock) GetNonce(ctx context.Context, address common.Address) (uint6
 	return r0, r1
 }
 
+// GetPendingReceipt provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) GetPendingReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReceipt")
+	}
+
+	var r0 *types.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.Receipt, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.Receipt); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingReceiptsFrom provides a mock function with given fields: ctx, fromID
+func (_m *PoolMock) GetPendingReceiptsFrom(ctx context.Context, fromID uint64) ([]pool.PendingReceipt, error) {
+	ret := _m.Called(ctx, fromID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReceiptsFrom")
+	}
+
+	var r0 []pool.PendingReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]pool.PendingReceipt, error)); ok {
+		return rf(ctx, fromID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []pool.PendingReceipt); ok {
+		r0 = rf(ctx, fromID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.PendingReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, fromID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPendingTxHashesSince provides a mock function with given fields: ctx, since
 func (_m *PoolMock) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
 	ret := _m.Called(ctx, since)
@@ -348,6 +380,71 @@ func (_m *PoolMock) GetTransactionByL2Hash(ctx context.Context, hash common.Hash
 	return r0, r1
 }
 
+// GetTxQueuePosition provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) GetTxQueuePosition(ctx context.Context, hash common.Hash) (uint64, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxQueuePosition")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (uint64, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) uint64); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxsByFromAndStatus provides a mock function with given fields: ctx, from, status
+func (_m *PoolMock) GetTxsByFromAndStatus(ctx context.Context, from common.Address, status ...pool.TxStatus) ([]pool.Transaction, error) {
+	_va := make([]interface{}, len(status))
+	for _i := range status {
+		_va[_i] = status[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, from)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTxsByFromAndStatus")
+	}
+
+	var r0 []pool.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, ...pool.TxStatus) ([]pool.Transaction, error)); ok {
+		return rf(ctx, from, status...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, ...pool.TxStatus) []pool.Transaction); ok {
+		r0 = rf(ctx, from, status...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, ...pool.TxStatus) error); ok {
+		r1 = rf(ctx, from, status...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPoolMock creates a new instance of PoolMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPoolMock(t interface {
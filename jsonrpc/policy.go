@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"context"
+	"errors"
 
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
@@ -59,3 +60,18 @@ func resolvePolicy(tx *ethTypes.Transaction) pool.PolicyName {
 	}
 	return pool.SendTx
 }
+
+// poolErrorCode returns the error code of an error adding a tx to the pool, so the txs rejected by policy can be told apart
+func poolErrorCode(err error) int {
+	switch {
+	case errors.Is(err, pool.ErrContractDisallowedCall), errors.Is(err, pool.ErrSelectorDisallowed), errors.Is(err, pool.ErrUnknownAPIKey):
+		return types.AccessDeniedCode
+	case errors.Is(err, pool.ErrGasExceedsPolicyLimit), errors.Is(err, pool.ErrValueExceedsPolicyLimit),
+		errors.Is(err, pool.ErrSponsorBudgetExceeded):
+		return types.LimitExceededCode
+	case errors.Is(err, pool.ErrQuotaExceeded):
+		return types.QuotaExceededCode
+	default:
+		return types.DefaultErrorCode
+	}
+}
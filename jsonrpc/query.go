@@ -23,6 +23,8 @@ const (
 	FilterTypeBlock = "block"
 	// FilterTypePendingTx represent a filter of type pending Tx.
 	FilterTypePendingTx = "pendingTx"
+	// FilterTypePendingReceipt represents a filter of type pending receipt.
+	FilterTypePendingReceipt = "pendingReceipt"
 )
 
 // Filter represents a filter.
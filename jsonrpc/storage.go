@@ -25,6 +25,8 @@ type Storage struct {
 	blockFiltersWithWSConn     map[string]*Filter
 	logFiltersWithWSConn       map[string]*Filter
 	pendingTxFiltersWithWSConn map[string]*Filter
+	// pendingReceiptFiltersWithWSConn is guarded by the pendingTxMutex
+	pendingReceiptFiltersWithWSConn map[string]*Filter
 
 	blockMutex     *sync.Mutex
 	logMutex       *sync.Mutex
@@ -34,14 +36,15 @@ type Storage struct {
 // NewStorage creates and initializes an instance of Storage
 func NewStorage() *Storage {
 	return &Storage{
-		allFilters:                 make(map[string]*Filter),
-		allFiltersWithWSConn:       make(map[*concurrentWsConn]map[string]*Filter),
-		blockFiltersWithWSConn:     make(map[string]*Filter),
-		logFiltersWithWSConn:       make(map[string]*Filter),
-		pendingTxFiltersWithWSConn: make(map[string]*Filter),
-		blockMutex:                 &sync.Mutex{},
-		logMutex:                   &sync.Mutex{},
-		pendingTxMutex:             &sync.Mutex{},
+		allFilters:                      make(map[string]*Filter),
+		allFiltersWithWSConn:            make(map[*concurrentWsConn]map[string]*Filter),
+		blockFiltersWithWSConn:          make(map[string]*Filter),
+		logFiltersWithWSConn:            make(map[string]*Filter),
+		pendingTxFiltersWithWSConn:      make(map[string]*Filter),
+		pendingReceiptFiltersWithWSConn: make(map[string]*Filter),
+		blockMutex:                      &sync.Mutex{},
+		logMutex:                        &sync.Mutex{},
+		pendingTxMutex:                  &sync.Mutex{},
 	}
 }
 
@@ -64,6 +67,11 @@ func (s *Storage) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string,
 	return s.createFilter(FilterTypePendingTx, nil, wsConn)
 }
 
+// NewPendingReceiptFilter persists a new pending receipt filter
+func (s *Storage) NewPendingReceiptFilter(wsConn *concurrentWsConn) (string, error) {
+	return s.createFilter(FilterTypePendingReceipt, nil, wsConn)
+}
+
 // create persists the filter to the memory and provides the filter id
 func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *concurrentWsConn) (string, error) {
 	lastPoll := time.Now().UTC()
@@ -104,6 +112,8 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 			s.logFiltersWithWSConn[id] = f
 		} else if t == FilterTypePendingTx {
 			s.pendingTxFiltersWithWSConn[id] = f
+		} else if t == FilterTypePendingReceipt {
+			s.pendingReceiptFiltersWithWSConn[id] = f
 		}
 	}
 	return id, nil
@@ -152,6 +162,20 @@ func (s *Storage) GetAllLogFiltersWithWSConn() []*Filter {
 	return filters
 }
 
+// GetAllPendingReceiptFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by new pending receipts
+func (s *Storage) GetAllPendingReceiptFiltersWithWSConn() []*Filter {
+	s.pendingTxMutex.Lock()
+	defer s.pendingTxMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.pendingReceiptFiltersWithWSConn {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
+
 // GetFilter gets a filter by its id
 func (s *Storage) GetFilter(filterID string) (*Filter, error) {
 	s.blockMutex.Lock()
@@ -234,6 +258,8 @@ func (s *Storage) deleteFilter(filter *Filter) {
 		delete(s.logFiltersWithWSConn, filter.ID)
 	} else if filter.Type == FilterTypePendingTx {
 		delete(s.pendingTxFiltersWithWSConn, filter.ID)
+	} else if filter.Type == FilterTypePendingReceipt {
+		delete(s.pendingReceiptFiltersWithWSConn, filter.ID)
 	}
 
 	if filter.WsConn != nil {
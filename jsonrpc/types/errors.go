@@ -17,6 +17,10 @@ const (
 	ParserErrorCode = -32700
 	// AccessDeniedCode error code when requests are denied
 	AccessDeniedCode = -32800
+	// LimitExceededCode error code when requests exceed a limit
+	LimitExceededCode = -32005
+	// QuotaExceededCode error code when the quota of txs or gas of the sender, IP or API key is exhausted
+	QuotaExceededCode = -32006
 )
 
 var (
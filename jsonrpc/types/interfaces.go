@@ -26,6 +26,10 @@ type PoolInterface interface {
 	CheckPolicy(ctx context.Context, policy pool.PolicyName, address common.Address) (bool, error)
 	CalculateEffectiveGasPrice(rawTx []byte, txGasPrice *big.Int, txGasUsed uint64, l1GasPrice uint64, l2GasPrice uint64) (*big.Int, error)
 	EffectiveGasPriceEnabled() bool
+	GetTxsByFromAndStatus(ctx context.Context, from common.Address, status ...pool.TxStatus) ([]pool.Transaction, error)
+	GetTxQueuePosition(ctx context.Context, hash common.Hash) (uint64, error)
+	GetPendingReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	GetPendingReceiptsFrom(ctx context.Context, fromID uint64) ([]pool.PendingReceipt, error)
 }
 
 // StateInterface gathers the methods required to interact with the state.
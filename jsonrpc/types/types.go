@@ -790,3 +790,21 @@ func NewZKCountersResponse(zkCounters state.ZKCounters, limits ZKCountersLimits,
 		OOCError:       oocErrMsg,
 	}
 }
+
+// TxPoolStatus is the status of a tx in the pool
+type TxPoolStatus struct {
+	Hash         common.Hash    `json:"hash"`
+	From         common.Address `json:"from"`
+	Nonce        ArgUint64      `json:"nonce"`
+	Status       string         `json:"status"`
+	FailedReason *string        `json:"failedReason"`
+	IsWIP        bool           `json:"isWIP"`
+	ReceivedAt   ArgUint64      `json:"receivedAt"`
+	// Ready and ReadinessReason tell if a pending tx can be added to a batch and why
+	Ready           *bool  `json:"ready,omitempty"`
+	ReadinessReason string `json:"readinessReason,omitempty"`
+	// QueuePosition is the number of pending txs ahead of a ready tx when they are sorted by gas price. It's
+	// approximate, since the sequencer sorts the txs by the configured tx ordering and reserves the resources of the
+	// priority lanes to their txs, and the txs ahead may not be ready
+	QueuePosition *ArgUint64 `json:"queuePosition,omitempty"`
+}
@@ -3,6 +3,7 @@ package pool
 import (
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // Config is the pool configuration
@@ -47,6 +48,85 @@ type Config struct {
 	// TxFeeCap is the global transaction fee(price * gaslimit) cap for
 	// send-transaction variants. The unit is ether. 0 means no cap.
 	TxFeeCap float64 `mapstructure:"TxFeeCap"`
+
+	// Quotas is the config of the quotas of txs and gas that senders, IPs and API keys can add to the pool over time
+	Quotas QuotaCfg `mapstructure:"Quotas"`
+
+	// Sponsorship is the config of the sponsors paying the fee of the zero gas price txs sent to their target contracts
+	Sponsorship SponsorshipCfg `mapstructure:"Sponsorship"`
+}
+
+// SponsorshipCfg contains the configuration of the gasless txs. The txs with zero gas price sent to the target of a
+// sponsor are accepted while the sponsor has budget left, being their fee paid by the sequencer on its behalf
+type SponsorshipCfg struct {
+	// Enabled is a flag to enable/disable the sponsored txs
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Sponsors are the sponsors of the gasless txs, a target of several sponsors is paid by the first one
+	Sponsors []SponsorCfg `mapstructure:"Sponsors"`
+}
+
+// SponsorCfg contains the configuration of a sponsor
+type SponsorCfg struct {
+	// Name of the sponsor, its used gas is stored in the pool DB by name
+	Name string `mapstructure:"Name"`
+
+	// Targets are the contracts whose zero gas price txs are sponsored
+	Targets []common.Address `mapstructure:"Targets"`
+
+	// GasBudget is the total gas the sponsor pays for. The gas limit of the txs is reserved when they are added to the
+	// pool and the gas they don't use is refunded once they are added to a block. All the gas is refunded if they fail,
+	// expire, are replaced or are deleted while pending
+	GasBudget uint64 `mapstructure:"GasBudget"`
+}
+
+// QuotaCfg contains the configuration of the token buckets limiting the txs and gas added to the pool.
+// Every tx consumes from the quota of its sender, of the IP it was sent from and of the API key it was sent with
+type QuotaCfg struct {
+	// Enabled is a flag to enable/disable the quotas
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Default are the limits of the senders, IPs and API keys not assigned to any tier
+	Default QuotaLimitsCfg `mapstructure:"Default"`
+
+	// Tiers are the tiers with their own limits, a key assigned to several tiers gets the first one
+	Tiers []QuotaTierCfg `mapstructure:"Tiers"`
+
+	// CleanupInterval is the interval to forget the quotas of the keys that have been refilled
+	CleanupInterval types.Duration `mapstructure:"CleanupInterval"`
+}
+
+// QuotaLimitsCfg contains the rates and bursts of a quota
+type QuotaLimitsCfg struct {
+	// TxsPerSecond is the number of txs per second the quota is refilled with, 0 means the txs aren't limited
+	TxsPerSecond float64 `mapstructure:"TxsPerSecond"`
+
+	// TxsBurst is the max number of txs that can be added at once
+	TxsBurst uint64 `mapstructure:"TxsBurst"`
+
+	// GasPerSecond is the gas per second the quota is refilled with, 0 means the gas isn't limited
+	GasPerSecond uint64 `mapstructure:"GasPerSecond"`
+
+	// GasBurst is the max gas that can be added at once, the txs with a higher gas limit are always rejected
+	GasBurst uint64 `mapstructure:"GasBurst"`
+}
+
+// QuotaTierCfg contains the limits of a tier and the keys assigned to it
+type QuotaTierCfg struct {
+	// Name of the tier, used as label of the metrics
+	Name string `mapstructure:"Name"`
+
+	// Limits of the quotas of the keys of the tier
+	Limits QuotaLimitsCfg `mapstructure:"Limits"`
+
+	// Senders are the addresses assigned to the tier
+	Senders []common.Address `mapstructure:"Senders"`
+
+	// IPs are the IPs assigned to the tier
+	IPs []string `mapstructure:"IPs"`
+
+	// APIKeys are the API keys assigned to the tier. The txs sent with an API key not assigned to any tier are rejected
+	APIKeys []string `mapstructure:"APIKeys"`
 }
 
 // EffectiveGasPriceCfg contains the configuration properties for the effective gas price
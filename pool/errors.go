@@ -85,4 +85,25 @@ var (
 
 	// ErrSenderDisallowedDeploy is returned when deploy transactions are disallowed by policy
 	ErrSenderDisallowedDeploy = errors.New("sender disallowed deploy by policy")
+
+	// ErrContractDisallowedCall is returned when calls to the contract are disallowed by policy
+	ErrContractDisallowedCall = errors.New("contract disallowed call by policy")
+
+	// ErrSelectorDisallowed is returned when calls to the function of the contract are disallowed by policy
+	ErrSelectorDisallowed = errors.New("function selector disallowed by policy")
+
+	// ErrGasExceedsPolicyLimit is returned when the gas of the tx exceeds the limit of the sender set by policy
+	ErrGasExceedsPolicyLimit = errors.New("gas exceeds the sender limit set by policy")
+
+	// ErrValueExceedsPolicyLimit is returned when the value of the tx exceeds the limit of the sender set by policy
+	ErrValueExceedsPolicyLimit = errors.New("value exceeds the sender limit set by policy")
+
+	// ErrQuotaExceeded is returned when the quota of txs or gas of the sender, IP or API key of the tx is exhausted
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	// ErrUnknownAPIKey is returned when the tx is sent with an API key that isn't assigned to any quota tier
+	ErrUnknownAPIKey = errors.New("unknown API key")
+
+	// ErrSponsorBudgetExceeded is returned when the gas of a sponsored tx exceeds the budget left of its sponsor
+	ErrSponsorBudgetExceeded = errors.New("sponsor gas budget exceeded")
 )
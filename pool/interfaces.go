@@ -20,6 +20,8 @@ type storage interface {
 	GetNonce(ctx context.Context, address common.Address) (uint64, error)
 	GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error)
 	GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]Transaction, error)
+	GetTxsByFromAndStatus(ctx context.Context, from common.Address, status ...TxStatus) ([]Transaction, error)
+	GetTxQueuePosition(ctx context.Context, hash common.Hash) (uint64, error)
 	GetTxsByStatus(ctx context.Context, state TxStatus, limit uint64) ([]Transaction, error)
 	GetNonWIPPendingTxs(ctx context.Context) ([]Transaction, error)
 	IsTxPending(ctx context.Context, hash common.Hash) (bool, error)
@@ -40,6 +42,15 @@ type storage interface {
 	MinL2GasPriceSince(ctx context.Context, timestamp time.Time) (uint64, error)
 	policy
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	ReserveSponsorGas(ctx context.Context, sponsor string, gas uint64, budget uint64) error
+	RefundSponsorGas(ctx context.Context, sponsor string, gas uint64) error
+	GetSponsorUsedGas(ctx context.Context, sponsor string) (uint64, error)
+	AddPendingReceipt(ctx context.Context, receipt *types.Receipt) error
+	GetPendingReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	GetPendingReceiptsFrom(ctx context.Context, fromID uint64) ([]PendingReceipt, error)
+	DeletePendingReceipts(ctx context.Context, hashes []common.Hash) error
+	DeletePendingReceiptsOlderThan(ctx context.Context, date time.Time) error
+	ClearPendingReceipts(ctx context.Context) error
 }
 
 type stateInterface interface {
@@ -57,4 +68,17 @@ type policy interface {
 	DescribePolicies(ctx context.Context) ([]Policy, error)
 	DescribePolicy(ctx context.Context, name PolicyName) (Policy, error)
 	ListAcl(ctx context.Context, policy PolicyName, query []common.Address) ([]common.Address, error)
+	AddAddressesToPriorityLane(ctx context.Context, lane string, addresses []common.Address) error
+	RemoveAddressesFromPriorityLane(ctx context.Context, lane string, addresses []common.Address) error
+	ClearPriorityLane(ctx context.Context, lane string) error
+	GetPriorityLaneMembers(ctx context.Context) ([]PriorityLaneMember, error)
+	GetAddressLimits(ctx context.Context, address common.Address) (*AddressLimits, error)
+	SetAddressLimits(ctx context.Context, limits []AddressLimits) error
+	RemoveAddressLimits(ctx context.Context, addresses []common.Address) error
+	ListAddressLimits(ctx context.Context) ([]AddressLimits, error)
+	CheckSelector(ctx context.Context, contract common.Address, selector Selector) (bool, error)
+	HasAllowedSelectors(ctx context.Context, contract common.Address) (bool, error)
+	AddSelectorFilters(ctx context.Context, filters []SelectorFilter) error
+	RemoveSelectorFilters(ctx context.Context, filters []SelectorFilter) error
+	ListSelectorFilters(ctx context.Context) ([]SelectorFilter, error)
 }
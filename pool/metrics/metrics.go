@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	prefix            = "pool_"
+	quotaPrefix       = prefix + "quota_"
+	quotaTxsName      = quotaPrefix + "txs"
+	quotaGasName      = quotaPrefix + "gas"
+	quotaRejectedName = quotaPrefix + "rejected"
+
+	quotaKindLabelName = "kind"
+	quotaTierLabelName = "tier"
+)
+
+// Register the metrics for the pool package.
+func Register() {
+	var counterVecs []metrics.CounterVecOpts
+
+	counterVecs = []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: quotaTxsName,
+				Help: "[POOL] number of txs consumed from the quotas",
+			},
+			Labels: []string{quotaKindLabelName, quotaTierLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: quotaGasName,
+				Help: "[POOL] gas consumed from the quotas",
+			},
+			Labels: []string{quotaKindLabelName, quotaTierLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: quotaRejectedName,
+				Help: "[POOL] number of txs rejected for exceeding the quotas",
+			},
+			Labels: []string{quotaKindLabelName, quotaTierLabelName},
+		},
+	}
+
+	metrics.RegisterCounterVecs(counterVecs...)
+}
+
+// QuotaUsed increments the txs and gas consumed from the quotas of the kind and tier.
+func QuotaUsed(kind, tier string, gas uint64) {
+	if txs, exist := metrics.CounterVec(quotaTxsName); exist {
+		txs.WithLabelValues(kind, tier).Inc()
+	}
+	if gasUsed, exist := metrics.CounterVec(quotaGasName); exist {
+		gasUsed.WithLabelValues(kind, tier).Add(float64(gas))
+	}
+}
+
+// QuotaRejected increments the txs rejected for exceeding the quotas of the kind and tier.
+func QuotaRejected(kind, tier string) {
+	if rejected, exist := metrics.CounterVec(quotaRejectedName); exist {
+		rejected.WithLabelValues(kind, tier).Inc()
+	}
+}
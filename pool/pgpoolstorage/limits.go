@@ -0,0 +1,215 @@
+package pgpoolstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetAddressLimits returns the gas and value limits of the txs sent by the address, ErrNotFound if it isn't limited
+func (p *PostgresPoolStorage) GetAddressLimits(ctx context.Context, address common.Address) (*pool.AddressLimits, error) {
+	sql := "SELECT address, max_gas, max_value::TEXT FROM pool.address_limit WHERE address = $1"
+	limits, err := scanAddressLimits(p.db.QueryRow(ctx, sql, address.Hex()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pool.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// SetAddressLimits sets the gas and value limits of the txs sent by the addresses, replacing the previous ones
+func (p *PostgresPoolStorage) SetAddressLimits(ctx context.Context, limits []pool.AddressLimits) error {
+	sql := `INSERT INTO pool.address_limit (address, max_gas, max_value) VALUES ($1, $2, $3::DECIMAL)
+			ON CONFLICT (address) DO UPDATE SET max_gas = EXCLUDED.max_gas, max_value = EXCLUDED.max_value`
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	for _, l := range limits {
+		var maxGas *int64
+		if l.MaxGas != nil {
+			if *l.MaxGas > math.MaxInt64 {
+				return fmt.Errorf("max gas %d of address %s exceeds the max of %d", *l.MaxGas, l.Address.Hex(), int64(math.MaxInt64))
+			}
+			gas := int64(*l.MaxGas)
+			maxGas = &gas
+		}
+		var maxValue *string
+		if l.MaxValue != nil {
+			value := l.MaxValue.String()
+			maxValue = &value
+		}
+		if _, err = tx.Exec(ctx, sql, l.Address.Hex(), maxGas, maxValue); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// RemoveAddressLimits removes the gas and value limits of the txs sent by the addresses
+func (p *PostgresPoolStorage) RemoveAddressLimits(ctx context.Context, addresses []common.Address) error {
+	sql := "DELETE FROM pool.address_limit WHERE address = $1"
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	for _, a := range addresses {
+		if _, err = tx.Exec(ctx, sql, a.Hex()); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ListAddressLimits returns the limits of all the limited addresses
+func (p *PostgresPoolStorage) ListAddressLimits(ctx context.Context) ([]pool.AddressLimits, error) {
+	sql := "SELECT address, max_gas, max_value::TEXT FROM pool.address_limit ORDER BY address"
+	rows, err := p.db.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []pool.AddressLimits
+	for rows.Next() {
+		limits, err := scanAddressLimits(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *limits)
+	}
+	return list, rows.Err()
+}
+
+func scanAddressLimits(row pgx.Row) (*pool.AddressLimits, error) {
+	var (
+		address  string
+		maxGas   *int64
+		maxValue *string
+	)
+	if err := row.Scan(&address, &maxGas, &maxValue); err != nil {
+		return nil, err
+	}
+
+	limits := &pool.AddressLimits{Address: common.HexToAddress(address)}
+	if maxGas != nil {
+		gas := uint64(*maxGas)
+		limits.MaxGas = &gas
+	}
+	if maxValue != nil {
+		value, ok := new(big.Int).SetString(*maxValue, 10) //nolint:gomnd
+		if !ok {
+			return nil, fmt.Errorf("invalid max value %s of address %s", *maxValue, address)
+		}
+		limits.MaxValue = value
+	}
+	return limits, nil
+}
+
+// CheckSelector returns if the function of the contract can be called. The filter of the selector is applied if it
+// exists, otherwise the call is allowed unless the contract has filters allowing other functions
+func (p *PostgresPoolStorage) CheckSelector(ctx context.Context, contract common.Address, selector pool.Selector) (bool, error) {
+	sql := `SELECT COALESCE(
+				(SELECT allow FROM pool.selector_filter WHERE address = $1 AND selector = $2),
+				NOT EXISTS (SELECT 1 FROM pool.selector_filter WHERE address = $1 AND allow)
+			)`
+	var allow bool
+	if err := p.db.QueryRow(ctx, sql, contract.Hex(), selector.String()).Scan(&allow); err != nil {
+		return false, err
+	}
+	return allow, nil
+}
+
+// HasAllowedSelectors returns if the contract has filters allowing functions, so only those functions can be called
+func (p *PostgresPoolStorage) HasAllowedSelectors(ctx context.Context, contract common.Address) (bool, error) {
+	sql := "SELECT EXISTS (SELECT 1 FROM pool.selector_filter WHERE address = $1 AND allow)"
+	var hasAllowed bool
+	if err := p.db.QueryRow(ctx, sql, contract.Hex()).Scan(&hasAllowed); err != nil {
+		return false, err
+	}
+	return hasAllowed, nil
+}
+
+// AddSelectorFilters adds the function selector filters, replacing the previous filter of the same function
+func (p *PostgresPoolStorage) AddSelectorFilters(ctx context.Context, filters []pool.SelectorFilter) error {
+	sql := `INSERT INTO pool.selector_filter (address, selector, allow) VALUES ($1, $2, $3)
+			ON CONFLICT (address, selector) DO UPDATE SET allow = EXCLUDED.allow`
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	for _, f := range filters {
+		if _, err = tx.Exec(ctx, sql, f.Contract.Hex(), f.Selector.String(), f.Allow); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// RemoveSelectorFilters removes the function selector filters
+func (p *PostgresPoolStorage) RemoveSelectorFilters(ctx context.Context, filters []pool.SelectorFilter) error {
+	sql := "DELETE FROM pool.selector_filter WHERE address = $1 AND selector = $2"
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	for _, f := range filters {
+		if _, err = tx.Exec(ctx, sql, f.Contract.Hex(), f.Selector.String()); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ListSelectorFilters returns all the function selector filters
+func (p *PostgresPoolStorage) ListSelectorFilters(ctx context.Context) ([]pool.SelectorFilter, error) {
+	sql := "SELECT address, selector, allow FROM pool.selector_filter ORDER BY address, selector"
+	rows, err := p.db.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var filters []pool.SelectorFilter
+	for rows.Next() {
+		var (
+			address, selector string
+			allow             bool
+		)
+		if err := rows.Scan(&address, &selector, &allow); err != nil {
+			return nil, err
+		}
+		decoded, err := hexutil.Decode(selector)
+		if err != nil || len(decoded) != pool.SelectorLength {
+			return nil, fmt.Errorf("invalid selector %s of contract %s", selector, address)
+		}
+		filter := pool.SelectorFilter{Contract: common.HexToAddress(address), Allow: allow}
+		copy(filter.Selector[:], decoded)
+		filters = append(filters, filter)
+	}
+	return filters, rows.Err()
+}
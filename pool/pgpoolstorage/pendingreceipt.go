@@ -0,0 +1,95 @@
+package pgpoolstorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddPendingReceipt stores the receipt of a tx added to the WIP L2 block of the sequencer, replacing the previous one
+// of the tx if any. The replaced receipts get a new id so they are published again
+func (p *PostgresPoolStorage) AddPendingReceipt(ctx context.Context, receipt *types.Receipt) error {
+	data, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+	sql := `INSERT INTO pool.pending_receipt (hash, receipt) VALUES ($1, $2)
+			ON CONFLICT (hash) DO UPDATE SET receipt = EXCLUDED.receipt, id = DEFAULT, created_at = NOW()`
+	_, err = p.db.Exec(ctx, sql, receipt.TxHash.String(), data)
+	return err
+}
+
+// GetPendingReceipt returns the pending receipt of the tx, ErrNotFound if the sequencer hasn't published it
+func (p *PostgresPoolStorage) GetPendingReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	sql := "SELECT receipt FROM pool.pending_receipt WHERE hash = $1"
+	var data []byte
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pool.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	receipt := &types.Receipt{}
+	if err := json.Unmarshal(data, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// GetPendingReceiptsFrom returns the pending receipts published after the one with the id, sorted by id
+func (p *PostgresPoolStorage) GetPendingReceiptsFrom(ctx context.Context, fromID uint64) ([]pool.PendingReceipt, error) {
+	sql := "SELECT id, receipt FROM pool.pending_receipt WHERE id > $1 ORDER BY id"
+	rows, err := p.db.Query(ctx, sql, int64(fromID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := make([]pool.PendingReceipt, 0)
+	for rows.Next() {
+		var (
+			id   int64
+			data []byte
+		)
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, err
+		}
+		receipt := &types.Receipt{}
+		if err := json.Unmarshal(data, receipt); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, pool.PendingReceipt{ID: uint64(id), Receipt: receipt})
+	}
+	return receipts, rows.Err()
+}
+
+// DeletePendingReceipts deletes the pending receipts of the txs
+func (p *PostgresPoolStorage) DeletePendingReceipts(ctx context.Context, hashes []common.Hash) error {
+	hh := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		hh = append(hh, h.String())
+	}
+	sql := "DELETE FROM pool.pending_receipt WHERE hash = ANY ($1)"
+	_, err := p.db.Exec(ctx, sql, hh)
+	return err
+}
+
+// DeletePendingReceiptsOlderThan deletes the pending receipts published before the date
+func (p *PostgresPoolStorage) DeletePendingReceiptsOlderThan(ctx context.Context, date time.Time) error {
+	sql := "DELETE FROM pool.pending_receipt WHERE created_at < $1"
+	_, err := p.db.Exec(ctx, sql, date)
+	return err
+}
+
+// ClearPendingReceipts deletes all the pending receipts
+func (p *PostgresPoolStorage) ClearPendingReceipts(ctx context.Context) error {
+	_, err := p.db.Exec(ctx, "DELETE FROM pool.pending_receipt")
+	return err
+}
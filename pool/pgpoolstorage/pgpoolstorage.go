@@ -543,6 +543,48 @@ func (p *PostgresPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from com
 	return txs, nil
 }
 
+// GetTxsByFromAndStatus gets the txs sent by the address with any of the statuses sorted by nonce
+func (p *PostgresPoolStorage) GetTxsByFromAndStatus(ctx context.Context, from common.Address, status ...pool.TxStatus) ([]pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes,
+				   used_poseidon_paddings, used_mem_aligns, used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters
+			  FROM pool.transaction
+			 WHERE from_address = $1
+			   AND status = ANY ($2)
+		  ORDER BY nonce, gas_price DESC`
+	rows, err := p.db.Query(ctx, sql, from.String(), status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
+// GetTxQueuePosition returns the number of pending txs ahead of the tx when they are sorted by gas price, the ties
+// being sorted by arrival. It's an approximation of the order of the sequencer, which depends on its tx ordering and
+// priority lanes
+func (p *PostgresPoolStorage) GetTxQueuePosition(ctx context.Context, hash common.Hash) (uint64, error) {
+	sql := `SELECT COUNT(*)
+			  FROM pool.transaction t, (SELECT gas_price, received_at FROM pool.transaction WHERE hash = $1) tx
+			 WHERE t.status = $2
+			   AND t.hash != $1
+			   AND (t.gas_price > tx.gas_price OR (t.gas_price = tx.gas_price AND t.received_at < tx.received_at))`
+	var position uint64
+	if err := p.db.QueryRow(ctx, sql, hash.String(), pool.TxStatusPending).Scan(&position); err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
 // GetTxFromAddressFromByHash gets tx from address by hash
 func (p *PostgresPoolStorage) GetTxFromAddressFromByHash(ctx context.Context, hash common.Hash) (common.Address, uint64, error) {
 	query := `SELECT from_address, nonce
@@ -0,0 +1,76 @@
+package pgpoolstorage
+
+import (
+	"context"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddAddressesToPriorityLane adds addresses to the named priority lane
+func (p *PostgresPoolStorage) AddAddressesToPriorityLane(ctx context.Context, lane string, addresses []common.Address) error {
+	sql := "INSERT INTO pool.priority_lane (lane, address) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	for _, a := range addresses {
+		_, err = tx.Exec(ctx, sql, lane, a.Hex())
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// RemoveAddressesFromPriorityLane removes addresses from the named priority lane
+func (p *PostgresPoolStorage) RemoveAddressesFromPriorityLane(ctx context.Context, lane string, addresses []common.Address) error {
+	sql := "DELETE FROM pool.priority_lane WHERE lane = $1 AND address = $2"
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	for _, a := range addresses {
+		_, err = tx.Exec(ctx, sql, lane, a.Hex())
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ClearPriorityLane removes _all_ addresses from the named priority lane
+func (p *PostgresPoolStorage) ClearPriorityLane(ctx context.Context, lane string) error {
+	sql := "DELETE FROM pool.priority_lane WHERE lane = $1"
+	_, err := p.db.Exec(ctx, sql, lane)
+	return err
+}
+
+// GetPriorityLaneMembers returns the addresses of all the priority lanes
+func (p *PostgresPoolStorage) GetPriorityLaneMembers(ctx context.Context) ([]pool.PriorityLaneMember, error) {
+	sql := "SELECT lane, address FROM pool.priority_lane ORDER BY lane, address"
+	rows, err := p.db.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []pool.PriorityLaneMember
+	for rows.Next() {
+		var lane, addr string
+		if err := rows.Scan(&lane, &addr); err != nil {
+			return nil, err
+		}
+		members = append(members, pool.PriorityLaneMember{Lane: lane, Address: common.HexToAddress(addr)})
+	}
+	return members, rows.Err()
+}
@@ -0,0 +1,43 @@
+package pgpoolstorage
+
+import (
+	"context"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+)
+
+// ReserveSponsorGas adds the gas to the gas used by the sponsor, returning ErrSponsorBudgetExceeded if it would
+// exceed its budget
+func (p *PostgresPoolStorage) ReserveSponsorGas(ctx context.Context, sponsor string, gas uint64, budget uint64) error {
+	if gas > budget {
+		return pool.ErrSponsorBudgetExceeded
+	}
+	sql := `INSERT INTO pool.sponsor (name, used_gas) VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE SET used_gas = pool.sponsor.used_gas + EXCLUDED.used_gas
+			WHERE pool.sponsor.used_gas + EXCLUDED.used_gas <= $3`
+	cmdTag, err := p.db.Exec(ctx, sql, sponsor, int64(gas), int64(budget))
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return pool.ErrSponsorBudgetExceeded
+	}
+	return nil
+}
+
+// RefundSponsorGas subtracts the gas from the gas used by the sponsor
+func (p *PostgresPoolStorage) RefundSponsorGas(ctx context.Context, sponsor string, gas uint64) error {
+	sql := "UPDATE pool.sponsor SET used_gas = GREATEST(used_gas - $2, 0) WHERE name = $1"
+	_, err := p.db.Exec(ctx, sql, sponsor, int64(gas))
+	return err
+}
+
+// GetSponsorUsedGas returns the gas used by the sponsor
+func (p *PostgresPoolStorage) GetSponsorUsedGas(ctx context.Context, sponsor string) (uint64, error) {
+	sql := "SELECT COALESCE((SELECT used_gas FROM pool.sponsor WHERE name = $1), 0)"
+	var usedGas int64
+	if err := p.db.QueryRow(ctx, sql, sponsor).Scan(&usedGas); err != nil {
+		return 0, err
+	}
+	return uint64(usedGas), nil
+}
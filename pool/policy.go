@@ -1,6 +1,11 @@
 package pool
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
 
 // PolicyName is a named policy
 type PolicyName string
@@ -10,8 +15,13 @@ const (
 	SendTx PolicyName = "send_tx"
 	// Deploy is the name of the policy that governs that an address may deploy a contract
 	Deploy PolicyName = "deploy"
+	// Call is the name of the policy that governs that a contract may be called
+	Call PolicyName = "call"
 )
 
+// SelectorLength is the length of the function selector of the tx data
+const SelectorLength = 4
+
 // Policy describes state of a named policy
 type Policy struct {
 	Name  PolicyName
@@ -34,10 +44,40 @@ type Acl struct {
 
 // IsPolicy tests if a string represents a known named Policy
 func IsPolicy(name string) bool {
-	for _, p := range []PolicyName{SendTx, Deploy} {
+	for _, p := range []PolicyName{SendTx, Deploy, Call} {
 		if name == string(p) {
 			return true
 		}
 	}
 	return false
 }
+
+// PriorityLaneMember describes an address whose txs, sent by or to it, are processed in a named priority lane
+type PriorityLaneMember struct {
+	Lane    string
+	Address common.Address
+}
+
+// AddressLimits are the max gas and value of the txs sent by an address, nil if not limited
+type AddressLimits struct {
+	Address  common.Address
+	MaxGas   *uint64
+	MaxValue *big.Int
+}
+
+// Selector is the function selector of a contract call
+type Selector [SelectorLength]byte
+
+// String returns the hex representation of the selector
+func (s Selector) String() string {
+	return hexutil.Encode(s[:])
+}
+
+// SelectorFilter allows or denies calling a function of a contract. If a contract has any allowing filter, only the
+// allowed functions can be called and the calls with data shorter than a selector are denied, otherwise all the
+// functions but the denied ones can be called
+type SelectorFilter struct {
+	Contract common.Address
+	Selector Selector
+	Allow    bool
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
@@ -52,6 +53,8 @@ type Pool struct {
 	gasPrices               GasPrices
 	gasPricesMux            *sync.RWMutex
 	effectiveGasPrice       *EffectiveGasPrice
+	quotas                  *quotas
+	sponsorships            *Sponsorships
 }
 
 type preExecutionResponse struct {
@@ -87,6 +90,19 @@ func NewPool(cfg Config, batchConstraintsCfg state.BatchConstraintsCfg, s storag
 		gasPrices:               GasPrices{0, 0},
 		gasPricesMux:            new(sync.RWMutex),
 		effectiveGasPrice:       NewEffectiveGasPrice(cfg.EffectiveGasPrice),
+		sponsorships:            NewSponsorships(cfg.Sponsorship),
+	}
+	if cfg.Quotas.Enabled {
+		metrics.Register()
+		p.quotas = newQuotas(cfg.Quotas)
+	}
+	if p.quotas != nil && cfg.Quotas.CleanupInterval.Duration > 0 {
+		go func(cfg *Config, p *Pool) {
+			for {
+				time.Sleep(cfg.Quotas.CleanupInterval.Duration)
+				p.quotas.cleanup()
+			}
+		}(&cfg, p)
 	}
 	p.refreshGasPrices()
 	go func(cfg *Config, p *Pool) {
@@ -186,7 +202,27 @@ func (p *Pool) AddTx(ctx context.Context, tx types.Transaction, ip string) error
 		return err
 	}
 
-	return p.StoreTx(ctx, tx, ip, false)
+	// the gas limit of the sponsored txs is reserved from the budget of the sponsor until they are added to a block, when
+	// the gas not used is refunded, or until they fail, expire, are replaced or deleted, when all the gas is refunded
+	sponsor, sponsored := p.sponsorships.sponsorOf(tx)
+	if sponsored {
+		if err := p.storage.ReserveSponsorGas(ctx, sponsor.Name, tx.Gas(), sponsor.GasBudget); err != nil {
+			if errors.Is(err, ErrSponsorBudgetExceeded) {
+				log.Infof("%v: %v %v", err.Error(), sponsor.Name, tx.Hash().String())
+			} else {
+				log.Errorf("failed to reserve the gas of the sponsor %s while adding tx to the pool, error: %v", sponsor.Name, err)
+			}
+			return err
+		}
+	}
+
+	err := p.StoreTx(ctx, tx, ip, false)
+	if err != nil && sponsored {
+		if err := p.storage.RefundSponsorGas(ctx, sponsor.Name, tx.Gas()); err != nil {
+			log.Errorf("failed to refund the gas of the sponsor %s of the tx %s not added to the pool, error: %v", sponsor.Name, tx.Hash().String(), err)
+		}
+	}
+	return err
 }
 
 // StoreTx adds a transaction to the pool with the pending state
@@ -242,9 +278,12 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 		return err
 	}
 
-	err = p.ValidateBreakEvenGasPrice(ctx, tx, preExecutionResponse.txResponse.GasUsed, gasPrices)
-	if err != nil {
-		return err
+	// the sponsored txs are accepted with zero gas price, as their fee is paid by their sponsor
+	if p.sponsorships.SponsorOf(tx) == "" {
+		err = p.ValidateBreakEvenGasPrice(ctx, tx, preExecutionResponse.txResponse.GasUsed, gasPrices)
+		if err != nil {
+			return err
+		}
 	}
 
 	poolTx := NewTransaction(tx, ip, isWIP)
@@ -476,6 +515,18 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrBlockedSender
 	}
 
+	if err := p.checkTxPolicies(ctx, from, poolTx); err != nil {
+		return err
+	}
+
+	// the quotas are consumed by every tx that gets here, so the ones rejected by the checks below are also limited
+	if p.quotas != nil {
+		if err := p.quotas.take(from, poolTx.IP, apiKeyFromContext(ctx), poolTx.Gas()); err != nil {
+			log.Infof("%v: %v", err.Error(), from.String())
+			return err
+		}
+	}
+
 	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
 	if err != nil {
 		log.Errorf("failed to load last l2 block while adding tx to the pool", err)
@@ -528,7 +579,7 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		log.Debugf("low gas price: minSuggestedGasPrice %v got %v", p.minSuggestedGasPrice, poolTx.GasPrice())
 	}
 	p.minSuggestedGasPriceMux.RUnlock()
-	if gasPriceCmp == -1 {
+	if gasPriceCmp == -1 && p.sponsorships.SponsorOf(poolTx.Transaction) == "" {
 		return ErrGasPrice
 	}
 
@@ -654,15 +705,37 @@ func (p *Pool) checkTxFieldCompatibilityWithExecutor(ctx context.Context, tx typ
 	return nil
 }
 
-// DeleteReorgedTransactions deletes transactions from the pool
+// DeleteReorgedTransactions deletes transactions from the pool. The sponsors of the deleted txs that were still
+// pending are refunded the gas reserved for them
 func (p *Pool) DeleteReorgedTransactions(ctx context.Context, transactions []*types.Transaction) error {
 	hashes := []common.Hash{}
+	pendingSponsored := []*types.Transaction{}
 
 	for _, tx := range transactions {
 		hashes = append(hashes, tx.Hash())
+
+		if _, sponsored := p.sponsorships.sponsorOf(*tx); sponsored {
+			pending, err := p.storage.IsTxPending(ctx, tx.Hash())
+			if err != nil {
+				return err
+			}
+			if pending {
+				pendingSponsored = append(pendingSponsored, tx)
+			}
+		}
 	}
 
-	return p.storage.DeleteTransactionsByHashes(ctx, hashes)
+	if err := p.storage.DeleteTransactionsByHashes(ctx, hashes); err != nil {
+		return err
+	}
+
+	for _, tx := range pendingSponsored {
+		sponsor, _ := p.sponsorships.sponsorOf(*tx)
+		if err := p.storage.RefundSponsorGas(ctx, sponsor.Name, tx.Gas()); err != nil {
+			log.Errorf("failed to refund the gas of the sponsor %s of the deleted tx %s, error: %v", sponsor.Name, tx.Hash().String(), err)
+		}
+	}
+	return nil
 }
 
 // UpdateTxWIPStatus updates a transaction wip status accordingly to the
@@ -747,6 +820,68 @@ func (p *Pool) CheckPolicy(ctx context.Context, policy PolicyName, address commo
 	return p.storage.CheckPolicy(ctx, policy, address)
 }
 
+// checkTxPolicies checks the tx against the policies of the called contract and the limits of the sender
+func (p *Pool) checkTxPolicies(ctx context.Context, from common.Address, poolTx Transaction) error {
+	if to := poolTx.To(); to != nil {
+		allow, err := p.storage.CheckPolicy(ctx, Call, *to)
+		if err != nil {
+			log.Errorf("failed to check call policy while adding tx to the pool", err)
+			return err
+		}
+		if !allow {
+			log.Infof("%v: %v", ErrContractDisallowedCall.Error(), to.String())
+			return ErrContractDisallowedCall
+		}
+
+		if len(poolTx.Data()) >= SelectorLength {
+			var selector Selector
+			copy(selector[:], poolTx.Data()[:SelectorLength])
+			allow, err := p.storage.CheckSelector(ctx, *to, selector)
+			if err != nil {
+				log.Errorf("failed to check function selector policy while adding tx to the pool", err)
+				return err
+			}
+			if !allow {
+				log.Infof("%v: %v %v", ErrSelectorDisallowed.Error(), to.String(), selector)
+				return ErrSelectorDisallowed
+			}
+		} else {
+			// the data without a selector calls the fallback functions, which can't be allowed by a filter
+			hasAllowed, err := p.storage.HasAllowedSelectors(ctx, *to)
+			if err != nil {
+				log.Errorf("failed to check function selector policy while adding tx to the pool", err)
+				return err
+			}
+			if hasAllowed {
+				log.Infof("%v: %v without selector", ErrSelectorDisallowed.Error(), to.String())
+				return ErrSelectorDisallowed
+			}
+		}
+	}
+
+	limits, err := p.storage.GetAddressLimits(ctx, from)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	} else if err != nil {
+		log.Errorf("failed to get sender limits while adding tx to the pool", err)
+		return err
+	}
+	if limits.MaxGas != nil && poolTx.Gas() > *limits.MaxGas {
+		log.Infof("%v: %v", ErrGasExceedsPolicyLimit.Error(), from.String())
+		return ErrGasExceedsPolicyLimit
+	}
+	if limits.MaxValue != nil && poolTx.Value().Cmp(limits.MaxValue) > 0 {
+		log.Infof("%v: %v", ErrValueExceedsPolicyLimit.Error(), from.String())
+		return ErrValueExceedsPolicyLimit
+	}
+	return nil
+}
+
+// GetPriorityLaneMembers returns the addresses of all the priority lanes
+func (p *Pool) GetPriorityLaneMembers(ctx context.Context) ([]PriorityLaneMember, error) {
+	return p.storage.GetPriorityLaneMembers(ctx)
+}
+
 // checkTxFee is an internal function used to check whether the fee of
 // the given transaction is _reasonable_(under the cap).
 func checkTxFee(gasPrice *big.Int, gas uint64, cap float64) error {
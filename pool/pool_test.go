@@ -2167,7 +2167,7 @@ func Test_PolicyAcl(t *testing.T) {
 
 	// Policies start out as deny lists, since there are no addresses on the
 	// lists, random addresses will always be allowed
-	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy} {
+	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy, pool.Call} {
 		allow, err := p.CheckPolicy(ctx, policy, randAddr())
 		require.NoError(t, err)
 		require.True(t, allow)
@@ -2176,14 +2176,14 @@ func Test_PolicyAcl(t *testing.T) {
 	addr := randAddr()
 
 	// put addr on lists
-	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy} {
+	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy, pool.Call} {
 		ctag, err := poolSqlDB.Exec(ctx, "INSERT INTO pool.acl (policy, address) VALUES ($1,$2)", policy, addr.Hex())
 		require.NoError(t, err)
 		require.Equal(t, int64(1), ctag.RowsAffected())
 	}
 
 	// addr should not be denied by policy
-	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy} {
+	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy, pool.Call} {
 		allow, err := p.CheckPolicy(ctx, policy, addr)
 		require.NoError(t, err)
 		require.False(t, allow)
@@ -2192,17 +2192,17 @@ func Test_PolicyAcl(t *testing.T) {
 	// change policies to allow by acl
 	ctag, err := poolSqlDB.Exec(ctx, "UPDATE pool.policy SET allow = true")
 	require.NoError(t, err)
-	require.Equal(t, int64(2), ctag.RowsAffected())
+	require.Equal(t, int64(3), ctag.RowsAffected())
 
 	// addr is now allowed
-	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy} {
+	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy, pool.Call} {
 		allow, err := p.CheckPolicy(ctx, policy, addr)
 		require.NoError(t, err)
 		require.True(t, allow)
 	}
 
 	// random addrs are now denied
-	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy} {
+	for _, policy := range []pool.PolicyName{pool.SendTx, pool.Deploy, pool.Call} {
 		for _, a := range []common.Address{randAddr(), randAddr()} {
 			allow, err := s.CheckPolicy(ctx, policy, a)
 			require.NoError(t, err)
@@ -2210,3 +2210,254 @@ func Test_PolicyAcl(t *testing.T) {
 		}
 	}
 }
+
+func Test_PriorityLanes(t *testing.T) {
+	initOrResetDB(t)
+
+	ctx := context.Background()
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	p := pool.NewPool(cfg, bc, s, nil, uint64(1), nil)
+
+	members, err := p.GetPriorityLaneMembers(ctx)
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	oracle, relayer, bridge := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	require.NoError(t, s.AddAddressesToPriorityLane(ctx, "oracles", []common.Address{oracle}))
+	require.NoError(t, s.AddAddressesToPriorityLane(ctx, "bridge", []common.Address{relayer, bridge, bridge}))
+
+	members, err = p.GetPriorityLaneMembers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []pool.PriorityLaneMember{
+		{Lane: "bridge", Address: relayer},
+		{Lane: "bridge", Address: bridge},
+		{Lane: "oracles", Address: oracle},
+	}, members)
+
+	require.NoError(t, s.RemoveAddressesFromPriorityLane(ctx, "bridge", []common.Address{relayer}))
+	require.NoError(t, s.ClearPriorityLane(ctx, "oracles"))
+	members, err = p.GetPriorityLaneMembers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []pool.PriorityLaneMember{{Lane: "bridge", Address: bridge}}, members)
+}
+
+func Test_PolicyLimitsAndSelectors(t *testing.T) {
+	initOrResetDB(t)
+
+	ctx := context.Background()
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	sender, other := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	_, err = s.GetAddressLimits(ctx, sender)
+	require.Equal(t, pool.ErrNotFound, err)
+
+	overflowGas := uint64(math.MaxInt64) + 1
+	require.Error(t, s.SetAddressLimits(ctx, []pool.AddressLimits{{Address: sender, MaxGas: &overflowGas}}))
+
+	maxGas := uint64(21000)
+	require.NoError(t, s.SetAddressLimits(ctx, []pool.AddressLimits{
+		{Address: sender, MaxGas: &maxGas},
+		{Address: other, MaxValue: big.NewInt(1000)},
+	}))
+	limits, err := s.GetAddressLimits(ctx, sender)
+	require.NoError(t, err)
+	require.Equal(t, &pool.AddressLimits{Address: sender, MaxGas: &maxGas}, limits)
+
+	require.NoError(t, s.RemoveAddressLimits(ctx, []common.Address{sender}))
+	list, err := s.ListAddressLimits(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []pool.AddressLimits{{Address: other, MaxValue: big.NewInt(1000)}}, list)
+
+	allowed, denied, transfer := pool.Selector{0x1}, pool.Selector{0x2}, pool.Selector{0xa9, 0x05, 0x9c, 0xbb}
+	contract, token := common.HexToAddress("0x3"), common.HexToAddress("0x4")
+
+	// without filters everything can be called
+	ok, err := s.CheckSelector(ctx, contract, allowed)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, s.AddSelectorFilters(ctx, []pool.SelectorFilter{
+		{Contract: contract, Selector: allowed, Allow: true},
+		{Contract: token, Selector: transfer, Allow: false},
+	}))
+	for _, tc := range []struct {
+		contract common.Address
+		expected bool
+	}{
+		{contract, true},
+		// the denying filters don't restrict the calls without a selector
+		{token, false},
+	} {
+		hasAllowed, err := s.HasAllowedSelectors(ctx, tc.contract)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, hasAllowed, tc.contract.String())
+	}
+	for _, tc := range []struct {
+		contract common.Address
+		selector pool.Selector
+		expected bool
+	}{
+		{contract, allowed, true},
+		// only the allowed selectors can be called once there is one
+		{contract, denied, false},
+		{token, transfer, false},
+		{token, allowed, true},
+	} {
+		ok, err := s.CheckSelector(ctx, tc.contract, tc.selector)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, ok, "%s %s", tc.contract, tc.selector)
+	}
+
+	require.NoError(t, s.RemoveSelectorFilters(ctx, []pool.SelectorFilter{{Contract: contract, Selector: allowed}}))
+	filters, err := s.ListSelectorFilters(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []pool.SelectorFilter{{Contract: token, Selector: transfer, Allow: false}}, filters)
+}
+
+func Test_AddTxSelectorPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	data := prepareToExecuteTx(t, chainID.Uint64())
+	defer data.stateSqlDB.Close() //nolint:gosec,errcheck
+	defer data.poolSqlDB.Close()  //nolint:gosec,errcheck
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	contract, allowed := common.HexToAddress("0x3"), pool.Selector{0x1, 0x2, 0x3, 0x4}
+	require.NoError(t, s.AddSelectorFilters(ctx, []pool.SelectorFilter{{Contract: contract, Selector: allowed, Allow: true}}))
+
+	auth, err := operations.GetAuth(senderPrivateKey, chainID.Uint64())
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"no data", nil, pool.ErrSelectorDisallowed},
+		{"data shorter than a selector", allowed[:3], pool.ErrSelectorDisallowed},
+		{"not allowed selector", []byte{0x1, 0x2, 0x3, 0x5}, pool.ErrSelectorDisallowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := ethTypes.NewTransaction(0, contract, big.NewInt(0), gasLimit, gasPrice, tc.data)
+			signedTx, err := auth.Signer(auth.From, tx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, data.pool.AddTx(ctx, *signedTx, ip))
+		})
+	}
+}
+
+func Test_SponsorGas(t *testing.T) {
+	initOrResetDB(t)
+
+	ctx := context.Background()
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	usedGas, err := s.GetSponsorUsedGas(ctx, "sponsor")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), usedGas)
+
+	require.Equal(t, pool.ErrSponsorBudgetExceeded, s.ReserveSponsorGas(ctx, "sponsor", 100001, 100000))
+	require.NoError(t, s.ReserveSponsorGas(ctx, "sponsor", 60000, 100000))
+	require.Equal(t, pool.ErrSponsorBudgetExceeded, s.ReserveSponsorGas(ctx, "sponsor", 60000, 100000))
+
+	require.NoError(t, s.RefundSponsorGas(ctx, "sponsor", 30000))
+	require.NoError(t, s.ReserveSponsorGas(ctx, "sponsor", 60000, 100000))
+	usedGas, err = s.GetSponsorUsedGas(ctx, "sponsor")
+	require.NoError(t, err)
+	require.Equal(t, uint64(90000), usedGas)
+}
+
+func Test_DeleteReorgedSponsoredTransactions(t *testing.T) {
+	initOrResetDB(t)
+
+	ctx := context.Background()
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	target := common.HexToAddress("0x5")
+	c := cfg
+	c.Sponsorship = pool.SponsorshipCfg{
+		Enabled:  true,
+		Sponsors: []pool.SponsorCfg{{Name: "sponsor", Targets: []common.Address{target}, GasBudget: 1000000}},
+	}
+	p := pool.NewPool(c, bc, s, nil, chainID.Uint64(), nil)
+
+	auth, err := operations.GetAuth(senderPrivateKey, chainID.Uint64())
+	require.NoError(t, err)
+	var txs []*ethTypes.Transaction
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx, err := auth.Signer(auth.From, ethTypes.NewTransaction(nonce, target, big.NewInt(0), 50000, big.NewInt(0), nil))
+		require.NoError(t, err)
+		require.NoError(t, s.AddTx(ctx, *pool.NewTransaction(*tx, ip, false)))
+		require.NoError(t, s.ReserveSponsorGas(ctx, "sponsor", tx.Gas(), 1000000))
+		txs = append(txs, tx)
+	}
+	// the selected tx has been charged the gas it has used, so it isn't refunded
+	require.NoError(t, s.RefundSponsorGas(ctx, "sponsor", 29000))
+	require.NoError(t, p.UpdateTxStatus(ctx, txs[1].Hash(), pool.TxStatusSelected, false, nil))
+
+	require.NoError(t, p.DeleteReorgedTransactions(ctx, txs))
+	usedGas, err := s.GetSponsorUsedGas(ctx, "sponsor")
+	require.NoError(t, err)
+	require.Equal(t, uint64(21000), usedGas)
+}
+
+func Test_PendingReceipts(t *testing.T) {
+	initOrResetDB(t)
+
+	ctx := context.Background()
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	hash1, hash2 := common.HexToHash("0x1"), common.HexToHash("0x2")
+	newReceipt := func(hash common.Hash, gasUsed uint64) *ethTypes.Receipt {
+		return &ethTypes.Receipt{TxHash: hash, GasUsed: gasUsed, CumulativeGasUsed: gasUsed, Logs: []*ethTypes.Log{}}
+	}
+
+	_, err = s.GetPendingReceipt(ctx, hash1)
+	require.Equal(t, pool.ErrNotFound, err)
+
+	require.NoError(t, s.AddPendingReceipt(ctx, newReceipt(hash1, 21000)))
+	require.NoError(t, s.AddPendingReceipt(ctx, newReceipt(hash2, 21000)))
+	receipts, err := s.GetPendingReceiptsFrom(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+	require.Equal(t, hash1, receipts[0].Receipt.TxHash)
+	require.Equal(t, hash2, receipts[1].Receipt.TxHash)
+
+	// the republished receipts are returned again
+	require.NoError(t, s.AddPendingReceipt(ctx, newReceipt(hash1, 30000)))
+	receipts, err = s.GetPendingReceiptsFrom(ctx, receipts[1].ID)
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	require.Equal(t, hash1, receipts[0].Receipt.TxHash)
+
+	receipt, err := s.GetPendingReceipt(ctx, hash1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30000), receipt.GasUsed)
+
+	require.NoError(t, s.DeletePendingReceipts(ctx, []common.Hash{hash1, hash2}))
+	_, err = s.GetPendingReceipt(ctx, hash1)
+	require.Equal(t, pool.ErrNotFound, err)
+
+	// the receipts are deleted once they are older than their TTL
+	require.NoError(t, s.AddPendingReceipt(ctx, newReceipt(hash1, 21000)))
+	require.NoError(t, s.DeletePendingReceiptsOlderThan(ctx, time.Now().Add(-time.Hour)))
+	_, err = s.GetPendingReceipt(ctx, hash1)
+	require.NoError(t, err)
+	require.NoError(t, s.DeletePendingReceiptsOlderThan(ctx, time.Now().Add(time.Hour)))
+	_, err = s.GetPendingReceipt(ctx, hash1)
+	require.Equal(t, pool.ErrNotFound, err)
+
+	require.NoError(t, s.AddPendingReceipt(ctx, newReceipt(hash2, 21000)))
+	require.NoError(t, s.ClearPendingReceipts(ctx))
+	receipts, err = s.GetPendingReceiptsFrom(ctx, 0)
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+}
@@ -0,0 +1,197 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool/metrics"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// QuotaKind is the kind of key the quota is tracked by
+type QuotaKind string
+
+const (
+	// SenderQuota is the quota of the txs sent by an address
+	SenderQuota QuotaKind = "sender"
+	// IPQuota is the quota of the txs sent from an IP
+	IPQuota QuotaKind = "ip"
+	// APIKeyQuota is the quota of the txs sent with an API key
+	APIKeyQuota QuotaKind = "apikey"
+
+	// defaultQuotaTier is the name of the tier of the keys not assigned to any tier
+	defaultQuotaTier = "default"
+)
+
+type apiKeyCtxKey struct{}
+
+// WithAPIKey returns a copy of the context with the API key the tx was sent with, so the quota of the API key is
+// applied when the tx is added to the pool
+func WithAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, apiKey)
+}
+
+// apiKeyFromContext returns the API key the tx was sent with, empty if none
+func apiKeyFromContext(ctx context.Context) string {
+	apiKey, _ := ctx.Value(apiKeyCtxKey{}).(string)
+	return apiKey
+}
+
+type quotaKey struct {
+	kind QuotaKind
+	key  string
+}
+
+// quotaBucket is the token bucket of the txs and gas of a key
+type quotaBucket struct {
+	tier   string
+	limits QuotaLimitsCfg
+	txs    float64
+	gas    float64
+	last   time.Time
+}
+
+func newQuotaBucket(tier string, limits QuotaLimitsCfg, now time.Time) *quotaBucket {
+	return &quotaBucket{tier: tier, limits: limits, txs: float64(limits.TxsBurst), gas: float64(limits.GasBurst), last: now}
+}
+
+// refill adds the tokens earned since the last refill, up to the burst
+func (b *quotaBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.txs = math.Min(float64(b.limits.TxsBurst), b.txs+elapsed*b.limits.TxsPerSecond)
+	b.gas = math.Min(float64(b.limits.GasBurst), b.gas+elapsed*float64(b.limits.GasPerSecond))
+	b.last = now
+}
+
+// allows returns true if there are tokens left for a tx with the gas, the rates set to 0 aren't limited
+func (b *quotaBucket) allows(gas uint64) bool {
+	return (b.limits.TxsPerSecond == 0 || b.txs >= 1) && (b.limits.GasPerSecond == 0 || b.gas >= float64(gas))
+}
+
+func (b *quotaBucket) take(gas uint64) {
+	if b.limits.TxsPerSecond != 0 {
+		b.txs--
+	}
+	if b.limits.GasPerSecond != 0 {
+		b.gas -= float64(gas)
+	}
+}
+
+// full returns true if the bucket has all the tokens of its burst, so it can be forgotten
+func (b *quotaBucket) full() bool {
+	return b.txs >= float64(b.limits.TxsBurst) && b.gas >= float64(b.limits.GasBurst)
+}
+
+// quotas keeps the token buckets limiting the txs and gas that each sender, IP and API key can add to the pool
+type quotas struct {
+	cfg QuotaCfg
+	// tiers is the tier of the keys assigned to one
+	tiers map[quotaKey]*QuotaTierCfg
+
+	bucketsMux sync.Mutex
+	buckets    map[quotaKey]*quotaBucket
+	now        func() time.Time
+}
+
+func newQuotas(cfg QuotaCfg) *quotas {
+	q := &quotas{
+		cfg:     cfg,
+		tiers:   make(map[quotaKey]*QuotaTierCfg),
+		buckets: make(map[quotaKey]*quotaBucket),
+		now:     time.Now,
+	}
+	for i := range cfg.Tiers {
+		tier := &cfg.Tiers[i]
+		assign := func(key quotaKey) {
+			// the first tier a key is assigned to wins
+			if _, found := q.tiers[key]; !found {
+				q.tiers[key] = tier
+			}
+		}
+		for _, sender := range tier.Senders {
+			assign(quotaKey{kind: SenderQuota, key: sender.Hex()})
+		}
+		for _, ip := range tier.IPs {
+			assign(quotaKey{kind: IPQuota, key: ip})
+		}
+		for _, apiKey := range tier.APIKeys {
+			assign(quotaKey{kind: APIKeyQuota, key: apiKey})
+		}
+	}
+	return q
+}
+
+// take consumes a tx and its gas from the quotas of the sender, the IP and the API key, returning ErrQuotaExceeded
+// without consuming anything if any of them is exhausted. The IP and the API key aren't limited if empty.
+// The API keys are sent by the clients, so the ones not assigned to a tier are rejected with ErrUnknownAPIKey
+// instead of getting a new quota each
+func (q *quotas) take(sender common.Address, ip, apiKey string, gas uint64) error {
+	keys := []quotaKey{{kind: SenderQuota, key: sender.Hex()}}
+	if ip != "" {
+		keys = append(keys, quotaKey{kind: IPQuota, key: ip})
+	}
+	if apiKey != "" {
+		key := quotaKey{kind: APIKeyQuota, key: apiKey}
+		if _, found := q.tiers[key]; !found {
+			metrics.QuotaRejected(string(APIKeyQuota), defaultQuotaTier)
+			return ErrUnknownAPIKey
+		}
+		keys = append(keys, key)
+	}
+
+	q.bucketsMux.Lock()
+	defer q.bucketsMux.Unlock()
+
+	now := q.now()
+	buckets := make([]*quotaBucket, 0, len(keys))
+	for _, key := range keys {
+		bucket := q.bucket(key, now)
+		if !bucket.allows(gas) {
+			metrics.QuotaRejected(string(key.kind), bucket.tier)
+			return fmt.Errorf("%w: %s", ErrQuotaExceeded, key.kind)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	for i, bucket := range buckets {
+		bucket.take(gas)
+		metrics.QuotaUsed(string(keys[i].kind), bucket.tier, gas)
+	}
+	return nil
+}
+
+// bucket returns the refilled bucket of the key, creating it if it doesn't exist
+func (q *quotas) bucket(key quotaKey, now time.Time) *quotaBucket {
+	bucket, found := q.buckets[key]
+	if !found {
+		tier, limits := defaultQuotaTier, q.cfg.Default
+		if t, found := q.tiers[key]; found {
+			tier, limits = t.Name, t.Limits
+		}
+		bucket = newQuotaBucket(tier, limits, now)
+		q.buckets[key] = bucket
+		return bucket
+	}
+	bucket.refill(now)
+	return bucket
+}
+
+// cleanup forgets the buckets that have been refilled, as they are the same as a new one
+func (q *quotas) cleanup() {
+	q.bucketsMux.Lock()
+	defer q.bucketsMux.Unlock()
+
+	now := q.now()
+	for key, bucket := range q.buckets {
+		bucket.refill(now)
+		if bucket.full() {
+			delete(q.buckets, key)
+		}
+	}
+}
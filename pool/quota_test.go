@@ -0,0 +1,67 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotas(t *testing.T) {
+	sender, partner := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	q := newQuotas(QuotaCfg{
+		Default: QuotaLimitsCfg{TxsPerSecond: 1, TxsBurst: 2, GasPerSecond: 100, GasBurst: 300},
+		Tiers: []QuotaTierCfg{
+			{Name: "partners", Limits: QuotaLimitsCfg{TxsPerSecond: 10, TxsBurst: 10}, Senders: []common.Address{partner}, APIKeys: []string{"key"}},
+			{Name: "ignored", Senders: []common.Address{partner}},
+		},
+	})
+	now := time.Now()
+	q.now = func() time.Time { return now }
+
+	// the burst of txs of the sender
+	require.NoError(t, q.take(sender, "", "", 100))
+	require.NoError(t, q.take(sender, "", "", 100))
+	err := q.take(sender, "", "", 100)
+	assert.True(t, errors.Is(err, ErrQuotaExceeded))
+	assert.ErrorContains(t, err, string(SenderQuota))
+
+	// the txs are refilled over time, but the gas is also limited
+	now = now.Add(time.Second)
+	err = q.take(sender, "", "", 300)
+	assert.True(t, errors.Is(err, ErrQuotaExceeded))
+	require.NoError(t, q.take(sender, "", "", 200))
+
+	// the gas of the partners tier isn't limited
+	for i := 0; i < 10; i++ {
+		require.NoError(t, q.take(partner, "", "key", 1000000))
+	}
+	err = q.take(partner, "", "key", 0)
+	assert.True(t, errors.Is(err, ErrQuotaExceeded))
+
+	// the API keys not assigned to a tier don't get a quota of their own
+	assert.ErrorIs(t, q.take(sender, "", "rotated", 0), ErrUnknownAPIKey)
+	assert.NotContains(t, q.buckets, quotaKey{kind: APIKeyQuota, key: "rotated"})
+
+	// the IP has its own quota, and nothing is consumed if any quota is exceeded
+	other := common.HexToAddress("0x3")
+	require.NoError(t, q.take(other, "10.0.0.1", "", 0))
+	require.NoError(t, q.take(other, "10.0.0.1", "", 0))
+	err = q.take(common.HexToAddress("0x4"), "10.0.0.1", "", 0)
+	assert.ErrorContains(t, err, string(IPQuota))
+	assert.Equal(t, float64(2), q.buckets[quotaKey{kind: SenderQuota, key: common.HexToAddress("0x4").Hex()}].txs)
+
+	// the refilled buckets are forgotten
+	now = now.Add(time.Minute)
+	q.cleanup()
+	assert.Empty(t, q.buckets)
+}
+
+func TestAPIKeyFromContext(t *testing.T) {
+	assert.Equal(t, "", apiKeyFromContext(context.Background()))
+	assert.Equal(t, "key", apiKeyFromContext(WithAPIKey(context.Background(), "key")))
+}
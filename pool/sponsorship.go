@@ -0,0 +1,45 @@
+package pool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Sponsorships resolves the sponsor paying the fee of the txs sent with zero gas price to its target contracts
+type Sponsorships struct {
+	sponsors map[common.Address]SponsorCfg
+}
+
+// NewSponsorships creates the sponsorships of the config, no tx is sponsored if they are disabled
+func NewSponsorships(cfg SponsorshipCfg) *Sponsorships {
+	s := &Sponsorships{sponsors: make(map[common.Address]SponsorCfg)}
+	if !cfg.Enabled {
+		return s
+	}
+	for _, sponsor := range cfg.Sponsors {
+		for _, target := range sponsor.Targets {
+			// the first sponsor of a target pays for it
+			if _, found := s.sponsors[target]; !found {
+				s.sponsors[target] = sponsor
+			}
+		}
+	}
+	return s
+}
+
+// SponsorOf returns the name of the sponsor paying the fee of the tx, empty if the tx isn't sponsored
+func (s *Sponsorships) SponsorOf(tx types.Transaction) string {
+	sponsor, found := s.sponsorOf(tx)
+	if !found {
+		return ""
+	}
+	return sponsor.Name
+}
+
+func (s *Sponsorships) sponsorOf(tx types.Transaction) (SponsorCfg, bool) {
+	if tx.To() == nil || tx.GasPrice().Sign() != 0 {
+		return SponsorCfg{}, false
+	}
+	sponsor, found := s.sponsors[*tx.To()]
+	return sponsor, found
+}
@@ -0,0 +1,42 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSponsorships(t *testing.T) {
+	target, other := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	cfg := SponsorshipCfg{
+		Enabled: true,
+		Sponsors: []SponsorCfg{
+			{Name: "first", Targets: []common.Address{target}, GasBudget: 1000000},
+			{Name: "second", Targets: []common.Address{target, other}, GasBudget: 1000000},
+		},
+	}
+	s := NewSponsorships(cfg)
+
+	testCases := []struct {
+		name     string
+		tx       *types.Transaction
+		expected string
+	}{
+		{"zero gas price to target", types.NewTransaction(0, target, big.NewInt(0), 21000, big.NewInt(0), nil), "first"},
+		{"zero gas price to other target", types.NewTransaction(0, other, big.NewInt(0), 21000, big.NewInt(0), nil), "second"},
+		{"paid tx to target", types.NewTransaction(0, target, big.NewInt(0), 21000, big.NewInt(1), nil), ""},
+		{"zero gas price to not sponsored", types.NewTransaction(0, common.HexToAddress("0x3"), big.NewInt(0), 21000, big.NewInt(0), nil), ""},
+		{"zero gas price deployment", types.NewContractCreation(0, big.NewInt(0), 21000, big.NewInt(0), nil), ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, s.SponsorOf(*tc.tx))
+		})
+	}
+
+	cfg.Enabled = false
+	assert.Equal(t, "", NewSponsorships(cfg).SponsorOf(*testCases[0].tx))
+}
@@ -35,6 +35,13 @@ type TxStatusUpdateInfo struct {
 	FailedReason *string
 }
 
+// PendingReceipt is the receipt of a tx added to the WIP L2 block of the sequencer before the L2 block is stored
+type PendingReceipt struct {
+	// ID is the sequential id of the receipt, assigned every time the receipt of the tx is published
+	ID      uint64
+	Receipt *types.Receipt
+}
+
 // Transaction represents a pool tx
 type Transaction struct {
 	types.Transaction
@@ -30,12 +30,37 @@ type Batch struct {
 	finalHighReservedZKCounters state.ZKCounters
 	closingReason               state.ClosingReason
 	finalLocalExitRoot          common.Hash
+	imLanesUsedResources        map[string]state.BatchResources // resources used by each priority lane when processing tx-by-tx
+	finalLanesUsedResources     map[string]state.BatchResources // resources used by each priority lane when a L2 block is processed
 }
 
 func (b *Batch) isEmpty() bool {
 	return b.countOfL2Blocks == 0
 }
 
+// addLaneUsedResources adds to lanesUsedResources the resources used by a tx of the priority lane, returning the updated map
+func addLaneUsedResources(lanesUsedResources map[string]state.BatchResources, lane string, used state.BatchResources) map[string]state.BatchResources {
+	if lanesUsedResources == nil {
+		lanesUsedResources = make(map[string]state.BatchResources)
+	}
+	laneResources := lanesUsedResources[lane]
+	laneResources.SumUp(used)
+	lanesUsedResources[lane] = laneResources
+	return lanesUsedResources
+}
+
+// copyLanesUsedResources returns a copy of the resources used by each priority lane
+func copyLanesUsedResources(lanesUsedResources map[string]state.BatchResources) map[string]state.BatchResources {
+	if lanesUsedResources == nil {
+		return nil
+	}
+	lanesCopy := make(map[string]state.BatchResources, len(lanesUsedResources))
+	for lane, resources := range lanesUsedResources {
+		lanesCopy[lane] = resources
+	}
+	return lanesCopy
+}
+
 // processBatchesPendingtoCheck performs a sanity check for batches closed but pending to be checked
 func (f *finalizer) processBatchesPendingtoCheck(ctx context.Context) {
 	notCheckedBatches, err := f.stateIntf.GetNotCheckedBatches(ctx, nil)
@@ -166,8 +191,20 @@ func (f *finalizer) processL2BlockReorg(ctx context.Context) error {
 
 	f.workerIntf.RestoreTxsPendingToStore(ctx)
 
+	// The pending receipts left are the ones of the txs of the L2 blocks not stored, which are reprocessed
+	if f.cfg.PublishPendingReceipts {
+		f.pendingReceipts <- pendingReceiptsToClear{}
+	}
+
+	prevWIPBatch := f.wipBatch
 	f.initWIPBatch(ctx)
 
+	// The resources of the priority lanes are rolled back to the ones used by the processed L2 blocks, as the reorged txs are processed again
+	if prevWIPBatch != nil && prevWIPBatch.batchNumber == f.wipBatch.batchNumber {
+		f.wipBatch.finalLanesUsedResources = copyLanesUsedResources(prevWIPBatch.finalLanesUsedResources)
+		f.wipBatch.imLanesUsedResources = copyLanesUsedResources(prevWIPBatch.finalLanesUsedResources)
+	}
+
 	f.initWIPL2Block(ctx)
 
 	// Since when processing the L2 block reorg we sync the state root we can reset next state root syncing
@@ -206,10 +243,15 @@ func (f *finalizer) finalizeSIPBatch(ctx context.Context) error {
 		return fmt.Errorf("error creating db transaction to close sip batch %d, error: %v", f.sipBatch.batchNumber, err)
 	}
 
-	// Close sip batch (close in statedb)
-	err = f.closeSIPBatch(ctx, dbTx)
+	// Check this instance is still the leader and close sip batch (close in statedb)
+	err = f.checkLease(ctx, dbTx)
 	if err != nil {
-		return fmt.Errorf("failed to close sip batch %d, error: %v", f.sipBatch.batchNumber, err)
+		err = fmt.Errorf("error checking the sequencer lease to close sip batch %d, error: %w", f.sipBatch.batchNumber, err)
+	} else {
+		err = f.closeSIPBatch(ctx, dbTx)
+		if err != nil {
+			err = fmt.Errorf("failed to close sip batch %d, error: %v", f.sipBatch.batchNumber, err)
+		}
 	}
 
 	if err != nil {
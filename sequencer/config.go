@@ -29,11 +29,62 @@ type Config struct {
 	// L2Coinbase defines which address is going to receive the fees. It gets the config value from SequenceSender.L2Coinbase
 	L2Coinbase common.Address `mapstructure:"L2Coinbase"`
 
+	// TxOrdering is the strategy used to choose the next tx to add to the batch among the ready txs. It can be
+	// "gasprice" (highest gas price first), "fifo" (first received by the pool first), "efficiency" (highest fee
+	// per share of the batch ZK counters used first) or "roundrobin" (one tx per sender in turns)
+	TxOrdering TxOrdering `mapstructure:"TxOrdering"`
+
+	// PriorityLanes reserves a share of each batch for the txs sent by or to the members of the lanes
+	PriorityLanes PriorityLanesCfg `mapstructure:"PriorityLanes"`
+
 	// Finalizer's specific config properties
 	Finalizer FinalizerCfg `mapstructure:"Finalizer"`
 
 	// StreamServerCfg is the config for the stream server
 	StreamServer StreamServerCfg `mapstructure:"StreamServer"`
+
+	// HA is the config to run several sequencer instances in active/passive mode
+	HA HACfg `mapstructure:"HA"`
+}
+
+// HACfg contains the configuration properties of the sequencer high availability. The instances compete for a lease
+// stored in the state DB, the one holding it sequences while the rest wait as standby to take over when it expires
+type HACfg struct {
+	// Enabled indicates if the sequencer must hold the lease to sequence
+	Enabled bool `mapstructure:"Enabled"`
+
+	// InstanceID identifies the instance as holder of the lease, it must be unique among the sequencer instances.
+	// The hostname is used if it's empty
+	InstanceID string `mapstructure:"InstanceID"`
+
+	// LeaseDuration is the time the lease is held without being renewed, after which a standby can take over
+	LeaseDuration types.Duration `mapstructure:"LeaseDuration"`
+
+	// RenewInterval is the time interval to renew the lease, it must be lower than LeaseDuration
+	RenewInterval types.Duration `mapstructure:"RenewInterval"`
+
+	// AcquireInterval is the time interval a standby tries to acquire the lease and updates its data stream
+	AcquireInterval types.Duration `mapstructure:"AcquireInterval"`
+}
+
+// PriorityLanesCfg contains the priority lanes configuration properties
+type PriorityLanesCfg struct {
+	// Lanes are the priority lanes, a tx whose sender and target are members of different lanes is processed in the
+	// first of them
+	Lanes []PriorityLaneCfg `mapstructure:"Lanes"`
+
+	// RefreshInterval is the time interval to reload the members of the lanes from the pool
+	RefreshInterval types.Duration `mapstructure:"RefreshInterval"`
+}
+
+// PriorityLaneCfg contains the configuration properties of a priority lane
+type PriorityLaneCfg struct {
+	// Name of the lane, its members are managed with the policy lane command
+	Name string `mapstructure:"Name"`
+
+	// ReservedPct is the percentage of the ZK counters and bytes of each batch reserved for the txs of the lane. The
+	// reserved resources not used by the lane are kept free, so the batch may be closed before being full
+	ReservedPct uint32 `mapstructure:"ReservedPct"`
 }
 
 // StreamServerCfg contains the data streamer's configuration properties
@@ -104,6 +155,14 @@ type FinalizerCfg struct {
 	// in the processPendingL2Blocks go func
 	SequentialProcessL2Block bool `mapstructure:"SequentialProcessL2Block"`
 
+	// PublishPendingReceipts indicates if the receipt of a tx must be published in the pool as soon as the tx is added
+	// to the wip L2 block, so the RPC can return it before the L2 block is stored
+	PublishPendingReceipts bool `mapstructure:"PublishPendingReceipts"`
+
+	// PendingReceiptsTTL is the time the pending receipts are kept in the pool if their txs aren't stored. The
+	// pending receipts older than it are deleted with this same frequency
+	PendingReceiptsTTL types.Duration `mapstructure:"PendingReceiptsTTL"`
+
 	// Metrics is the config for the sequencer metrics
 	Metrics MetricsCfg `mapstructure:"Metrics"`
 }
@@ -20,10 +20,13 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
 )
 
 const (
 	pendingL2BlocksBufferSize = 100
+	pendingReceiptsBufferSize = 1000
 	changeL2BlockSize         = 9 //1 byte (tx type = 0B) + 4 bytes for deltaTimestamp + 4 for l1InfoTreeIndex
 )
 
@@ -86,8 +89,22 @@ type finalizer struct {
 	streamServer      *datastreamer.StreamServer
 	dataToStream      chan interface{}
 	dataToStreamCount atomic.Int32
+	// sequencer lease, nil if HA is disabled
+	lease *sequencerLease
+	// pending receipts to publish in the pool and their deletions, done in order by publishPendingReceipts
+	pendingReceipts chan interface{}
 }
 
+// pendingReceiptsToDelete are the pending receipts of the txs of a stored L2 block, to delete from the pool
+type pendingReceiptsToDelete struct {
+	blockNumber uint64
+	trackingNum uint64
+	txHashes    []common.Hash
+}
+
+// pendingReceiptsToClear clears all the pending receipts of the pool, which belong to reorged txs
+type pendingReceiptsToClear struct{}
+
 // newFinalizer returns a new instance of Finalizer.
 func newFinalizer(
 	cfg FinalizerCfg,
@@ -103,6 +120,7 @@ func newFinalizer(
 	streamServer *datastreamer.StreamServer,
 	workerReadyTxsCond *timeoutCond,
 	dataToStream chan interface{},
+	lease *sequencerLease,
 ) *finalizer {
 	f := finalizer{
 		cfg:              cfg,
@@ -146,6 +164,11 @@ func newFinalizer(
 		// stream server
 		streamServer: streamServer,
 		dataToStream: dataToStream,
+		// sequencer lease
+		lease: lease,
+	}
+	if cfg.PublishPendingReceipts {
+		f.pendingReceipts = make(chan interface{}, pendingReceiptsBufferSize)
 	}
 
 	f.l2BlockReorg.Store(false)
@@ -180,6 +203,11 @@ func (f *finalizer) Start(ctx context.Context) {
 	// Foced batches checking
 	go f.checkForcedBatches(ctx)
 
+	// Publish pending receipts
+	if f.cfg.PublishPendingReceipts {
+		go f.publishPendingReceipts(ctx)
+	}
+
 	// Processing transactions and finalizing batches
 	f.finalizeBatches(ctx)
 }
@@ -404,7 +432,7 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 			f.finalizeWIPL2Block(ctx)
 		}
 
-		tx, err := f.workerIntf.GetBestFittingTx(f.wipBatch.imRemainingResources, f.wipBatch.imHighReservedZKCounters)
+		tx, err := f.workerIntf.GetBestFittingTx(f.wipBatch.imRemainingResources, f.wipBatch.imHighReservedZKCounters, f.wipBatch.imLanesUsedResources)
 
 		// If we have txs pending to process but none of them fits into the wip batch, we close the wip batch and open a new one
 		if err == ErrNoFittingTransaction {
@@ -496,7 +524,14 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 	txGasPrice := tx.GasPrice
 
 	// If it is the first time we process this tx then we calculate the EffectiveGasPrice
-	if firstTxProcess {
+	if firstTxProcess && tx.Sponsor != "" {
+		// The fee of the sponsored txs is paid by their sponsor, so they are processed with their zero gas price
+		tx.L1GasPrice, tx.L2GasPrice = f.poolIntf.GetL1AndL2GasPrice()
+		tx.EGPLog.L1GasPrice = tx.L1GasPrice
+		tx.EGPLog.L2GasPrice = tx.L2GasPrice
+		tx.EffectiveGasPrice.SetUint64(0)
+		tx.IsLastExecution = true
+	} else if firstTxProcess {
 		// Get L1 gas price and store in txTracker to make it consistent during the lifespan of the transaction
 		tx.L1GasPrice, tx.L2GasPrice = f.poolIntf.GetL1AndL2GasPrice()
 		// Get the tx and l2 gas price we will use in the egp calculation. If egp is disabled we will use a "simulated" tx gas price
@@ -538,7 +573,7 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 	}
 
 	egpPercentage, err := state.CalculateEffectiveGasPricePercentage(txGasPrice, tx.EffectiveGasPrice)
-	if err != nil {
+	if err != nil && tx.Sponsor == "" {
 		if f.effectiveGasPrice.IsEnabled() {
 			return nil, err
 		} else {
@@ -550,8 +585,8 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 		tx.EGPLog.Percentage = egpPercentage
 	}
 
-	// If EGP is disabled we use tx GasPrice (MaxEffectivePercentage=255)
-	if !f.effectiveGasPrice.IsEnabled() {
+	// If EGP is disabled or the tx is sponsored we use tx GasPrice (MaxEffectivePercentage=255)
+	if !f.effectiveGasPrice.IsEnabled() || tx.Sponsor != "" {
 		egpPercentage = state.MaxEffectivePercentage
 	}
 
@@ -587,6 +622,8 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 		err = f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusInvalid, false, &errMsg)
 		if err != nil {
 			log.Errorf("failed to update status to invalid in the pool for tx %s, error: %v", tx.Hash.String(), err)
+		} else {
+			refundSponsorGas(ctx, f.poolIntf, tx, tx.Gas)
 		}
 		return nil, err
 	}
@@ -704,6 +741,8 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 			err = f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusInvalid, false, &errMsg)
 			if err != nil {
 				log.Errorf("failed to update status to invalid in the pool for tx %s, error: %v", tx.Hash.String(), err)
+			} else {
+				refundSponsorGas(ctx, f.poolIntf, tx, tx.Gas)
 			}
 
 			return nil, ErrBatchResourceOverFlow, state.ZKCounters{}
@@ -717,6 +756,11 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 		return nil, ErrBatchResourceOverFlow, state.ZKCounters{}
 	}
 
+	// Update the resources used by the priority lane of the tx, once it's added to the batch
+	if tx.Lane != "" {
+		f.wipBatch.imLanesUsedResources = addLaneUsedResources(f.wipBatch.imLanesUsedResources, tx.Lane, state.BatchResources{ZKCounters: result.UsedZkCounters, Bytes: uint64(len(tx.RawTx))})
+	}
+
 	// Save Enabled, GasPriceOC, BalanceOC and final effective gas price for later logging
 	tx.EGPLog.Enabled = egpEnabled
 	tx.EGPLog.GasPriceOC = txResponse.HasGaspriceOpcode
@@ -737,13 +781,75 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 	// Update metrics
 	f.wipL2Block.metrics.gas += txResponse.GasUsed
 
+	// Publish the receipt of the tx before the wip L2 block is stored. It's published in the background, in order
+	// with the deletion of the pending receipts once the L2 block is stored
+	if f.cfg.PublishPendingReceipts {
+		f.pendingReceipts <- f.newPendingReceipt(txResponse)
+	}
+
 	return nil, nil, neededZKCounters
 }
 
+// newPendingReceipt returns the receipt of the last tx added to the wip L2 block. The receipt has no block number nor
+// hash as the L2 block is still open
+func (f *finalizer) newPendingReceipt(txResponse *state.ProcessTransactionResponse) *types.Receipt {
+	forkID := f.stateIntf.GetForkIDByBatchNumber(f.wipBatch.batchNumber)
+	receipt := state.GenerateReceipt(nil, txResponse, uint(len(f.wipL2Block.transactions)-1), forkID)
+	if forkID > state.FORKID_ETROG {
+		// The tx has been executed alone, so the cumulative gas used is the one of all the txs of the wip L2 block
+		receipt.CumulativeGasUsed = f.wipL2Block.metrics.gas
+	}
+
+	// The receipts are published as JSON, that requires the logs and their topics
+	if receipt.Logs == nil {
+		receipt.Logs = []*types.Log{}
+	}
+	for _, l := range receipt.Logs {
+		if l.Topics == nil {
+			l.Topics = []common.Hash{}
+		}
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+	return receipt
+}
+
+// publishPendingReceipts publishes in the pool the receipts of the txs added to the wip L2 block, and deletes them
+// once their L2 block is stored or reorged. The pool is updated in the same order the finalizer sends the changes,
+// so a receipt can't be published after it's deleted
+func (f *finalizer) publishPendingReceipts(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data := <-f.pendingReceipts:
+			switch data := data.(type) {
+			case *types.Receipt:
+				if err := f.poolIntf.AddPendingReceipt(ctx, data); err != nil {
+					log.Errorf("failed to publish the pending receipt of tx %s, error: %v", data.TxHash.String(), err)
+				}
+			case pendingReceiptsToDelete:
+				if err := f.poolIntf.DeletePendingReceipts(ctx, data.txHashes); err != nil {
+					log.Errorf("error deleting the pending receipts of L2 block %d [%d], error: %v", data.blockNumber, data.trackingNum, err)
+				}
+			case pendingReceiptsToClear:
+				if err := f.poolIntf.ClearPendingReceipts(ctx); err != nil {
+					log.Errorf("error clearing the pending receipts of the reorged txs, error: %v", err)
+				}
+			}
+		}
+	}
+}
+
 // compareTxEffectiveGasPrice compares newEffectiveGasPrice with tx.EffectiveGasPrice.
 // It returns ErrEffectiveGasPriceReprocess if the tx needs to be reprocessed with
 // the tx.EffectiveGasPrice updated, otherwise it returns nil
 func (f *finalizer) compareTxEffectiveGasPrice(ctx context.Context, tx *TxTracker, newEffectiveGasPrice *big.Int, hasGasPriceOC bool, hasBalanceOC bool) error {
+	// The sponsored txs keep their zero effective gas price whatever the gas they use
+	if tx.Sponsor != "" {
+		return nil
+	}
+
 	// Get the tx gas price we will use in the egp calculation. If egp is disabled we will use a "simulated" tx gas price
 	txGasPrice, _ := f.effectiveGasPrice.GetTxAndL2GasPrice(tx.GasPrice, tx.L1GasPrice, tx.L2GasPrice)
 
@@ -802,6 +908,18 @@ func (f *finalizer) updateWorkerAfterSuccessfulProcessing(ctx context.Context, t
 			log.Errorf("failed to update status to failed in the pool for tx %s, error: %v", txToDelete.Hash.String(), err)
 			continue
 		}
+		refundSponsorGas(ctx, f.poolIntf, txToDelete, txToDelete.Gas)
+	}
+}
+
+// refundSponsorGas refunds the sponsor of the tx the gas reserved for it that won't be used. The txs added to a block
+// are refunded the gas they haven't used, and the txs that fail, expire or are replaced all their gas
+func refundSponsorGas(ctx context.Context, poolIntf txPool, tx *TxTracker, gas uint64) {
+	if tx.Sponsor == "" || gas == 0 {
+		return
+	}
+	if err := poolIntf.RefundSponsorGas(ctx, tx.Sponsor, gas); err != nil {
+		log.Errorf("failed to refund %d gas to the sponsor %s of tx %s, error: %v", gas, tx.Sponsor, tx.HashStr, err)
 	}
 }
 
@@ -824,6 +942,8 @@ func (f *finalizer) handleProcessTransactionError(ctx context.Context, result *s
 			err := f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusInvalid, false, &failedReason)
 			if err != nil {
 				log.Errorf("failed to update status to invalid in the pool for tx %s, error: %v", tx.HashStr, err)
+			} else {
+				refundSponsorGas(ctx, f.poolIntf, tx, tx.Gas)
 			}
 		}()
 	} else if executor.IsInvalidNonceError(errorCode) || executor.IsInvalidBalanceError(errorCode) {
@@ -845,6 +965,8 @@ func (f *finalizer) handleProcessTransactionError(ctx context.Context, result *s
 				err := f.poolIntf.UpdateTxStatus(ctx, txToDelete.Hash, pool.TxStatusFailed, false, &failedReason)
 				if err != nil {
 					log.Errorf("failed to update status to failed in the pool for tx %s, error: %v", txToDelete.Hash.String(), err)
+				} else {
+					refundSponsorGas(ctx, f.poolIntf, txToDelete, txToDelete.Gas)
 				}
 			}()
 		}
@@ -860,6 +982,8 @@ func (f *finalizer) handleProcessTransactionError(ctx context.Context, result *s
 			err := f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusFailed, false, &failedReason)
 			if err != nil {
 				log.Errorf("failed to update status to failed in the pool for tx %s, error: %v", tx.Hash.String(), err)
+			} else {
+				refundSponsorGas(ctx, f.poolIntf, tx, tx.Gas)
 			}
 		}()
 	}
@@ -908,6 +1032,15 @@ func (f *finalizer) Halt(ctx context.Context, err error, isFatal bool) {
 	}
 }
 
+// checkLease returns an error if the sequencer lease isn't held anymore when HA is enabled, so a db tx is only
+// committed while this instance is the leader
+func (f *finalizer) checkLease(ctx context.Context, dbTx pgx.Tx) error {
+	if f.lease == nil {
+		return nil
+	}
+	return f.lease.check(ctx, dbTx)
+}
+
 // LogEvent adds an event for runtime debugging
 func (f *finalizer) LogEvent(ctx context.Context, level event.Level, eventId event.EventID, description string, json interface{}) {
 	event := &event.Event{
@@ -3,6 +3,7 @@ package sequencer
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -13,7 +14,9 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -117,7 +120,7 @@ func TestNewFinalizer(t *testing.T) {
 	poolMock.On("GetLastSentFlushID", context.Background()).Return(uint64(0), nil)
 
 	// arrange and act
-	f = newFinalizer(cfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, l2Coinbase, isSynced, bc, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil)
+	f = newFinalizer(cfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, l2Coinbase, isSynced, bc, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil, nil)
 
 	// assert
 	assert.NotNil(t, f)
@@ -2075,6 +2078,165 @@ func TestFinalizer_setNextForcedBatchDeadline(t *testing.T) {
 	assert.Equal(t, expected, f.nextForcedBatchDeadline)
 }
 
+func TestFinalizer_compareTxEffectiveGasPriceSponsoredTx(t *testing.T) {
+	// arrange
+	f = setupFinalizer(false)
+	tx := &TxTracker{
+		GasPrice:          big.NewInt(0),
+		EffectiveGasPrice: big.NewInt(0),
+		Sponsor:           "sponsor",
+	}
+
+	// act
+	err := f.compareTxEffectiveGasPrice(context.Background(), tx, big.NewInt(1000), false, false)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), tx.EffectiveGasPrice)
+}
+
+func Test_refundSponsorGas(t *testing.T) {
+	// arrange
+	f = setupFinalizer(false)
+	ctx := context.Background()
+	poolMock.On("RefundSponsorGas", ctx, "sponsor", uint64(5000)).Return(nil).Once()
+
+	// act
+	refundSponsorGas(ctx, f.poolIntf, &TxTracker{HashStr: "0x1", Sponsor: "sponsor"}, 5000)
+	// the txs not sponsored and the sponsored txs that have used all their gas aren't refunded
+	refundSponsorGas(ctx, f.poolIntf, &TxTracker{HashStr: "0x2"}, 5000)
+	refundSponsorGas(ctx, f.poolIntf, &TxTracker{HashStr: "0x3", Sponsor: "sponsor"}, 0)
+
+	// assert
+	poolMock.AssertExpectations(t)
+}
+
+func TestFinalizer_handleProcessTransactionErrorRefundsSponsorGas(t *testing.T) {
+	testCases := []struct {
+		name           string
+		romError       executor.RomError
+		expectedStatus pool.TxStatus
+	}{
+		{"failed tx", executor.RomError_ROM_ERROR_OUT_OF_GAS, pool.TxStatusFailed},
+		{"invalid tx", executor.RomError_ROM_ERROR_OUT_OF_COUNTERS_STEP, pool.TxStatusInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			f = setupFinalizer(true)
+			f.wipL2Block = &L2Block{}
+			ctx := context.Background()
+			tx := &TxTracker{Hash: oldHash, HashStr: oldHash.String(), From: senderAddr, Gas: 50000, Sponsor: "sponsor"}
+			result := &state.ProcessBatchResponse{
+				BlockResponses: []*state.ProcessBlockResponse{{
+					TransactionResponses: []*state.ProcessTransactionResponse{{TxHash: oldHash, RomError: executor.RomErr(tc.romError), GasUsed: 21000}},
+				}},
+			}
+			workerMock.On("DeleteTx", oldHash, senderAddr).Once()
+			poolMock.On("UpdateTxStatus", ctx, oldHash, tc.expectedStatus, false, mock.Anything).Return(nil).Once()
+			poolMock.On("RefundSponsorGas", ctx, "sponsor", uint64(50000)).Return(nil).Once()
+
+			// act
+			f.handleProcessTransactionError(ctx, result, tx).Wait()
+
+			// assert
+			workerMock.AssertExpectations(t)
+			poolMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFinalizer_updateWorkerAfterSuccessfulProcessingRefundsSponsorGas(t *testing.T) {
+	// arrange
+	f = setupFinalizer(false)
+	ctx := context.Background()
+	result := &state.ProcessBatchResponse{ReadWriteAddresses: map[common.Address]*state.InfoReadWrite{}}
+	txToDelete := &TxTracker{Hash: newHash, HashStr: newHash.String(), From: receiverAddr, Gas: 50000, Sponsor: "sponsor", FailedReason: &testErrStr}
+	workerMock.On("MoveTxPendingToStore", oldHash, senderAddr).Once()
+	workerMock.On("UpdateAfterSingleSuccessfulTxExecution", senderAddr, result.ReadWriteAddresses).Return([]*TxTracker{txToDelete}).Once()
+	poolMock.On("UpdateTxStatus", ctx, newHash, pool.TxStatusFailed, false, &testErrStr).Return(nil).Once()
+	poolMock.On("RefundSponsorGas", ctx, "sponsor", uint64(50000)).Return(nil).Once()
+
+	// act
+	f.updateWorkerAfterSuccessfulProcessing(ctx, oldHash, senderAddr, false, result)
+
+	// assert
+	workerMock.AssertExpectations(t)
+	poolMock.AssertExpectations(t)
+}
+
+func TestFinalizer_newPendingReceipt(t *testing.T) {
+	// arrange
+	f = setupFinalizer(false)
+	f.wipBatch = &Batch{batchNumber: 1}
+	f.wipL2Block = &L2Block{transactions: []*TxTracker{{}, {}}}
+	f.wipL2Block.metrics.gas = 71000
+	stateMock.On("GetForkIDByBatchNumber", uint64(1)).Return(uint64(state.FORKID_ELDERBERRY)).Once()
+	tx := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil)
+	txResponse := &state.ProcessTransactionResponse{
+		Tx:                *tx,
+		TxHash:            tx.Hash(),
+		GasUsed:           21000,
+		CumulativeGasUsed: 21000,
+		Status:            uint32(types.ReceiptStatusSuccessful),
+		Logs:              []*types.Log{{Address: common.HexToAddress("0x2")}},
+	}
+
+	// act
+	receipt := f.newPendingReceipt(txResponse)
+
+	// assert
+	assert.Equal(t, tx.Hash(), receipt.TxHash)
+	assert.Nil(t, receipt.BlockNumber)
+	assert.Equal(t, uint(1), receipt.TransactionIndex)
+	assert.Equal(t, uint64(71000), receipt.CumulativeGasUsed)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	assert.Equal(t, []common.Hash{}, receipt.Logs[0].Topics)
+	assert.True(t, receipt.Bloom.Test(common.HexToAddress("0x2").Bytes()))
+	stateMock.AssertExpectations(t)
+}
+
+func TestFinalizer_publishPendingReceipts(t *testing.T) {
+	// arrange
+	f = setupFinalizer(false)
+	f.pendingReceipts = make(chan interface{}, pendingReceiptsBufferSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	receipt1 := &types.Receipt{TxHash: common.HexToHash("0x1")}
+	receipt2 := &types.Receipt{TxHash: common.HexToHash("0x2")}
+
+	var mu sync.Mutex
+	ops := []string{}
+	record := func(op string) func(mock.Arguments) {
+		return func(mock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+			ops = append(ops, op)
+		}
+	}
+	poolMock.On("AddPendingReceipt", ctx, receipt1).Run(record("add 0x1")).Return(nil).Once()
+	poolMock.On("DeletePendingReceipts", ctx, []common.Hash{receipt1.TxHash}).Run(record("delete 0x1")).Return(nil).Once()
+	poolMock.On("AddPendingReceipt", ctx, receipt2).Run(record("add 0x2")).Return(nil).Once()
+	poolMock.On("ClearPendingReceipts", ctx).Run(record("clear")).Return(nil).Once()
+
+	// act
+	f.pendingReceipts <- receipt1
+	f.pendingReceipts <- pendingReceiptsToDelete{blockNumber: 1, txHashes: []common.Hash{receipt1.TxHash}}
+	f.pendingReceipts <- receipt2
+	f.pendingReceipts <- pendingReceiptsToClear{}
+	go f.publishPendingReceipts(ctx)
+
+	// assert
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(ops) == 4
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"add 0x1", "delete 0x1", "add 0x2", "clear"}, ops)
+	poolMock.AssertExpectations(t)
+}
+
 func TestFinalizer_getConstraintThresholdUint64(t *testing.T) {
 	// arrange
 	f = setupFinalizer(false)
@@ -74,6 +74,12 @@ func (f *finalizer) processForcedBatch(ctx context.Context, forcedBatch state.Fo
 		return lastBatchNumber, stateRoot, "", retError
 	}
 
+	// Check this instance is still the leader, keeping the lease until the forced batch is stored
+	err = f.checkLease(ctx, dbTx)
+	if err != nil {
+		return rollbackOnError(fmt.Errorf("error checking the sequencer lease to process forced batch %d, error: %w", forcedBatch.ForcedBatchNumber, err))
+	}
+
 	// Get L1 block for the forced batch
 	fbL1Block, err := f.stateIntf.GetBlockByNumber(ctx, forcedBatch.BlockNumber, dbTx)
 	if err != nil {
@@ -29,6 +29,12 @@ type txPool interface {
 	GetDefaultMinGasPriceAllowed() uint64
 	GetL1AndL2GasPrice() (uint64, uint64)
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	GetPriorityLaneMembers(ctx context.Context) ([]pool.PriorityLaneMember, error)
+	RefundSponsorGas(ctx context.Context, sponsor string, gas uint64) error
+	AddPendingReceipt(ctx context.Context, receipt *types.Receipt) error
+	DeletePendingReceipts(ctx context.Context, hashes []common.Hash) error
+	DeletePendingReceiptsOlderThan(ctx context.Context, date time.Time) error
+	ClearPendingReceipts(ctx context.Context) error
 }
 
 // ethermanInterface contains the methods required to interact with ethereum.
@@ -81,10 +87,14 @@ type stateInterface interface {
 	GetL1InfoRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)
 	GetLatestBatchGlobalExitRoot(ctx context.Context, dbTx pgx.Tx) (common.Hash, error)
 	GetNotCheckedBatches(ctx context.Context, dbTx pgx.Tx) ([]*state.Batch, error)
+	TryAcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, bool, error)
+	RenewSequencerLease(ctx context.Context, holder string, epoch uint64, duration time.Duration, dbTx pgx.Tx) error
+	CheckSequencerLease(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx) error
+	ReleaseSequencerLease(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx) error
 }
 
 type workerInterface interface {
-	GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters) (*TxTracker, error)
+	GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters, lanesUsedResources map[string]state.BatchResources) (*TxTracker, error)
 	UpdateAfterSingleSuccessfulTxExecution(from common.Address, touchedAddresses map[common.Address]*state.InfoReadWrite) []*TxTracker
 	UpdateTxZKCounters(txHash common.Hash, from common.Address, usedZKCounters state.ZKCounters, reservedZKCounters state.ZKCounters)
 	AddTxTracker(ctx context.Context, txTracker *TxTracker) (replacedTx *TxTracker, dropReason error)
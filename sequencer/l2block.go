@@ -250,6 +250,13 @@ func (f *finalizer) processL2Block(ctx context.Context, l2Block *L2Block) error
 
 		l2Block.batch.finalHighReservedZKCounters = newHighZKCounters
 		l2Block.highReservedZKCounters = l2Block.batch.finalHighReservedZKCounters
+
+		// Update the resources used by the priority lanes of the batch
+		for _, tx := range l2Block.transactions {
+			if tx.Lane != "" {
+				l2Block.batch.finalLanesUsedResources = addLaneUsedResources(l2Block.batch.finalLanesUsedResources, tx.Lane, state.BatchResources{ZKCounters: tx.UsedZKCounters, Bytes: uint64(len(tx.RawTx))})
+			}
+		}
 	} else {
 		overflowLog := fmt.Sprintf("L2 block %d [%d] needed resources exceeds the remaining batch %d resources, overflow resource: %s, batch bytes: %d, L2 block bytes: %d, counters: {batch: %s, used: %s, reserved: %s, needed: %s, high: %s}",
 			blockResponse.BlockNumber, l2Block.trackingNum, l2Block.batch.batchNumber, overflowResource, l2Block.batch.finalRemainingResources.Bytes, batchL2DataSize,
@@ -399,6 +406,12 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 		return retError
 	}
 
+	// Check this instance is still the leader, keeping the lease until the L2 block is stored
+	err = f.checkLease(ctx, dbTx)
+	if err != nil {
+		return rollbackOnError(fmt.Errorf("error checking the sequencer lease to store L2 block %d [%d], error: %w", blockResponse.BlockNumber, l2Block.trackingNum, err))
+	}
+
 	if (f.sipBatch == nil) || (f.sipBatch.batchNumber != l2Block.batch.batchNumber) {
 		// We have l2 blocks to store from a new batch, therefore we insert this new batch in the statedb
 		// First we need to close the current sipBatch
@@ -483,12 +496,26 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 	}
 
 	// Update txs status in the pool
-	for _, txResponse := range blockResponse.TransactionResponses {
+	for i, txResponse := range blockResponse.TransactionResponses {
 		// Change Tx status to selected
 		err = f.poolIntf.UpdateTxStatus(ctx, txResponse.TxHash, pool.TxStatusSelected, false, nil)
 		if err != nil {
 			return err
 		}
+
+		// Refund the sponsor the gas reserved for the tx that it hasn't used, now that the tx can't be reprocessed
+		if tx := l2Block.transactions[i]; tx.Gas > txResponse.GasUsed {
+			refundSponsorGas(ctx, f.poolIntf, tx, tx.Gas-txResponse.GasUsed)
+		}
+	}
+
+	// Delete the pending receipts of the txs as their receipts are now stored in the state
+	if f.cfg.PublishPendingReceipts && len(blockResponse.TransactionResponses) > 0 {
+		hashes := make([]common.Hash, 0, len(blockResponse.TransactionResponses))
+		for _, txResponse := range blockResponse.TransactionResponses {
+			hashes = append(hashes, txResponse.TxHash)
+		}
+		f.pendingReceipts <- pendingReceiptsToDelete{blockNumber: blockResponse.BlockNumber, trackingNum: l2Block.trackingNum, txHashes: hashes}
 	}
 
 	// Send L2 block to data streamer
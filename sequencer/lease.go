@@ -0,0 +1,87 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	// ErrInvalidHACfg is returned when the high availability config isn't valid
+	ErrInvalidHACfg = errors.New("invalid sequencer HA config")
+)
+
+// sequencerLease is the lease the sequencer instances compete for in active/passive mode. Its epoch is increased
+// every time it's acquired, so the writes of a previous leader are fenced off
+type sequencerLease struct {
+	cfg       HACfg
+	holder    string
+	stateIntf stateInterface
+	epoch     uint64
+	renewedAt time.Time
+}
+
+// newSequencerLease returns the lease of the sequencer instance, checking the config is valid
+func newSequencerLease(cfg HACfg, stateIntf stateInterface) (*sequencerLease, error) {
+	if cfg.LeaseDuration.Duration <= 0 || cfg.RenewInterval.Duration <= 0 || cfg.RenewInterval.Duration >= cfg.LeaseDuration.Duration {
+		return nil, fmt.Errorf("%w: the renew interval %v must be lower than the lease duration %v", ErrInvalidHACfg, cfg.RenewInterval, cfg.LeaseDuration)
+	}
+
+	holder := cfg.InstanceID
+	if holder == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get the hostname as instance id, error: %v", ErrInvalidHACfg, err)
+		}
+		holder = hostname
+	}
+
+	return &sequencerLease{cfg: cfg, holder: holder, stateIntf: stateIntf}, nil
+}
+
+// tryAcquire tries to acquire the lease, returning false if it's held by another instance
+func (l *sequencerLease) tryAcquire(ctx context.Context) (bool, error) {
+	now := time.Now()
+	epoch, acquired, err := l.stateIntf.TryAcquireSequencerLease(ctx, l.holder, l.cfg.LeaseDuration.Duration, nil)
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	l.epoch = epoch
+	l.renewedAt = now
+	log.Infof("sequencer lease acquired by %s, epoch: %d", l.holder, l.epoch)
+	return true, nil
+}
+
+// renew extends the lease, returning ErrSequencerLeaseLost if another instance has acquired it
+func (l *sequencerLease) renew(ctx context.Context) error {
+	now := time.Now()
+	err := l.stateIntf.RenewSequencerLease(ctx, l.holder, l.epoch, l.cfg.LeaseDuration.Duration, nil)
+	if err != nil {
+		return err
+	}
+
+	l.renewedAt = now
+	return nil
+}
+
+// expired returns true if the lease hasn't been renewed for its duration, so it may have been acquired by another
+// instance
+func (l *sequencerLease) expired() bool {
+	return time.Since(l.renewedAt) >= l.cfg.LeaseDuration.Duration
+}
+
+// check returns ErrSequencerLeaseLost if the lease isn't held anymore. The lease is kept until the end of the db tx
+func (l *sequencerLease) check(ctx context.Context, dbTx pgx.Tx) error {
+	return l.stateIntf.CheckSequencerLease(ctx, l.holder, l.epoch, dbTx)
+}
+
+// release expires the lease so a standby can take over without waiting for it to expire
+func (l *sequencerLease) release(ctx context.Context) error {
+	return l.stateIntf.ReleaseSequencerLease(ctx, l.holder, l.epoch, nil)
+}
@@ -0,0 +1,85 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSequencerLease(t *testing.T) {
+	cfg := HACfg{
+		Enabled:       true,
+		InstanceID:    "sequencer-1",
+		LeaseDuration: cfgTypes.NewDuration(15 * time.Second),
+		RenewInterval: cfgTypes.NewDuration(5 * time.Second),
+	}
+	lease, err := newSequencerLease(cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "sequencer-1", lease.holder)
+
+	cfg.InstanceID = ""
+	lease, err = newSequencerLease(cfg, nil)
+	require.NoError(t, err)
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	assert.Equal(t, hostname, lease.holder)
+
+	cfg.RenewInterval = cfg.LeaseDuration
+	_, err = newSequencerLease(cfg, nil)
+	assert.True(t, errors.Is(err, ErrInvalidHACfg))
+}
+
+func TestSequencerLease(t *testing.T) {
+	ctx := context.Background()
+	st := NewStateMock(t)
+	lease, err := newSequencerLease(HACfg{
+		InstanceID:    "sequencer-1",
+		LeaseDuration: cfgTypes.NewDuration(15 * time.Second),
+		RenewInterval: cfgTypes.NewDuration(5 * time.Second),
+	}, st)
+	require.NoError(t, err)
+
+	// the lease is held by another instance
+	st.On("TryAcquireSequencerLease", ctx, "sequencer-1", 15*time.Second, nil).Return(uint64(0), false, nil).Once()
+	acquired, err := lease.tryAcquire(ctx)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	st.On("TryAcquireSequencerLease", ctx, "sequencer-1", 15*time.Second, nil).Return(uint64(3), true, nil).Once()
+	acquired, err = lease.tryAcquire(ctx)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	assert.Equal(t, uint64(3), lease.epoch)
+	assert.False(t, lease.expired())
+
+	// the lease is renewed and checked with the epoch it was acquired with
+	st.On("RenewSequencerLease", ctx, "sequencer-1", uint64(3), 15*time.Second, nil).Return(nil).Once()
+	require.NoError(t, lease.renew(ctx))
+	st.On("CheckSequencerLease", ctx, "sequencer-1", uint64(3), nil).Return(state.ErrSequencerLeaseLost).Once()
+	assert.Equal(t, state.ErrSequencerLeaseLost, lease.check(ctx, nil))
+
+	// the lease expires if it isn't renewed
+	lease.renewedAt = time.Now().Add(-15 * time.Second)
+	assert.True(t, lease.expired())
+}
+
+func TestFinalizer_checkLease(t *testing.T) {
+	// arrange
+	f = setupFinalizer(false)
+	ctx := context.Background()
+
+	// act & assert, the lease isn't checked if HA is disabled
+	assert.NoError(t, f.checkLease(ctx, nil))
+
+	f.lease = &sequencerLease{holder: "sequencer-1", epoch: 2, stateIntf: stateMock}
+	stateMock.On("CheckSequencerLease", ctx, "sequencer-1", uint64(2), nil).Return(nil).Once()
+	assert.NoError(t, f.checkLease(ctx, nil))
+	stateMock.AssertExpectations(t)
+}
@@ -14,6 +14,8 @@ import (
 	state "github.com/0xPolygonHermez/zkevm-node/state"
 
 	time "time"
+
+	types "github.com/ethereum/go-ethereum/core/types"
 )
 
 // PoolMock is an autogenerated mock type for the txPool type
@@ -21,6 +23,42 @@ type PoolMock struct {
 	mock.Mock
 }
 
+// AddPendingReceipt provides a mock function with given fields: ctx, receipt
+func (_m *PoolMock) AddPendingReceipt(ctx context.Context, receipt *types.Receipt) error {
+	ret := _m.Called(ctx, receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPendingReceipt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Receipt) error); ok {
+		r0 = rf(ctx, receipt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClearPendingReceipts provides a mock function with given fields: ctx
+func (_m *PoolMock) ClearPendingReceipts(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearPendingReceipts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteFailedTransactionsOlderThan provides a mock function with given fields: ctx, date
 func (_m *PoolMock) DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error {
 	ret := _m.Called(ctx, date)
@@ -39,6 +77,42 @@ func (_m *PoolMock) DeleteFailedTransactionsOlderThan(ctx context.Context, date
 	return r0
 }
 
+// DeletePendingReceipts provides a mock function with given fields: ctx, hashes
+func (_m *PoolMock) DeletePendingReceipts(ctx context.Context, hashes []common.Hash) error {
+	ret := _m.Called(ctx, hashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePendingReceipts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) error); ok {
+		r0 = rf(ctx, hashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePendingReceiptsOlderThan provides a mock function with given fields: ctx, date
+func (_m *PoolMock) DeletePendingReceiptsOlderThan(ctx context.Context, date time.Time) error {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePendingReceiptsOlderThan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTransactionByHash provides a mock function with given fields: ctx, hash
 func (_m *PoolMock) DeleteTransactionByHash(ctx context.Context, hash common.Hash) error {
 	ret := _m.Called(ctx, hash)
@@ -209,6 +283,36 @@ func (_m *PoolMock) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction
 	return r0, r1
 }
 
+// GetPriorityLaneMembers provides a mock function with given fields: ctx
+func (_m *PoolMock) GetPriorityLaneMembers(ctx context.Context) ([]pool.PriorityLaneMember, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriorityLaneMembers")
+	}
+
+	var r0 []pool.PriorityLaneMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pool.PriorityLaneMember, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []pool.PriorityLaneMember); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.PriorityLaneMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTxZkCountersByHash provides a mock function with given fields: ctx, hash
 func (_m *PoolMock) GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error) {
 	ret := _m.Called(ctx, hash)
@@ -266,6 +370,24 @@ func (_m *PoolMock) MarkWIPTxsAsPending(ctx context.Context) error {
 	return r0
 }
 
+// RefundSponsorGas provides a mock function with given fields: ctx, sponsor, gas
+func (_m *PoolMock) RefundSponsorGas(ctx context.Context, sponsor string, gas uint64) error {
+	ret := _m.Called(ctx, sponsor, gas)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundSponsorGas")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, sponsor, gas)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTxStatus provides a mock function with given fields: ctx, hash, newStatus, isWIP, failedReason
 func (_m *PoolMock) UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus pool.TxStatus, isWIP bool, failedReason *string) error {
 	ret := _m.Called(ctx, hash, newStatus, isWIP, failedReason)
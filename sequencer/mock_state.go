@@ -13,6 +13,8 @@ import (
 	pgx "github.com/jackc/pgx/v4"
 
 	state "github.com/0xPolygonHermez/zkevm-node/state"
+
+	time "time"
 )
 
 // StateMock is an autogenerated mock type for the stateInterface type
@@ -70,6 +72,24 @@ func (_m *StateMock) BuildChangeL2Block(deltaTimestamp uint32, l1InfoTreeIndex u
 	return r0
 }
 
+// CheckSequencerLease provides a mock function with given fields: ctx, holder, epoch, dbTx
+func (_m *StateMock) CheckSequencerLease(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, epoch, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, epoch, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CloseBatch provides a mock function with given fields: ctx, receipt, dbTx
 func (_m *StateMock) CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, receipt, dbTx)
@@ -1018,6 +1038,42 @@ func (_m *StateMock) ProcessBatchV2(ctx context.Context, request state.ProcessRe
 	return r0, r1, r2
 }
 
+// ReleaseSequencerLease provides a mock function with given fields: ctx, holder, epoch, dbTx
+func (_m *StateMock) ReleaseSequencerLease(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, epoch, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, epoch, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenewSequencerLease provides a mock function with given fields: ctx, holder, epoch, duration, dbTx
+func (_m *StateMock) RenewSequencerLease(ctx context.Context, holder string, epoch uint64, duration time.Duration, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, epoch, duration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, time.Duration, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, epoch, duration, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreL2Block provides a mock function with given fields: ctx, batchNumber, l2Block, txsEGPLog, dbTx
 func (_m *StateMock) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) (common.Hash, error) {
 	ret := _m.Called(ctx, batchNumber, l2Block, txsEGPLog, dbTx)
@@ -1048,6 +1104,41 @@ func (_m *StateMock) StoreL2Block(ctx context.Context, batchNumber uint64, l2Blo
 	return r0, r1
 }
 
+// TryAcquireSequencerLease provides a mock function with given fields: ctx, holder, duration, dbTx
+func (_m *StateMock) TryAcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, bool, error) {
+	ret := _m.Called(ctx, holder, duration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryAcquireSequencerLease")
+	}
+
+	var r0 uint64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) (uint64, bool, error)); ok {
+		return rf(ctx, holder, duration, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, pgx.Tx) bool); ok {
+		r1 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration, pgx.Tx) error); ok {
+		r2 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateBatchAsChecked provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StateMock) UpdateBatchAsChecked(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -0,0 +1,92 @@
+package sequencer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+const maxReservedPct = 100
+
+// priorityLane is a named lane with a share of each batch reserved for the txs of its members
+type priorityLane struct {
+	name     string
+	reserved state.BatchResources
+}
+
+// newPriorityLanes creates the priority lanes, reserving them their share of the max batch resources
+func newPriorityLanes(cfg []PriorityLaneCfg, constraints state.BatchConstraintsCfg) ([]priorityLane, error) {
+	maxResources := getMaxBatchResources(constraints)
+	names := make(map[string]struct{}, len(cfg))
+	totalPct := uint32(0)
+
+	lanes := make([]priorityLane, 0, len(cfg))
+	for _, laneCfg := range cfg {
+		if laneCfg.Name == "" {
+			return nil, fmt.Errorf("the name of a priority lane is empty")
+		}
+		if _, found := names[laneCfg.Name]; found {
+			return nil, fmt.Errorf("priority lane %s is duplicated", laneCfg.Name)
+		}
+		names[laneCfg.Name] = struct{}{}
+
+		totalPct += laneCfg.ReservedPct
+		if totalPct > maxReservedPct {
+			return nil, fmt.Errorf("the priority lanes reserve more than the %d%% of the batch", maxReservedPct)
+		}
+
+		lanes = append(lanes, priorityLane{name: laneCfg.Name, reserved: pctOfResources(maxResources, laneCfg.ReservedPct)})
+	}
+	return lanes, nil
+}
+
+// pctOfResources returns the percentage of the resources
+func pctOfResources(r state.BatchResources, pct uint32) state.BatchResources {
+	pctOf := func(value uint32) uint32 {
+		return uint32(uint64(value) * uint64(pct) / maxReservedPct)
+	}
+	return state.BatchResources{
+		ZKCounters: state.ZKCounters{
+			GasUsed:          r.ZKCounters.GasUsed * uint64(pct) / maxReservedPct,
+			KeccakHashes:     pctOf(r.ZKCounters.KeccakHashes),
+			PoseidonHashes:   pctOf(r.ZKCounters.PoseidonHashes),
+			PoseidonPaddings: pctOf(r.ZKCounters.PoseidonPaddings),
+			MemAligns:        pctOf(r.ZKCounters.MemAligns),
+			Arithmetics:      pctOf(r.ZKCounters.Arithmetics),
+			Binaries:         pctOf(r.ZKCounters.Binaries),
+			Steps:            pctOf(r.ZKCounters.Steps),
+			Sha256Hashes_V2:  pctOf(r.ZKCounters.Sha256Hashes_V2),
+		},
+		Bytes: r.Bytes * uint64(pct) / maxReservedPct,
+	}
+}
+
+// subResources returns the resources minus the other ones, being 0 the resources that would underflow
+func subResources(r state.BatchResources, other state.BatchResources) state.BatchResources {
+	sub := func(value, other uint32) uint32 {
+		if other > value {
+			return 0
+		}
+		return value - other
+	}
+	sub64 := func(value, other uint64) uint64 {
+		if other > value {
+			return 0
+		}
+		return value - other
+	}
+	return state.BatchResources{
+		ZKCounters: state.ZKCounters{
+			GasUsed:          sub64(r.ZKCounters.GasUsed, other.ZKCounters.GasUsed),
+			KeccakHashes:     sub(r.ZKCounters.KeccakHashes, other.ZKCounters.KeccakHashes),
+			PoseidonHashes:   sub(r.ZKCounters.PoseidonHashes, other.ZKCounters.PoseidonHashes),
+			PoseidonPaddings: sub(r.ZKCounters.PoseidonPaddings, other.ZKCounters.PoseidonPaddings),
+			MemAligns:        sub(r.ZKCounters.MemAligns, other.ZKCounters.MemAligns),
+			Arithmetics:      sub(r.ZKCounters.Arithmetics, other.ZKCounters.Arithmetics),
+			Binaries:         sub(r.ZKCounters.Binaries, other.ZKCounters.Binaries),
+			Steps:            sub(r.ZKCounters.Steps, other.ZKCounters.Steps),
+			Sha256Hashes_V2:  sub(r.ZKCounters.Sha256Hashes_V2, other.ZKCounters.Sha256Hashes_V2),
+		},
+		Bytes: sub64(r.Bytes, other.Bytes),
+	}
+}
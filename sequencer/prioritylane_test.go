@@ -0,0 +1,111 @@
+package sequencer
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uniformResources(value uint32) state.BatchResources {
+	return state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: uint64(value), KeccakHashes: value, PoseidonHashes: value, PoseidonPaddings: value, MemAligns: value, Arithmetics: value, Binaries: value, Steps: value, Sha256Hashes_V2: value},
+		Bytes:      uint64(value),
+	}
+}
+
+func TestNewPriorityLanes(t *testing.T) {
+	lanes, err := newPriorityLanes([]PriorityLaneCfg{{Name: "oracles", ReservedPct: 30}, {Name: "bridge", ReservedPct: 70}}, rcMax)
+	require.NoError(t, err)
+	assert.Equal(t, []priorityLane{
+		{name: "oracles", reserved: uniformResources(3)},
+		{name: "bridge", reserved: uniformResources(7)},
+	}, lanes)
+
+	_, err = newPriorityLanes([]PriorityLaneCfg{{Name: "oracles", ReservedPct: 30}, {Name: "bridge", ReservedPct: 71}}, rcMax)
+	assert.ErrorContains(t, err, "more than")
+	_, err = newPriorityLanes([]PriorityLaneCfg{{Name: "oracles", ReservedPct: 30}, {Name: "oracles", ReservedPct: 10}}, rcMax)
+	assert.ErrorContains(t, err, "duplicated")
+	_, err = newPriorityLanes([]PriorityLaneCfg{{ReservedPct: 10}}, rcMax)
+	assert.ErrorContains(t, err, "empty")
+}
+
+func TestSubResources(t *testing.T) {
+	assert.Equal(t, uniformResources(2), subResources(uniformResources(5), uniformResources(3)))
+	assert.Equal(t, uniformResources(0), subResources(uniformResources(3), uniformResources(5)))
+}
+
+func TestWorkerPriorityLanes(t *testing.T) {
+	lanes, err := newPriorityLanes([]PriorityLaneCfg{{Name: "oracles", ReservedPct: 30}, {Name: "bridge", ReservedPct: 20}}, rcMax)
+	require.NoError(t, err)
+	worker := NewWorker(nil, rcMax, &gasPriceOrdering{}, lanes, newTimeoutCond(&sync.Mutex{}))
+
+	oracle, bridge, user := common.Address{1}, common.Address{2}, common.Address{3}
+	worker.UpdatePriorityLaneMembers([]pool.PriorityLaneMember{
+		{Lane: "bridge", Address: bridge},
+		{Lane: "oracles", Address: oracle},
+		// the first configured lane wins
+		{Lane: "bridge", Address: oracle},
+		// members of lanes not configured are ignored
+		{Lane: "unknown", Address: user},
+	})
+	assert.Equal(t, map[common.Address]int{oracle: 0, bridge: 1}, worker.laneMembers)
+
+	newTx := func(hash common.Hash, from common.Address, to *common.Address, gasPrice int64, counter uint32) *TxTracker {
+		resources := uniformResources(counter)
+		return &TxTracker{Hash: hash, HashStr: hash.String(), From: from, FromStr: from.String(), To: to, GasPrice: big.NewInt(gasPrice),
+			UsedZKCounters: resources.ZKCounters, ReservedZKCounters: resources.ZKCounters, Bytes: resources.Bytes}
+	}
+	bigRetail := newTx(common.Hash{1}, user, nil, 100, 6)
+	smallRetail := newTx(common.Hash{2}, user, nil, 50, 5)
+	oracleTx := newTx(common.Hash{3}, oracle, nil, 3, 3)
+	// the bridge claims are sent by anyone to the bridge
+	claimTx := newTx(common.Hash{4}, user, &bridge, 2, 2)
+	for _, tx := range []*TxTracker{bigRetail, smallRetail, oracleTx, claimTx} {
+		worker.txSortedList.add(tx)
+	}
+
+	// the retail txs can only use the 50% of the batch not reserved
+	tx, err := worker.GetBestFittingTx(uniformResources(10), state.ZKCounters{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, smallRetail, tx)
+	assert.Empty(t, tx.Lane)
+
+	// the lane txs can use their reserved resources, but not the ones of the other lanes
+	tx, err = worker.GetBestFittingTx(uniformResources(5), state.ZKCounters{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, oracleTx, tx)
+	assert.Equal(t, "oracles", tx.Lane)
+	_, err = worker.GetBestFittingTx(uniformResources(1), state.ZKCounters{}, nil)
+	assert.ErrorIs(t, err, ErrNoFittingTransaction)
+
+	tx, err = worker.GetBestFittingTx(uniformResources(2), state.ZKCounters{}, map[string]state.BatchResources{"oracles": uniformResources(3)})
+	require.NoError(t, err)
+	assert.Equal(t, claimTx, tx)
+	assert.Equal(t, "bridge", tx.Lane)
+
+	// once the lanes have used their reserved resources, the rest of the batch is shared
+	tx, err = worker.GetBestFittingTx(uniformResources(10), state.ZKCounters{}, map[string]state.BatchResources{"oracles": uniformResources(3), "bridge": uniformResources(2)})
+	require.NoError(t, err)
+	assert.Equal(t, bigRetail, tx)
+}
+
+func TestAddLaneUsedResources(t *testing.T) {
+	batch := &Batch{}
+	batch.imLanesUsedResources = addLaneUsedResources(batch.imLanesUsedResources, "oracles", uniformResources(1))
+	batch.imLanesUsedResources = addLaneUsedResources(batch.imLanesUsedResources, "oracles", uniformResources(2))
+	assert.Equal(t, map[string]state.BatchResources{"oracles": uniformResources(3)}, batch.imLanesUsedResources)
+
+	// the lanes of a reorged batch are rolled back to a copy of the resources used by its processed L2 blocks
+	batch.finalLanesUsedResources = addLaneUsedResources(batch.finalLanesUsedResources, "oracles", uniformResources(1))
+	lanesCopy := copyLanesUsedResources(batch.finalLanesUsedResources)
+	assert.Equal(t, map[string]state.BatchResources{"oracles": uniformResources(1)}, lanesCopy)
+	lanesCopy = addLaneUsedResources(lanesCopy, "oracles", uniformResources(1))
+	assert.Equal(t, map[string]state.BatchResources{"oracles": uniformResources(1)}, batch.finalLanesUsedResources)
+	assert.Nil(t, copyLanesUsedResources(nil))
+}
@@ -2,6 +2,7 @@ package sequencer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -26,6 +27,9 @@ type Sequencer struct {
 	batchCfg state.BatchConfig
 	poolCfg  pool.Config
 
+	sponsorships *pool.Sponsorships
+	lease        *sequencerLease
+
 	pool      txPool
 	stateIntf stateInterface
 	eventLog  *event.EventLog
@@ -43,14 +47,34 @@ type Sequencer struct {
 
 // New init sequencer
 func New(cfg Config, batchCfg state.BatchConfig, poolCfg pool.Config, txPool txPool, stateIntf stateInterface, etherman ethermanInterface, eventLog *event.EventLog) (*Sequencer, error) {
+	// Check the tx ordering and priority lanes are valid before starting
+	if _, err := newTxOrderingStrategy(cfg.TxOrdering, batchCfg.Constraints); err != nil {
+		return nil, err
+	}
+	if _, err := newPriorityLanes(cfg.PriorityLanes.Lanes, batchCfg.Constraints); err != nil {
+		return nil, err
+	}
+	if cfg.Finalizer.PublishPendingReceipts && cfg.Finalizer.PendingReceiptsTTL.Duration <= 0 {
+		return nil, fmt.Errorf("the pending receipts TTL must be positive when the pending receipts are published")
+	}
+
 	sequencer := &Sequencer{
-		cfg:       cfg,
-		batchCfg:  batchCfg,
-		poolCfg:   poolCfg,
-		pool:      txPool,
-		stateIntf: stateIntf,
-		etherman:  etherman,
-		eventLog:  eventLog,
+		cfg:          cfg,
+		batchCfg:     batchCfg,
+		poolCfg:      poolCfg,
+		sponsorships: pool.NewSponsorships(poolCfg.Sponsorship),
+		pool:         txPool,
+		stateIntf:    stateIntf,
+		etherman:     etherman,
+		eventLog:     eventLog,
+	}
+
+	if cfg.HA.Enabled {
+		lease, err := newSequencerLease(cfg.HA, stateIntf)
+		if err != nil {
+			return nil, err
+		}
+		sequencer.lease = lease
 	}
 
 	sequencer.dataToStream = make(chan interface{}, datastreamChannelBufferSize)
@@ -65,10 +89,7 @@ func (s *Sequencer) Start(ctx context.Context) {
 		time.Sleep(time.Second)
 	}
 
-	err := s.pool.MarkWIPTxsAsPending(ctx)
-	if err != nil {
-		log.Fatalf("failed to mark wip txs as pending, error: %v", err)
-	}
+	var err error
 
 	// Start stream server if enabled
 	if s.cfg.StreamServer.Enabled {
@@ -85,19 +106,60 @@ func (s *Sequencer) Start(ctx context.Context) {
 		s.updateDataStreamerFile(ctx, s.cfg.StreamServer.ChainID)
 	}
 
+	// Wait as standby until this instance is the leader, then resume from the state stored by the previous leader
+	if s.lease != nil {
+		if !s.waitForLease(ctx) {
+			return
+		}
+		if s.streamServer != nil {
+			s.updateDataStreamerFile(ctx, s.cfg.StreamServer.ChainID)
+		}
+	}
+
+	err = s.pool.MarkWIPTxsAsPending(ctx)
+	if err != nil {
+		log.Fatalf("failed to mark wip txs as pending, error: %v", err)
+	}
+
+	// The pending receipts of a previous run belong to txs that weren't stored, which are processed again
+	err = s.pool.ClearPendingReceipts(ctx)
+	if err != nil {
+		log.Fatalf("failed to clear the pending receipts, error: %v", err)
+	}
+
 	if s.streamServer != nil {
 		go s.sendDataToStreamer(s.cfg.StreamServer.ChainID)
 	}
 
 	s.workerReadyTxsCond = newTimeoutCond(&sync.Mutex{})
-	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.workerReadyTxsCond)
-	s.finalizer = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.cfg.L2Coinbase, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream)
+	txOrdering, err := newTxOrderingStrategy(s.cfg.TxOrdering, s.batchCfg.Constraints)
+	if err != nil {
+		log.Fatalf("failed to create tx ordering strategy, error: %v", err)
+	}
+	priorityLanes, err := newPriorityLanes(s.cfg.PriorityLanes.Lanes, s.batchCfg.Constraints)
+	if err != nil {
+		log.Fatalf("failed to create priority lanes, error: %v", err)
+	}
+	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, txOrdering, priorityLanes, s.workerReadyTxsCond)
+	s.finalizer = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.cfg.L2Coinbase, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream, s.lease)
 	go s.finalizer.Start(ctx)
 
+	if s.lease != nil {
+		go s.renewLease(ctx)
+	}
+
 	go s.loadFromPool(ctx)
 
+	if len(priorityLanes) > 0 {
+		go s.loadPriorityLaneMembers(ctx)
+	}
+
 	go s.deleteOldPoolTxs(ctx)
 
+	if s.cfg.Finalizer.PublishPendingReceipts {
+		go s.deleteOldPendingReceipts(ctx)
+	}
+
 	go s.expireOldWorkerTxs(ctx)
 
 	go s.checkStateInconsistency(ctx)
@@ -128,6 +190,58 @@ func (s *Sequencer) checkStateInconsistency(ctx context.Context) {
 	}
 }
 
+// waitForLease waits as standby until the sequencer lease is acquired, keeping the data stream file up to date with
+// the L2 blocks stored by the leader meanwhile. It returns false if the context is done before
+func (s *Sequencer) waitForLease(ctx context.Context) bool {
+	for {
+		acquired, err := s.lease.tryAcquire(ctx)
+		if err != nil {
+			log.Errorf("failed to acquire the sequencer lease, error: %v", err)
+		} else if acquired {
+			return true
+		} else {
+			log.Infof("sequencer lease held by another instance, waiting as standby...")
+		}
+
+		if s.streamServer != nil {
+			err = state.GenerateDataStreamFile(ctx, s.streamServer, s.stateIntf, true, nil, s.cfg.StreamServer.ChainID, s.cfg.StreamServer.UpgradeEtrogBatchNumber)
+			if err != nil {
+				log.Errorf("failed to update data streamer file as standby, error: %v", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(s.cfg.HA.AcquireInterval.Duration):
+		}
+	}
+}
+
+// renewLease renews periodically the sequencer lease. The sequencer is halted if the lease is lost, as another
+// instance may have taken over, and the lease is released when the context is done
+func (s *Sequencer) renewLease(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			err := s.lease.release(context.Background())
+			if err != nil {
+				log.Errorf("failed to release the sequencer lease, error: %v", err)
+			}
+			return
+		case <-time.After(s.cfg.HA.RenewInterval.Duration):
+		}
+
+		err := s.lease.renew(ctx)
+		if errors.Is(err, state.ErrSequencerLeaseLost) || (err != nil && s.lease.expired()) {
+			s.finalizer.Halt(ctx, fmt.Errorf("failed to renew the sequencer lease, error: %v", err), true)
+			return
+		} else if err != nil {
+			log.Errorf("failed to renew the sequencer lease, retrying, error: %v", err)
+		}
+	}
+}
+
 func (s *Sequencer) updateDataStreamerFile(ctx context.Context, chainID uint64) {
 	err := state.GenerateDataStreamFile(ctx, s.streamServer, s.stateIntf, true, nil, chainID, s.cfg.StreamServer.UpgradeEtrogBatchNumber)
 	if err != nil {
@@ -175,6 +289,23 @@ func (s *Sequencer) deleteOldPoolTxs(ctx context.Context) {
 	}
 }
 
+// deleteOldPendingReceipts deletes periodically the pending receipts older than their TTL, which are left behind if
+// their txs are never stored
+func (s *Sequencer) deleteOldPendingReceipts(ctx context.Context) {
+	for {
+		time.Sleep(s.cfg.Finalizer.PendingReceiptsTTL.Duration)
+
+		if s.finalizer.haltFinalizer.Load() {
+			return
+		}
+
+		err := s.pool.DeletePendingReceiptsOlderThan(ctx, time.Now().Add(-s.cfg.Finalizer.PendingReceiptsTTL.Duration))
+		if err != nil {
+			log.Errorf("failed to delete old pending receipts from the pool, error: %v", err)
+		}
+	}
+}
+
 func (s *Sequencer) expireOldWorkerTxs(ctx context.Context) {
 	for {
 		time.Sleep(s.cfg.TxLifetimeCheckInterval.Duration)
@@ -183,14 +314,39 @@ func (s *Sequencer) expireOldWorkerTxs(ctx context.Context) {
 			return
 		}
 
-		txTrackers := s.worker.ExpireTransactions(s.cfg.TxLifetimeMax.Duration)
-		failedReason := ErrExpiredTransaction.Error()
-		for _, txTracker := range txTrackers {
-			err := s.pool.UpdateTxStatus(ctx, txTracker.Hash, pool.TxStatusFailed, false, &failedReason)
-			if err != nil {
-				log.Errorf("failed to update tx status, error: %v", err)
-			}
+		s.expireWorkerTxs(ctx)
+	}
+}
+
+// expireWorkerTxs sets as failed the txs that have been in the worker for more than the max lifetime
+func (s *Sequencer) expireWorkerTxs(ctx context.Context) {
+	txTrackers := s.worker.ExpireTransactions(s.cfg.TxLifetimeMax.Duration)
+	failedReason := ErrExpiredTransaction.Error()
+	for _, txTracker := range txTrackers {
+		err := s.pool.UpdateTxStatus(ctx, txTracker.Hash, pool.TxStatusFailed, false, &failedReason)
+		if err != nil {
+			log.Errorf("failed to update tx status, error: %v", err)
+			continue
+		}
+		refundSponsorGas(ctx, s.pool, txTracker, txTracker.Gas)
+	}
+}
+
+// loadPriorityLaneMembers keeps loading the members of the priority lanes from the pool
+func (s *Sequencer) loadPriorityLaneMembers(ctx context.Context) {
+	for {
+		if s.finalizer.haltFinalizer.Load() {
+			return
 		}
+
+		members, err := s.pool.GetPriorityLaneMembers(ctx)
+		if err != nil {
+			log.Errorf("error loading priority lane members from pool, error: %v", err)
+		} else {
+			s.worker.UpdatePriorityLaneMembers(members)
+		}
+
+		time.Sleep(s.cfg.PriorityLanes.RefreshInterval.Duration)
 	}
 }
 
@@ -224,16 +380,24 @@ func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) erro
 	if err != nil {
 		return err
 	}
+	txTracker.PoolReceivedAt = tx.ReceivedAt
+	txTracker.Sponsor = s.sponsorships.SponsorOf(tx.Transaction)
 	replacedTx, dropReason := s.worker.AddTxTracker(ctx, txTracker)
 	if dropReason != nil {
 		failedReason := dropReason.Error()
-		return s.pool.UpdateTxStatus(ctx, txTracker.Hash, pool.TxStatusFailed, false, &failedReason)
+		if err := s.pool.UpdateTxStatus(ctx, txTracker.Hash, pool.TxStatusFailed, false, &failedReason); err != nil {
+			return err
+		}
+		refundSponsorGas(ctx, s.pool, txTracker, txTracker.Gas)
+		return nil
 	} else {
 		if replacedTx != nil {
 			failedReason := ErrReplacedTransaction.Error()
 			err := s.pool.UpdateTxStatus(ctx, replacedTx.Hash, pool.TxStatusFailed, false, &failedReason)
 			if err != nil {
 				log.Warnf("error when setting as failed replacedTx %s, error: %v", replacedTx.HashStr, err)
+			} else {
+				refundSponsorGas(ctx, s.pool, replacedTx, replacedTx.Gas)
 			}
 		}
 		return s.pool.UpdateTxWIPStatus(ctx, tx.Hash(), true)
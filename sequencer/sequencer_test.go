@@ -0,0 +1,106 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var sponsoredTarget = common.HexToAddress("0x5")
+
+func setupSponsorshipSequencer() (*Sequencer, *PoolMock) {
+	poolMock := new(PoolMock)
+	return &Sequencer{
+		cfg: Config{TxLifetimeMax: cfgTypes.NewDuration(time.Minute)},
+		sponsorships: pool.NewSponsorships(pool.SponsorshipCfg{
+			Enabled:  true,
+			Sponsors: []pool.SponsorCfg{{Name: "sponsor", Targets: []common.Address{sponsoredTarget}, GasBudget: 1000000}},
+		}),
+		pool:   poolMock,
+		worker: NewWorker(nil, bc, &gasPriceOrdering{}, nil, newTimeoutCond(&sync.Mutex{})),
+	}, poolMock
+}
+
+func TestSequencer_addTxToWorkerRefundsSponsorGas(t *testing.T) {
+	ctx := context.Background()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.NewEIP155Signer(big.NewInt(1000))
+	newPoolTx := func(gasPrice int64, ip string) pool.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(0, sponsoredTarget, big.NewInt(0), 50000, big.NewInt(gasPrice), nil), signer, privateKey)
+		require.NoError(t, err)
+		return pool.Transaction{Transaction: *tx, IP: ip}
+	}
+
+	t.Run("replaced tx", func(t *testing.T) {
+		s, poolMock := setupSponsorshipSequencer()
+		from := crypto.PubkeyToAddress(privateKey.PublicKey)
+		s.worker.pool[from.String()] = newAddrQueue(from, 0, big.NewInt(1000000000))
+
+		sponsoredTx, replacingTx := newPoolTx(0, ""), newPoolTx(1, "")
+		poolMock.On("UpdateTxWIPStatus", ctx, mock.Anything, true).Return(nil).Twice()
+		poolMock.On("UpdateTxStatus", ctx, sponsoredTx.Hash(), pool.TxStatusFailed, false, mock.Anything).Return(nil).Once()
+		poolMock.On("RefundSponsorGas", ctx, "sponsor", uint64(50000)).Return(nil).Once()
+
+		require.NoError(t, s.addTxToWorker(ctx, sponsoredTx))
+		require.NoError(t, s.addTxToWorker(ctx, replacingTx))
+		poolMock.AssertExpectations(t)
+	})
+
+	t.Run("dropped tx", func(t *testing.T) {
+		s, poolMock := setupSponsorshipSequencer()
+
+		sponsoredTx := newPoolTx(0, "invalid ip")
+		poolMock.On("UpdateTxStatus", ctx, sponsoredTx.Hash(), pool.TxStatusFailed, false, mock.Anything).Return(nil).Once()
+		poolMock.On("RefundSponsorGas", ctx, "sponsor", uint64(50000)).Return(nil).Once()
+
+		require.NoError(t, s.addTxToWorker(ctx, sponsoredTx))
+		poolMock.AssertExpectations(t)
+	})
+}
+
+func TestSequencer_expireWorkerTxsRefundsSponsorGas(t *testing.T) {
+	ctx := context.Background()
+	s, poolMock := setupSponsorshipSequencer()
+
+	from := common.HexToAddress("0x1")
+	expired := &TxTracker{Hash: common.HexToHash("0x1"), From: from, FromStr: from.String(), Gas: 50000, GasPrice: big.NewInt(0),
+		Cost: big.NewInt(0), Sponsor: "sponsor", ReceivedAt: time.Now().Add(-2 * time.Minute)}
+	notSponsored := &TxTracker{Hash: common.HexToHash("0x2"), From: from, FromStr: from.String(), Nonce: 1, Gas: 50000, GasPrice: big.NewInt(1),
+		Cost: big.NewInt(0), ReceivedAt: time.Now().Add(-2 * time.Minute)}
+	queue := newAddrQueue(from, 0, big.NewInt(0))
+	_, _, _, err := queue.addTx(expired)
+	require.NoError(t, err)
+	_, _, _, err = queue.addTx(notSponsored)
+	require.NoError(t, err)
+	s.worker.pool[from.String()] = queue
+
+	poolMock.On("UpdateTxStatus", ctx, mock.Anything, pool.TxStatusFailed, false, mock.Anything).Return(nil).Twice()
+	poolMock.On("RefundSponsorGas", ctx, "sponsor", uint64(50000)).Return(nil).Once()
+
+	s.expireWorkerTxs(ctx)
+	poolMock.AssertExpectations(t)
+	assert.True(t, queue.IsEmpty())
+}
+
+func TestNewPendingReceiptsTTL(t *testing.T) {
+	cfg := Config{Finalizer: FinalizerCfg{PublishPendingReceipts: true}}
+	_, err := New(cfg, state.BatchConfig{}, pool.Config{}, nil, nil, nil, nil)
+	assert.ErrorContains(t, err, "pending receipts TTL")
+
+	cfg.Finalizer.PendingReceiptsTTL = cfgTypes.NewDuration(time.Minute)
+	_, err = New(cfg, state.BatchConfig{}, pool.Config{}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+}
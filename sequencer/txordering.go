@@ -0,0 +1,226 @@
+package sequencer
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+// TxOrdering is the strategy used by the worker to choose the next tx to add to the batch
+type TxOrdering string
+
+const (
+	// GasPriceTxOrdering chooses the tx with the highest gas price that fits in the batch
+	GasPriceTxOrdering TxOrdering = "gasprice"
+	// FIFOTxOrdering chooses the tx that arrived first to the pool that fits in the batch
+	FIFOTxOrdering TxOrdering = "fifo"
+	// EfficiencyTxOrdering chooses the tx that pays the highest fee per ZK counter used that fits in the batch
+	EfficiencyTxOrdering TxOrdering = "efficiency"
+	// RoundRobinTxOrdering chooses the tx of the sender that has been waiting longer since its last tx was added to a batch
+	RoundRobinTxOrdering TxOrdering = "roundrobin"
+)
+
+// txOrderingStrategy chooses the next tx to process among the ready txs of the worker
+type txOrderingStrategy interface {
+	// selectTx returns the tx to process among the ready txs (sorted by gas price) for which fits returns true, or nil if none fits
+	selectTx(txs *txSortedList, fits func(tx *TxTracker) bool) *TxTracker
+	// selected notifies the tx returned by selectTx is going to be processed
+	selected(tx *TxTracker)
+}
+
+// newTxOrderingStrategy creates the strategy for the tx ordering, the gas price ordering is used if it's empty
+func newTxOrderingStrategy(ordering TxOrdering, constraints state.BatchConstraintsCfg) (txOrderingStrategy, error) {
+	switch ordering {
+	case GasPriceTxOrdering, "":
+		return &gasPriceOrdering{}, nil
+	case FIFOTxOrdering:
+		return &fifoOrdering{}, nil
+	case EfficiencyTxOrdering:
+		return &efficiencyOrdering{constraints: constraints}, nil
+	case RoundRobinTxOrdering:
+		return &roundRobinOrdering{lastSelected: make(map[string]uint64)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tx ordering %q", ordering)
+	}
+}
+
+// gasPriceOrdering returns the first tx of the txSortedList that fits, looking for it in parallel
+type gasPriceOrdering struct{}
+
+func (o *gasPriceOrdering) selectTx(txs *txSortedList, fits func(tx *TxTracker) bool) *TxTracker {
+	var (
+		tx         *TxTracker
+		foundMutex sync.RWMutex
+	)
+
+	nGoRoutines := runtime.NumCPU()
+	foundAt := -1
+
+	wg := sync.WaitGroup{}
+	wg.Add(nGoRoutines)
+
+	// Each go routine looks for a fitting tx
+	for i := 0; i < nGoRoutines; i++ {
+		go func(n int) {
+			defer wg.Done()
+			for i := n; i < txs.len(); i += nGoRoutines {
+				foundMutex.RLock()
+				if foundAt != -1 && i > foundAt {
+					foundMutex.RUnlock()
+					return
+				}
+				foundMutex.RUnlock()
+
+				txCandidate := txs.getByIndex(i)
+				if !fits(txCandidate) {
+					// We don't add this Tx
+					continue
+				}
+
+				foundMutex.Lock()
+				if foundAt == -1 || foundAt > i {
+					foundAt = i
+					tx = txCandidate
+				}
+				foundMutex.Unlock()
+
+				return
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return tx
+}
+
+func (o *gasPriceOrdering) selected(tx *TxTracker) {}
+
+// fifoOrdering returns the fitting tx received first by the pool, the ties are solved by gas price
+type fifoOrdering struct{}
+
+func (o *fifoOrdering) selectTx(txs *txSortedList, fits func(tx *TxTracker) bool) *TxTracker {
+	var best *TxTracker
+	for _, tx := range txs.GetSorted() {
+		if best != nil && !tx.arrivedAt().Before(best.arrivedAt()) {
+			continue
+		}
+		if fits(tx) {
+			best = tx
+		}
+	}
+	return best
+}
+
+func (o *fifoOrdering) selected(tx *TxTracker) {}
+
+// efficiencyOrdering returns the fitting tx paying the highest fee for the share of the batch it uses.
+// The share of the batch is the one of the most used resource, as it's the one limiting the txs of the batch
+type efficiencyOrdering struct {
+	constraints state.BatchConstraintsCfg
+}
+
+func (o *efficiencyOrdering) selectTx(txs *txSortedList, fits func(tx *TxTracker) bool) *TxTracker {
+	var (
+		best           *TxTracker
+		bestEfficiency float64
+	)
+	for _, tx := range txs.GetSorted() {
+		efficiency := o.efficiency(tx)
+		if best != nil && efficiency <= bestEfficiency {
+			continue
+		}
+		if fits(tx) {
+			best, bestEfficiency = tx, efficiency
+		}
+	}
+	return best
+}
+
+func (o *efficiencyOrdering) selected(tx *TxTracker) {}
+
+// efficiency returns the fee of the tx per share of the batch used
+func (o *efficiencyOrdering) efficiency(tx *TxTracker) float64 {
+	gas := tx.UsedZKCounters.GasUsed
+	if gas == 0 {
+		// the tx hasn't been pre-executed, so the gas limit is the best estimation we have
+		gas = tx.Gas
+	}
+	fee, _ := new(big.Float).SetInt(new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(gas))).Float64()
+
+	counters := tx.ReservedZKCounters
+	share := math.Max(batchShare(tx.Bytes, o.constraints.MaxBatchBytesSize), batchShare(counters.GasUsed, o.constraints.MaxCumulativeGasUsed))
+	for _, used := range []struct {
+		counter, max uint32
+	}{
+		{counters.KeccakHashes, o.constraints.MaxKeccakHashes},
+		{counters.PoseidonHashes, o.constraints.MaxPoseidonHashes},
+		{counters.PoseidonPaddings, o.constraints.MaxPoseidonPaddings},
+		{counters.MemAligns, o.constraints.MaxMemAligns},
+		{counters.Arithmetics, o.constraints.MaxArithmetics},
+		{counters.Binaries, o.constraints.MaxBinaries},
+		{counters.Steps, o.constraints.MaxSteps},
+		{counters.Sha256Hashes_V2, o.constraints.MaxSHA256Hashes},
+	} {
+		share = math.Max(share, batchShare(uint64(used.counter), uint64(used.max)))
+	}
+
+	if share == 0 {
+		return math.Inf(1)
+	}
+	return fee / share
+}
+
+// batchShare returns the share of the resource of the batch that is used
+func batchShare(used, max uint64) float64 {
+	if max == 0 {
+		return 0
+	}
+	return float64(used) / float64(max)
+}
+
+// roundRobinOrdering returns the fitting tx of the sender whose last tx was selected longer ago, so a sender with
+// a high gas price can't fill the batches with its txs. The ties (i.e. senders never selected) are solved by gas price
+type roundRobinOrdering struct {
+	// lastSelected is the round in which a tx of the sender was last selected
+	lastSelected map[string]uint64
+	round        uint64
+}
+
+func (o *roundRobinOrdering) selectTx(txs *txSortedList, fits func(tx *TxTracker) bool) *TxTracker {
+	var (
+		best      *TxTracker
+		bestRound uint64
+	)
+	sorted := txs.GetSorted()
+	for _, tx := range sorted {
+		round := o.lastSelected[tx.FromStr]
+		if best != nil && round >= bestRound {
+			continue
+		}
+		if fits(tx) {
+			best, bestRound = tx, round
+		}
+	}
+
+	// The senders without ready txs don't need to keep their turn, as only the waiting senders compete for the batch
+	if len(o.lastSelected) > 2*len(sorted) {
+		ready := make(map[string]uint64, len(sorted))
+		for _, tx := range sorted {
+			if round, found := o.lastSelected[tx.FromStr]; found {
+				ready[tx.FromStr] = round
+			}
+		}
+		o.lastSelected = ready
+	}
+
+	return best
+}
+
+func (o *roundRobinOrdering) selected(tx *TxTracker) {
+	o.round++
+	o.lastSelected[tx.FromStr] = o.round
+}
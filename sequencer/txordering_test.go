@@ -0,0 +1,210 @@
+package sequencer
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrderingTx(hash, from string, gasPrice int64, counter uint32, receivedAt time.Time) *TxTracker {
+	counters := state.ZKCounters{GasUsed: uint64(counter), KeccakHashes: counter, PoseidonHashes: counter, PoseidonPaddings: counter, MemAligns: counter, Arithmetics: counter, Binaries: counter, Steps: counter, Sha256Hashes_V2: counter}
+	return &TxTracker{
+		HashStr:            hash,
+		FromStr:            from,
+		GasPrice:           big.NewInt(gasPrice),
+		Bytes:              uint64(counter),
+		UsedZKCounters:     counters,
+		ReservedZKCounters: counters,
+		PoolReceivedAt:     receivedAt,
+	}
+}
+
+// fitsIn returns a fits func for the remaining resources
+func fitsIn(remaining state.BatchResources) func(tx *TxTracker) bool {
+	return func(tx *TxTracker) bool {
+		fits, _ := remaining.Fits(state.BatchResources{ZKCounters: tx.ReservedZKCounters, Bytes: tx.Bytes})
+		return fits
+	}
+}
+
+func TestNewTxOrderingStrategy(t *testing.T) {
+	for ordering, expected := range map[TxOrdering]txOrderingStrategy{
+		"":                   &gasPriceOrdering{},
+		GasPriceTxOrdering:   &gasPriceOrdering{},
+		FIFOTxOrdering:       &fifoOrdering{},
+		EfficiencyTxOrdering: &efficiencyOrdering{constraints: rcMax},
+		RoundRobinTxOrdering: &roundRobinOrdering{lastSelected: map[string]uint64{}},
+	} {
+		strategy, err := newTxOrderingStrategy(ordering, rcMax)
+		require.NoError(t, err)
+		assert.Equal(t, expected, strategy)
+	}
+
+	_, err := newTxOrderingStrategy("random", rcMax)
+	assert.ErrorContains(t, err, "unsupported")
+}
+
+func TestTxOrderingStrategies(t *testing.T) {
+	now := time.Now()
+	txs := newTxSortedList()
+	// cheapest and oldest
+	txs.add(newOrderingTx("0x01", "0xa", 10, 2, now.Add(-time.Minute)))
+	// most expensive but big
+	txs.add(newOrderingTx("0x02", "0xb", 100, 8, now))
+	// same sender than the most expensive, small and pays a lot per counter
+	txs.add(newOrderingTx("0x03", "0xb", 90, 1, now.Add(-time.Second)))
+	// the oldest, too big for the remaining resources
+	txs.add(newOrderingTx("0x04", "0xc", 50, 6, now.Add(-time.Hour)))
+
+	remaining := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 9, KeccakHashes: 9, PoseidonHashes: 9, PoseidonPaddings: 9, MemAligns: 9, Arithmetics: 9, Binaries: 9, Steps: 9, Sha256Hashes_V2: 9},
+		Bytes:      5,
+	}
+
+	testCases := []struct {
+		ordering TxOrdering
+		expected []string
+	}{
+		{ordering: GasPriceTxOrdering, expected: []string{"0x03", "0x03", "0x03"}},
+		{ordering: FIFOTxOrdering, expected: []string{"0x01", "0x01", "0x01"}},
+		{ordering: EfficiencyTxOrdering, expected: []string{"0x03", "0x03", "0x03"}},
+		// the sender 0xb has to wait for 0xa after getting a tx in the batch
+		{ordering: RoundRobinTxOrdering, expected: []string{"0x03", "0x01", "0x03"}},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.ordering), func(t *testing.T) {
+			strategy, err := newTxOrderingStrategy(tc.ordering, rcMax)
+			require.NoError(t, err)
+
+			for i, expected := range tc.expected {
+				tx := strategy.selectTx(txs, fitsIn(remaining))
+				require.NotNil(t, tx)
+				assert.Equal(t, expected, tx.HashStr, "selection %d", i)
+				strategy.selected(tx)
+			}
+
+			// no tx fits in an exhausted batch
+			assert.Nil(t, strategy.selectTx(txs, fitsIn(state.BatchResources{})))
+		})
+	}
+}
+
+func TestFIFOOrderingFallsBackToWorkerArrival(t *testing.T) {
+	now := time.Now()
+	txs := newTxSortedList()
+	fromPool := newOrderingTx("0x01", "0xa", 10, 1, now)
+	restored := newOrderingTx("0x02", "0xb", 10, 1, time.Time{})
+	restored.ReceivedAt = now.Add(-time.Minute)
+	txs.add(fromPool)
+	txs.add(restored)
+
+	tx := (&fifoOrdering{}).selectTx(txs, fitsIn(state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 10, KeccakHashes: 10, PoseidonHashes: 10, PoseidonPaddings: 10, MemAligns: 10, Arithmetics: 10, Binaries: 10, Steps: 10, Sha256Hashes_V2: 10}, Bytes: 10}))
+	assert.Equal(t, restored, tx)
+}
+
+func TestRoundRobinOrderingForgetsIdleSenders(t *testing.T) {
+	strategy := &roundRobinOrdering{lastSelected: map[string]uint64{}}
+	for i := 0; i < 5; i++ {
+		strategy.selected(&TxTracker{FromStr: fmt.Sprintf("0x%d", i)})
+	}
+
+	txs := newTxSortedList()
+	txs.add(newOrderingTx("0x01", "0x4", 10, 1, time.Now()))
+	strategy.selectTx(txs, func(tx *TxTracker) bool { return true })
+	assert.Equal(t, map[string]uint64{"0x4": 5}, strategy.lastSelected)
+}
+
+// BenchmarkTxOrderingFillRatio fills batches with random txs from a few senders and reports the mean usage of the most
+// used resource of the closed batches, so the strategies can be compared by how well they pack the batches
+func BenchmarkTxOrderingFillRatio(b *testing.B) {
+	const (
+		nTxs     = 2000
+		nSenders = 50
+		maxCount = 10000
+	)
+	constraints := state.BatchConstraintsCfg{
+		MaxCumulativeGasUsed: maxCount,
+		MaxKeccakHashes:      maxCount,
+		MaxPoseidonHashes:    maxCount,
+		MaxPoseidonPaddings:  maxCount,
+		MaxMemAligns:         maxCount,
+		MaxArithmetics:       maxCount,
+		MaxBinaries:          maxCount,
+		MaxSteps:             maxCount,
+		MaxSHA256Hashes:      maxCount,
+		MaxBatchBytesSize:    maxCount,
+	}
+
+	for _, ordering := range []TxOrdering{GasPriceTxOrdering, FIFOTxOrdering, EfficiencyTxOrdering, RoundRobinTxOrdering} {
+		b.Run(string(ordering), func(b *testing.B) {
+			var fillRatio float64
+			var nBatches int
+			for n := 0; n < b.N; n++ {
+				b.StopTimer()
+				// the same txs are used for all the strategies
+				random := rand.New(rand.NewSource(int64(n))) //nolint:gosec
+				now := time.Now()
+				txs := newTxSortedList()
+				for i := 0; i < nTxs; i++ {
+					tx := newOrderingTx(fmt.Sprintf("0x%d", i), fmt.Sprintf("0x%d", random.Intn(nSenders)), 1+random.Int63n(1000), 0, now.Add(time.Duration(random.Intn(nTxs))*time.Second))
+					tx.ReservedZKCounters = state.ZKCounters{
+						GasUsed:          uint64(1 + random.Intn(maxCount/10)),
+						KeccakHashes:     uint32(random.Intn(maxCount / 10)),
+						PoseidonHashes:   uint32(random.Intn(maxCount / 10)),
+						PoseidonPaddings: uint32(random.Intn(maxCount / 10)),
+						MemAligns:        uint32(random.Intn(maxCount / 10)),
+						Arithmetics:      uint32(random.Intn(maxCount / 10)),
+						Binaries:         uint32(random.Intn(maxCount / 10)),
+						Steps:            uint32(random.Intn(maxCount / 10)),
+						Sha256Hashes_V2:  uint32(random.Intn(maxCount / 10)),
+					}
+					tx.UsedZKCounters = tx.ReservedZKCounters
+					tx.Bytes = uint64(1 + random.Intn(maxCount/10))
+					txs.add(tx)
+				}
+				strategy, err := newTxOrderingStrategy(ordering, constraints)
+				require.NoError(b, err)
+				b.StartTimer()
+
+				for txs.len() > 0 {
+					remaining := state.BatchResources{
+						ZKCounters: state.ZKCounters{GasUsed: maxCount, KeccakHashes: maxCount, PoseidonHashes: maxCount, PoseidonPaddings: maxCount, MemAligns: maxCount, Arithmetics: maxCount, Binaries: maxCount, Steps: maxCount, Sha256Hashes_V2: maxCount},
+						Bytes:      maxCount,
+					}
+					for {
+						tx := strategy.selectTx(txs, fitsIn(remaining))
+						if tx == nil {
+							break
+						}
+						strategy.selected(tx)
+						remaining.Sub(state.BatchResources{ZKCounters: tx.ReservedZKCounters, Bytes: tx.Bytes})
+						txs.delete(tx)
+					}
+					fillRatio += 1 - float64(minRemaining(remaining))/maxCount
+					nBatches++
+				}
+			}
+			b.ReportMetric(fillRatio/float64(nBatches), "fill/batch")
+			b.ReportMetric(float64(nBatches)/float64(b.N), "batches/op")
+		})
+	}
+}
+
+// minRemaining returns the remaining amount of the most used resource
+func minRemaining(r state.BatchResources) uint64 {
+	min := r.Bytes
+	for _, remaining := range []uint64{r.ZKCounters.GasUsed, uint64(r.ZKCounters.KeccakHashes), uint64(r.ZKCounters.PoseidonHashes),
+		uint64(r.ZKCounters.PoseidonPaddings), uint64(r.ZKCounters.MemAligns), uint64(r.ZKCounters.Arithmetics),
+		uint64(r.ZKCounters.Binaries), uint64(r.ZKCounters.Steps), uint64(r.ZKCounters.Sha256Hashes_V2)} {
+		if remaining < min {
+			min = remaining
+		}
+	}
+	return min
+}
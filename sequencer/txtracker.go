@@ -15,6 +15,7 @@ type TxTracker struct {
 	HashStr            string
 	From               common.Address
 	FromStr            string
+	To                 *common.Address
 	Nonce              uint64
 	Gas                uint64 // To check if it fits into a batch
 	GasPrice           *big.Int
@@ -24,6 +25,7 @@ type TxTracker struct {
 	ReservedZKCounters state.ZKCounters
 	RawTx              []byte
 	ReceivedAt         time.Time // To check if it has been in the txSortedList for too long
+	PoolReceivedAt     time.Time // To sort the txs by arrival to the pool
 	IP                 string    // IP of the tx sender
 	FailedReason       *string   // FailedReason is the reason why the tx failed, if it failed
 	EffectiveGasPrice  *big.Int
@@ -32,6 +34,8 @@ type TxTracker struct {
 	EGPLog             state.EffectiveGasPriceLog
 	L1GasPrice         uint64
 	L2GasPrice         uint64
+	Lane               string // Priority lane in which the tx has been selected, empty if none
+	Sponsor            string // Sponsor paying the fee of the zero gas price tx, empty if none
 }
 
 // newTxTracker creates and inti a TxTracker
@@ -51,6 +55,7 @@ func newTxTracker(tx types.Transaction, usedZKCounters state.ZKCounters, reserve
 		HashStr:            tx.Hash().String(),
 		From:               addr,
 		FromStr:            addr.String(),
+		To:                 tx.To(),
 		Nonce:              tx.Nonce(),
 		Gas:                tx.Gas(),
 		GasPrice:           tx.GasPrice(),
@@ -80,3 +85,11 @@ func (tx *TxTracker) updateZKCounters(usedZKCounters state.ZKCounters, reservedZ
 	tx.UsedZKCounters = usedZKCounters
 	tx.ReservedZKCounters = reservedZKCounters
 }
+
+// arrivedAt returns the time the tx was received by the pool, or by the worker if unknown
+func (tx *TxTracker) arrivedAt() time.Time {
+	if tx.PoolReceivedAt.IsZero() {
+		return tx.ReceivedAt
+	}
+	return tx.PoolReceivedAt
+}
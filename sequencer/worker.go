@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"math/big"
-	"runtime"
 	"sync"
 	"time"
 
@@ -26,10 +25,13 @@ type Worker struct {
 	batchConstraints state.BatchConstraintsCfg
 	readyTxsCond     *timeoutCond
 	wipTx            *TxTracker
+	txOrdering       txOrderingStrategy
+	lanes            []priorityLane
+	laneMembers      map[common.Address]int // index of the lane of each member
 }
 
 // NewWorker creates an init a worker
-func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, readyTxsCond *timeoutCond) *Worker {
+func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, txOrdering txOrderingStrategy, lanes []priorityLane, readyTxsCond *timeoutCond) *Worker {
 	w := Worker{
 		pool:             make(map[string]*addrQueue),
 		workerMutex:      new(sync.Mutex),
@@ -38,6 +40,9 @@ func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, read
 		state:            state,
 		batchConstraints: constraints,
 		readyTxsCond:     readyTxsCond,
+		txOrdering:       txOrdering,
+		lanes:            lanes,
+		laneMembers:      make(map[common.Address]int),
 	}
 
 	return &w
@@ -404,8 +409,9 @@ func (w *Worker) DeleteTxPendingToStore(txHash common.Hash, addr common.Address)
 	}
 }
 
-// GetBestFittingTx gets the most efficient tx that fits in the available batch resources
-func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters) (*TxTracker, error) {
+// GetBestFittingTx gets the most efficient tx that fits in the available batch resources. The resources reserved for the
+// priority lanes not used yet by them (lanesUsedResources) are only available for the txs of each lane
+func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters, lanesUsedResources map[string]state.BatchResources) (*TxTracker, error) {
 	w.workerMutex.Lock()
 	defer w.workerMutex.Unlock()
 
@@ -430,52 +436,33 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 		return nil, ErrTransactionsListEmpty
 	}
 
-	var (
-		tx         *TxTracker
-		foundMutex sync.RWMutex
-	)
-
-	nGoRoutines := runtime.NumCPU()
-	foundAt := -1
-
-	wg := sync.WaitGroup{}
-	wg.Add(nGoRoutines)
-
-	// Each go routine looks for a fitting tx
-	for i := 0; i < nGoRoutines; i++ {
-		go func(n int, bresources state.BatchResources) {
-			defer wg.Done()
-			for i := n; i < w.txSortedList.len(); i += nGoRoutines {
-				foundMutex.RLock()
-				if foundAt != -1 && i > foundAt {
-					foundMutex.RUnlock()
-					return
-				}
-				foundMutex.RUnlock()
-
-				txCandidate := w.txSortedList.getByIndex(i)
-				needed, _ := getNeededZKCounters(highReservedCounters, txCandidate.UsedZKCounters, txCandidate.ReservedZKCounters)
-				fits, _ := bresources.Fits(state.BatchResources{ZKCounters: needed, Bytes: txCandidate.Bytes})
-				if !fits {
-					// We don't add this Tx
-					continue
-				}
-
-				foundMutex.Lock()
-				if foundAt == -1 || foundAt > i {
-					foundAt = i
-					tx = txCandidate
-				}
-				foundMutex.Unlock()
-
-				return
-			}
-		}(i, remainingResources)
+	unusedReserved := make([]state.BatchResources, len(w.lanes))
+	totalUnusedReserved := state.BatchResources{}
+	for i, lane := range w.lanes {
+		unusedReserved[i] = subResources(lane.reserved, lanesUsedResources[lane.name])
+		totalUnusedReserved.SumUp(unusedReserved[i])
 	}
-	wg.Wait()
+	sharedResources := subResources(remainingResources, totalUnusedReserved)
+
+	tx := w.txOrdering.selectTx(w.txSortedList, func(txCandidate *TxTracker) bool {
+		availableResources := sharedResources
+		if lane := w.laneOf(txCandidate); lane != -1 {
+			// The tx can use the resources reserved for its lane, but not the ones reserved for the other lanes
+			availableResources = subResources(remainingResources, subResources(totalUnusedReserved, unusedReserved[lane]))
+		}
 
-	if foundAt != -1 {
-		log.Debugf("best fitting tx %s found at index %d with gasPrice %d", tx.HashStr, foundAt, tx.GasPrice)
+		needed, _ := getNeededZKCounters(highReservedCounters, txCandidate.UsedZKCounters, txCandidate.ReservedZKCounters)
+		fits, _ := availableResources.Fits(state.BatchResources{ZKCounters: needed, Bytes: txCandidate.Bytes})
+		return fits
+	})
+
+	if tx != nil {
+		log.Debugf("best fitting tx %s found with gasPrice %d", tx.HashStr, tx.GasPrice)
+		w.txOrdering.selected(tx)
+		tx.Lane = ""
+		if lane := w.laneOf(tx); lane != -1 {
+			tx.Lane = w.lanes[lane].name
+		}
 		w.wipTx = tx
 		return tx, nil
 	} else {
@@ -483,6 +470,38 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 	}
 }
 
+// UpdatePriorityLaneMembers sets the members of the priority lanes, the members of lanes not configured are ignored
+func (w *Worker) UpdatePriorityLaneMembers(members []pool.PriorityLaneMember) {
+	laneMembers := make(map[common.Address]int, len(members))
+	// An address member of several lanes belongs to the first one
+	for i, lane := range w.lanes {
+		for _, member := range members {
+			if _, found := laneMembers[member.Address]; !found && member.Lane == lane.name {
+				laneMembers[member.Address] = i
+			}
+		}
+	}
+
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	w.laneMembers = laneMembers
+}
+
+// laneOf returns the index of the priority lane of the tx, checking both its sender and target, or -1 if it hasn't one
+func (w *Worker) laneOf(tx *TxTracker) int {
+	lane := -1
+	if fromLane, found := w.laneMembers[tx.From]; found {
+		lane = fromLane
+	}
+	if tx.To != nil {
+		if toLane, found := w.laneMembers[*tx.To]; found && (lane == -1 || toLane < lane) {
+			lane = toLane
+		}
+	}
+	return lane
+}
+
 // ExpireTransactions deletes old txs
 func (w *Worker) ExpireTransactions(maxTime time.Duration) []*TxTracker {
 	w.workerMutex.Lock()
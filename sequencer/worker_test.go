@@ -258,7 +258,7 @@ func TestWorkerGetBestTx(t *testing.T) {
 	ct := 0
 
 	for {
-		tx, _ := worker.GetBestFittingTx(rc, state.ZKCounters{})
+		tx, _ := worker.GetBestFittingTx(rc, state.ZKCounters{}, nil)
 		if tx != nil {
 			if ct >= len(expectedGetBestTx) {
 				t.Fatalf("Error getting more best tx than expected. Expected=%d, Actual=%d", len(expectedGetBestTx), ct+1)
@@ -284,6 +284,6 @@ func TestWorkerGetBestTx(t *testing.T) {
 }
 
 func initWorker(stateMock *StateMock, rcMax state.BatchConstraintsCfg) *Worker {
-	worker := NewWorker(stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	worker := NewWorker(stateMock, rcMax, &gasPriceOrdering{}, nil, newTimeoutCond(&sync.Mutex{}))
 	return worker
 }
@@ -0,0 +1,58 @@
+package sequencesender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ethermanTypes "github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/blob"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// batchBlobData returns the L2 data of the sequences encoded to be attached to the sequence tx as blobs.
+// It returns nil if the batch data isn't sent in blobs, the rollup contract of the fork of the sequence
+// doesn't accept blobs or L1 doesn't support blob txs
+func (s *SequenceSender) batchBlobData(ctx context.Context, sequences []types.Sequence) ([]byte, error) {
+	if !s.cfg.BatchDataInBlobs {
+		return nil, nil
+	}
+
+	if forkID := s.state.GetForkIDByBatchNumber(sequences[0].BatchNumber); forkID < s.cfg.BlobsForkID {
+		log.Debugf("fork ID %d of batch %d is below the blobs fork ID %d, sending the sequence without blobs", forkID, sequences[0].BatchNumber, s.cfg.BlobsForkID)
+		return nil, nil
+	}
+
+	_, err := s.etherman.SuggestedBlobGasPrice(ctx)
+	if errors.Is(err, ethermanTypes.ErrBlobsNotSupported) {
+		log.Warn("L1 doesn't support blob txs, sending the sequence without blobs")
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check if L1 supports blob txs, err: %w", err)
+	}
+
+	batches := make([]blob.Batch, 0, len(sequences))
+	for _, seq := range sequences {
+		batches = append(batches, blob.Batch{Number: seq.BatchNumber, L2Data: seq.BatchL2Data})
+	}
+	return blob.EncodeBatches(batches), nil
+}
+
+// addSequenceTx adds the sequence tx to the eth tx manager, attaching the blob data as blobs if it's not empty
+func (s *SequenceSender) addSequenceTx(ctx context.Context, monitoredTxID string, from common.Address, to *common.Address, data, blobData []byte) error {
+	if len(blobData) == 0 {
+		return s.ethTxManager.Add(ctx, ethTxManagerOwner, monitoredTxID, from, to, nil, data, s.cfg.GasOffset, nil)
+	}
+
+	blobs, err := blob.Encode(blobData)
+	if err != nil {
+		return err
+	}
+	sidecar, err := blob.NewSidecar(blobs)
+	if err != nil {
+		return fmt.Errorf("failed to compute the blob sidecar, err: %w", err)
+	}
+	return s.ethTxManager.AddWithBlobs(ctx, ethTxManagerOwner, monitoredTxID, from, to, nil, data, s.cfg.GasOffset, sidecar, nil)
+}
@@ -45,4 +45,33 @@ type Config struct {
 	MaxBatchesForL1 uint64 `mapstructure:"MaxBatchesForL1"`
 	// SequenceL1BlockConfirmations is number of blocks to consider a sequence sent to L1 as final
 	SequenceL1BlockConfirmations uint64 `mapstructure:"SequenceL1BlockConfirmations"`
+	// BatchDataInBlobs attaches the L2 data of the sequenced batches to the sequence tx as EIP-4844 blobs, so it's
+	// also available on L1. The sequences are limited to the batches that fit in the blobs of a tx. If L1 doesn't
+	// support blob txs, the sequences are sent without blobs
+	BatchDataInBlobs bool `mapstructure:"BatchDataInBlobs"`
+	// BlobsForkID is the first fork ID whose rollup contract accepts sequence txs with blobs. The sequences of batches
+	// of previous forks are sent without blobs. It's required if BatchDataInBlobs is enabled
+	BlobsForkID uint64 `mapstructure:"BlobsForkID"`
+	// SendPolicy decides when a sequence is sent to L1 and how many batches it includes, based on its estimated cost
+	SendPolicy SendPolicyConfig `mapstructure:"SendPolicy"`
+}
+
+// SendPolicyConfig represents the configuration of the cost aware send policy
+type SendPolicyConfig struct {
+	// Enabled makes the policy decide when to send the sequences. If disabled, a sequence is sent when it reaches
+	// MaxBatchesForL1 or when LastBatchVirtualizationTimeMaxWaitPeriod has elapsed since the last virtualized batch
+	Enabled bool `mapstructure:"Enabled"`
+	// DryRun makes the policy log its decisions without applying them, whether it's enabled or not
+	DryRun bool `mapstructure:"DryRun"`
+	// MaxBaseFee is the L1 base fee (in wei) above which sequences are delayed until
+	// LastBatchVirtualizationTimeMaxWaitPeriod elapses. If 0, the base fee doesn't delay the sequences
+	MaxBaseFee uint64 `mapstructure:"MaxBaseFee"`
+	// TargetCostPerBatch is the estimated L1 cost per batch (in wei) at which a sequence is sent without waiting for
+	// MaxBatchesForL1 or LastBatchVirtualizationTimeMaxWaitPeriod. The sequence includes the smallest amount of
+	// batches that meets the target, and the rest are left for the next one. If 0, the sequences are never sent early
+	TargetCostPerBatch uint64 `mapstructure:"TargetCostPerBatch"`
+	// FixedGasPerSequence is the estimated gas used by a sequence tx regardless of its amount of batches
+	FixedGasPerSequence uint64 `mapstructure:"FixedGasPerSequence"`
+	// GasPerBatch is the estimated gas used by each batch of a sequence tx
+	GasPerBatch uint64 `mapstructure:"GasPerBatch"`
 }
@@ -21,6 +21,8 @@ type etherman interface {
 	EstimateGasSequenceBatches(sender common.Address, sequences []ethmanTypes.Sequence, maxSequenceTimestamp uint64, initSequenceBatchNumber uint64, l2Coinbase common.Address, committeeSignaturesAndAddrs []byte) (*types.Transaction, error)
 	GetLatestBlockHeader(ctx context.Context) (*types.Header, error)
 	GetLatestBatchNumber() (uint64, error)
+	GetRevertMessage(ctx context.Context, tx *types.Transaction) (string, error)
+	SuggestedBlobGasPrice(ctx context.Context) (*big.Int, error)
 }
 
 // stateInterface gathers the methods required to interact with the state.
@@ -35,13 +37,23 @@ type stateInterface interface {
 	GetLastClosedBatch(ctx context.Context, dbTx pgx.Tx) (*state.Batch, error)
 	GetLastL2BlockByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.L2Block, error)
 	GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error)
+	GetForkIDByBatchNumber(batchNumber uint64) uint64
 }
 
 type ethTxManager interface {
 	Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, dbTx pgx.Tx) error
+	AddWithBlobs(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, blobSidecar *types.BlobTxSidecar, dbTx pgx.Tx) error
 	ProcessPendingMonitoredTxs(ctx context.Context, owner string, failedResultHandler ethtxmanager.ResultHandler, dbTx pgx.Tx)
+	SetStatusDone(ctx context.Context, owner, id string, dbTx pgx.Tx) error
 }
 
 type dataAbilitier interface {
 	PostSequence(ctx context.Context, sequences []ethmanTypes.Sequence) ([]byte, error)
 }
+
+// storageInterface gathers the methods required to persist the pending sequences.
+type storageInterface interface {
+	AddPendingSequence(ctx context.Context, seq PendingSequence, dbTx pgx.Tx) error
+	GetPendingSequences(ctx context.Context, dbTx pgx.Tx) ([]PendingSequence, error)
+	DeletePendingSequence(ctx context.Context, monitoredID string, dbTx pgx.Tx) error
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Prefix for the metrics of the sequencesender package.
+	Prefix = "sequencesender_"
+
+	// EstimatedCostPerBatchName is the name of the metric with the estimated L1 cost per batch of the next sequence.
+	EstimatedCostPerBatchName = Prefix + "estimated_cost_per_batch"
+
+	// SendDecisionsName is the name of the metric that counts the decisions of the send policy.
+	SendDecisionsName = Prefix + "send_decisions"
+
+	// SendDecisionLabelName is the name of the label with the send policy decision.
+	SendDecisionLabelName = "decision"
+)
+
+// SendDecision is the decision taken by the send policy
+type SendDecision string
+
+const (
+	// SendDecisionSend means the sequence is sent
+	SendDecisionSend SendDecision = "send"
+	// SendDecisionWait means the sequence is delayed
+	SendDecisionWait SendDecision = "wait"
+)
+
+// Register the metrics for the sequencesender package.
+func Register() {
+	gauges := []prometheus.GaugeOpts{
+		{
+			Name: EstimatedCostPerBatchName,
+			Help: "[SEQUENCESENDER] estimated L1 cost per batch of the next sequence, in wei",
+		},
+	}
+
+	counterVecs := []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: SendDecisionsName,
+				Help: "[SEQUENCESENDER] decisions of the send policy",
+			},
+			Labels: []string{SendDecisionLabelName},
+		},
+	}
+
+	metrics.RegisterGauges(gauges...)
+	metrics.RegisterCounterVecs(counterVecs...)
+}
+
+// EstimatedCostPerBatch sets the gauge to the estimated cost per batch, in wei.
+func EstimatedCostPerBatch(cost float64) {
+	metrics.GaugeSet(EstimatedCostPerBatchName, cost)
+}
+
+// SendDecisionTaken increments the counter of the send policy decision.
+func SendDecisionTaken(decision SendDecision) {
+	metrics.CounterVecInc(SendDecisionsName, string(decision))
+}
@@ -13,6 +13,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	pgx "github.com/jackc/pgx/v4"
+
+	types "github.com/ethereum/go-ethereum/core/types"
 )
 
 // EthTxManagerMock is an autogenerated mock type for the ethTxManager type
@@ -38,11 +40,47 @@ func (_m *EthTxManagerMock) Add(ctx context.Context, owner string, id string, fr
 	return r0
 }
 
+// AddWithBlobs provides a mock function with given fields: ctx, owner, id, from, to, value, data, gasOffset, blobSidecar, dbTx
+func (_m *EthTxManagerMock) AddWithBlobs(ctx context.Context, owner string, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, blobSidecar *types.BlobTxSidecar, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, owner, id, from, to, value, data, gasOffset, blobSidecar, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWithBlobs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, common.Address, *common.Address, *big.Int, []byte, uint64, *types.BlobTxSidecar, pgx.Tx) error); ok {
+		r0 = rf(ctx, owner, id, from, to, value, data, gasOffset, blobSidecar, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ProcessPendingMonitoredTxs provides a mock function with given fields: ctx, owner, failedResultHandler, dbTx
 func (_m *EthTxManagerMock) ProcessPendingMonitoredTxs(ctx context.Context, owner string, failedResultHandler ethtxmanager.ResultHandler, dbTx pgx.Tx) {
 	_m.Called(ctx, owner, failedResultHandler, dbTx)
 }
 
+// SetStatusDone provides a mock function with given fields: ctx, owner, id, dbTx
+func (_m *EthTxManagerMock) SetStatusDone(ctx context.Context, owner string, id string, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, owner, id, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatusDone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, pgx.Tx) error); ok {
+		r0 = rf(ctx, owner, id, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewEthTxManagerMock creates a new instance of EthTxManagerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEthTxManagerMock(t interface {
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.39.0. DO NOT EDIT.
+
+package sequencesender
+
+import (
+	context "context"
+
+	pgx "github.com/jackc/pgx/v4"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StorageMock is an autogenerated mock type for the storageInterface type
+type StorageMock struct {
+	mock.Mock
+}
+
+// AddPendingSequence provides a mock function with given fields: ctx, seq, dbTx
+func (_m *StorageMock) AddPendingSequence(ctx context.Context, seq PendingSequence, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, seq, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPendingSequence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, PendingSequence, pgx.Tx) error); ok {
+		r0 = rf(ctx, seq, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePendingSequence provides a mock function with given fields: ctx, monitoredID, dbTx
+func (_m *StorageMock) DeletePendingSequence(ctx context.Context, monitoredID string, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, monitoredID, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePendingSequence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pgx.Tx) error); ok {
+		r0 = rf(ctx, monitoredID, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPendingSequences provides a mock function with given fields: ctx, dbTx
+func (_m *StorageMock) GetPendingSequences(ctx context.Context, dbTx pgx.Tx) ([]PendingSequence, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingSequences")
+	}
+
+	var r0 []PendingSequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) ([]PendingSequence, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) []PendingSequence); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]PendingSequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStorageMock creates a new instance of StorageMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorageMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StorageMock {
+	mock := &StorageMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
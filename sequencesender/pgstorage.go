@@ -0,0 +1,135 @@
+package sequencesender
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PendingSequence is a sequence that has been posted to the data availability layer and
+// whose L1 transaction hasn't been confirmed yet
+type PendingSequence struct {
+	MonitoredID string          `json:"monitoredId"`
+	FromBatch   uint64          `json:"fromBatch"`
+	ToBatch     uint64          `json:"toBatch"`
+	From        common.Address  `json:"from"`
+	To          *common.Address `json:"to"`
+	// DAMessage is the message returned by the data availability layer, e.g. the committee signatures
+	DAMessage []byte `json:"daMessage"`
+	// TxData is the input of the sequenceBatches L1 transaction
+	TxData []byte `json:"txData"`
+	// BlobData is the L2 data of the batches attached to the tx as blobs, empty if the tx doesn't carry blobs
+	BlobData  []byte    `json:"blobData"`
+	CreatedAt time.Time `json:"createdAt"`
+	// MonitoredTxStatus is the status of the monitored tx in the eth tx manager, empty if the
+	// sequence hasn't been added to it yet
+	MonitoredTxStatus string `json:"monitoredTxStatus"`
+}
+
+// PostgresStorage persists the pending sequences of the sequence sender
+type PostgresStorage struct {
+	*pgxpool.Pool
+}
+
+// NewPostgresStorage creates a new instance of storage that use
+// postgres to store data
+func NewPostgresStorage(dbCfg db.Config) (*PostgresStorage, error) {
+	db, err := db.NewSQLDB(dbCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresStorage{
+		db,
+	}, nil
+}
+
+// AddPendingSequence persists a pending sequence, overwriting it if it already exists
+func (s *PostgresStorage) AddPendingSequence(ctx context.Context, seq PendingSequence, dbTx pgx.Tx) error {
+	conn := s.dbConn(dbTx)
+	cmd := `
+        INSERT INTO state.pending_sequence (monitored_id, from_batch, to_batch, from_addr, to_addr, da_message, tx_data, blob_data, created_at)
+                                    VALUES (          $1,         $2,       $3,        $4,      $5,         $6,      $7,        $8,         $9)
+        ON CONFLICT (monitored_id) DO UPDATE SET
+            from_batch = EXCLUDED.from_batch, to_batch = EXCLUDED.to_batch, from_addr = EXCLUDED.from_addr,
+            to_addr = EXCLUDED.to_addr, da_message = EXCLUDED.da_message, tx_data = EXCLUDED.tx_data, blob_data = EXCLUDED.blob_data`
+
+	var to *string
+	if seq.To != nil {
+		addr := seq.To.String()
+		to = &addr
+	}
+	_, err := conn.Exec(ctx, cmd, seq.MonitoredID, seq.FromBatch, seq.ToBatch, seq.From.String(), to,
+		seq.DAMessage, seq.TxData, seq.BlobData, time.Now().UTC().Round(time.Microsecond))
+	return err
+}
+
+// GetPendingSequences loads the pending sequences ordered by their first batch, together with
+// the status of their monitored tx
+func (s *PostgresStorage) GetPendingSequences(ctx context.Context, dbTx pgx.Tx) ([]PendingSequence, error) {
+	conn := s.dbConn(dbTx)
+	cmd := `
+        SELECT p.monitored_id, p.from_batch, p.to_batch, p.from_addr, p.to_addr, p.da_message, p.tx_data, p.blob_data, p.created_at, m.status
+          FROM state.pending_sequence p
+     LEFT JOIN state.monitored_txs m ON m.owner = $1 AND m.id = p.monitored_id
+      ORDER BY p.from_batch`
+
+	rows, err := conn.Query(ctx, cmd, ethTxManagerOwner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sequences := []PendingSequence{}
+	for rows.Next() {
+		var (
+			seq    PendingSequence
+			from   string
+			to     *string
+			status *string
+		)
+		err := rows.Scan(&seq.MonitoredID, &seq.FromBatch, &seq.ToBatch, &from, &to, &seq.DAMessage, &seq.TxData, &seq.BlobData, &seq.CreatedAt, &status)
+		if err != nil {
+			return nil, err
+		}
+		seq.From = common.HexToAddress(from)
+		if to != nil {
+			addr := common.HexToAddress(*to)
+			seq.To = &addr
+		}
+		if status != nil {
+			seq.MonitoredTxStatus = *status
+		}
+		sequences = append(sequences, seq)
+	}
+	return sequences, rows.Err()
+}
+
+// DeletePendingSequence removes a pending sequence
+func (s *PostgresStorage) DeletePendingSequence(ctx context.Context, monitoredID string, dbTx pgx.Tx) error {
+	conn := s.dbConn(dbTx)
+	const cmd = "DELETE FROM state.pending_sequence WHERE monitored_id = $1"
+	_, err := conn.Exec(ctx, cmd, monitoredID)
+	return err
+}
+
+// dbConn represents an instance of an object that can
+// connect to a postgres db to execute sql commands and query data
+type dbConn interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (commandTag pgconn.CommandTag, err error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// dbConn determines which db connection to use, dbTx or the main pgxpool
+func (s *PostgresStorage) dbConn(dbTx pgx.Tx) dbConn {
+	if dbTx != nil {
+		return dbTx
+	}
+	return s
+}
@@ -0,0 +1,240 @@
+package sequencesender
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	monitoredIDRecoveryFormat   = "sequence-from-%v-to-%v-attempt-%v"
+	maxSequenceRecoveryAttempts = 5
+)
+
+// revertClass groups the reasons why a sequence tx can fail on L1
+type revertClass int
+
+const (
+	revertUnknown revertClass = iota
+	revertTimestamp
+	revertGlobalExitRoot
+	revertDASignature
+	revertNonce
+	revertOutOfSync
+	revertUnrecoverable
+)
+
+// recoveryAction is what the sequence sender does to send again a failed sequence
+type recoveryAction int
+
+const (
+	// actionRebuild sends the same batches in a new tx, reusing the DA message. The batches are read again
+	// from the state, the timestamp limit is taken from the last L1 block and the eth tx manager takes the
+	// nonce of the new tx from L1
+	actionRebuild recoveryAction = iota
+	// actionResign sends the same batches after posting them to the DA layer again
+	actionResign
+	// actionTrim sends the first half of the batches after posting them to the DA layer again
+	actionTrim
+	// actionResync forgets the failed sequence and builds a new one from the last batch sequenced in the SC
+	actionResync
+	// actionHalt halts the sequence sender
+	actionHalt
+)
+
+// revertMessagesByClass maps the revert messages, including the names of the smart contracts custom errors,
+// to their class. The messages are matched ignoring case, and a message can be contained in the actual one
+var revertMessagesByClass = map[revertClass][]string{
+	revertTimestamp: {
+		"SequencedTimestampInvalid",
+		"SequencedTimestampBelowForcedTimestamp",
+		"MaxTimestampSequenceInvalid",
+		"timestamp must be inside range",
+	},
+	revertGlobalExitRoot: {
+		"GlobalExitRootNotExist",
+		"L1InfoTreeLeafCountInvalid",
+	},
+	revertDASignature: {
+		"UnexpectedAddrsAndSignaturesSize",
+		"UnexpectedAddrsBytesLength",
+		"UnexpectedCommitteeHash",
+		"WrongAddrOrder",
+		"CommitteeAddressDoesntExist",
+		"ECDSA",
+	},
+	revertNonce: {
+		"nonce",
+	},
+	revertOutOfSync: {
+		"InitSequencedBatchDoesNotMatch",
+		"ForcedDataDoesNotMatch",
+	},
+	revertUnrecoverable: {
+		"OnlyTrustedSequencer",
+		"OnlyRollupManager",
+		"SequenceWithDataAvailabilityNotAllowed",
+		"TransactionsLengthAboveMax",
+		"ForceBatchesOverflow",
+		"SequenceZeroBatches",
+	},
+}
+
+func (c revertClass) String() string {
+	switch c {
+	case revertTimestamp:
+		return "timestamp out of range"
+	case revertGlobalExitRoot:
+		return "wrong global exit root"
+	case revertDASignature:
+		return "invalid DA signature"
+	case revertNonce:
+		return "nonce issue"
+	case revertOutOfSync:
+		return "out of sync with the SC"
+	case revertUnrecoverable:
+		return "unrecoverable"
+	default:
+		return "unknown"
+	}
+}
+
+// action returns the recovery action for the class
+func (c revertClass) action() recoveryAction {
+	switch c {
+	case revertTimestamp, revertNonce:
+		return actionRebuild
+	case revertGlobalExitRoot:
+		return actionTrim
+	case revertOutOfSync:
+		return actionResync
+	case revertUnrecoverable:
+		return actionHalt
+	default:
+		// the DA message is the part of the tx that can't be checked locally, so it's collected again
+		return actionResign
+	}
+}
+
+// classifyRevert returns the class of a revert message
+func classifyRevert(revertMessage string) revertClass {
+	revertMessage = strings.ToLower(revertMessage)
+	// check the classes in order, so the result doesn't depend on the map iteration
+	for class := revertTimestamp; class <= revertUnrecoverable; class++ {
+		for _, msg := range revertMessagesByClass[class] {
+			if strings.Contains(revertMessage, strings.ToLower(msg)) {
+				return class
+			}
+		}
+	}
+	return revertUnknown
+}
+
+// sequenceRecovery keeps track of the sequence being sent again after its tx failed
+type sequenceRecovery struct {
+	fromBatch uint64
+	toBatch   uint64
+	// daMessage is reused if it isn't nil
+	daMessage []byte
+	// refreshTimestampLimit sets the timestamp limit of the sequence to the timestamp of the last L1 block
+	refreshTimestampLimit bool
+	attempts              int
+}
+
+// monitoredTxID returns the id of the monitored tx for the next attempt, which can't match the failed ones
+func (r *sequenceRecovery) monitoredTxID() string {
+	return fmt.Sprintf(monitoredIDRecoveryFormat, r.fromBatch, r.toBatch, r.attempts)
+}
+
+// timestampLimit returns the timestamp limit of the sequence for the next attempt. The last L1 block
+// timestamp is only used if it's above the timestamp of the last L2 block of the sequence
+func (r *sequenceRecovery) timestampLimit(lastL2BlockTimestamp, lastL1BlockTimestamp uint64) uint64 {
+	if r.refreshTimestampLimit && lastL1BlockTimestamp > lastL2BlockTimestamp {
+		return lastL1BlockTimestamp
+	}
+	return lastL2BlockTimestamp
+}
+
+// handleFailedSequence decides how to recover from a failed sequence tx and prepares the next attempt.
+// The monitored tx is set as done, so it's not processed again
+func (s *SequenceSender) handleFailedSequence(ctx context.Context, result ethtxmanager.MonitoredTxResult, dbTx pgx.Tx) {
+	mTxResultLogger := ethtxmanager.CreateMonitoredTxResultLogger(ethTxManagerOwner, result)
+
+	var fromBatch, toBatch uint64
+	if _, err := fmt.Sscanf(result.ID, monitoredIDFormat, &fromBatch, &toBatch); err != nil {
+		s.halt(ctx, fmt.Errorf("failed to read the batch range of the failed monitored tx %s: %v", result.ID, err))
+	}
+
+	revertMessage := s.getRevertMessage(ctx, result)
+	class := classifyRevert(revertMessage)
+	action := class.action()
+
+	attempts := 1
+	if s.recovery != nil && s.recovery.fromBatch == fromBatch {
+		attempts = s.recovery.attempts + 1
+	}
+	mTxResultLogger.Warnf("sequence [%d-%d] failed, revert message: %q, class: %s, attempt: %d", fromBatch, toBatch, revertMessage, class, attempts)
+
+	if action == actionHalt {
+		s.halt(ctx, fmt.Errorf("sequence [%d-%d] failed with unrecoverable revert %q", fromBatch, toBatch, revertMessage))
+	}
+	if attempts > maxSequenceRecoveryAttempts {
+		s.halt(ctx, fmt.Errorf("sequence [%d-%d] failed %d times, last revert message: %q", fromBatch, toBatch, attempts, revertMessage))
+	}
+
+	recovery := &sequenceRecovery{fromBatch: fromBatch, toBatch: toBatch, attempts: attempts}
+	switch action {
+	case actionRebuild:
+		recovery.refreshTimestampLimit = true
+		pendingSequences, err := s.storage.GetPendingSequences(ctx, dbTx)
+		if err != nil {
+			mTxResultLogger.Errorf("failed to get pending sequences, the sequence will be posted to the DA layer again, err: %v", err)
+		}
+		for _, seq := range pendingSequences {
+			if seq.MonitoredID == result.ID {
+				recovery.daMessage = seq.DAMessage
+			}
+		}
+	case actionTrim:
+		recovery.toBatch = fromBatch + (toBatch-fromBatch)/2 //nolint:gomnd
+	case actionResync:
+		recovery = nil
+	}
+
+	if err := s.ethTxManager.SetStatusDone(ctx, ethTxManagerOwner, result.ID, dbTx); err != nil {
+		// the failed monitored tx will be handled again in the next cycle
+		mTxResultLogger.Errorf("failed to set monitored tx as done, err: %v", err)
+		return
+	}
+	if err := s.storage.DeletePendingSequence(ctx, result.ID, dbTx); err != nil {
+		mTxResultLogger.Errorf("failed to delete pending sequence, err: %v", err)
+	}
+
+	s.recovery = recovery
+	s.lastSequenceInitialBatch = 0
+	s.lastSequenceEndBatch = 0
+}
+
+// getRevertMessage returns the revert message of the mined tx of a failed monitored tx
+func (s *SequenceSender) getRevertMessage(ctx context.Context, result ethtxmanager.MonitoredTxResult) string {
+	for _, tx := range result.Txs {
+		if tx.Receipt == nil || tx.Receipt.Status != types.ReceiptStatusFailed {
+			continue
+		}
+		if tx.RevertMessage != "" {
+			return tx.RevertMessage
+		}
+		revertMessage, err := s.etherman.GetRevertMessage(ctx, tx.Tx)
+		if err != nil {
+			log.Warnf("failed to get the revert message of tx %s: %v", tx.Tx.Hash(), err)
+			continue
+		}
+		return revertMessage
+	}
+	return ""
+}
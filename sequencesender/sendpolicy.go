@@ -0,0 +1,105 @@
+package sequencesender
+
+import (
+	"math/big"
+)
+
+// sendDecision is the result of evaluating the send policy for the batches ready to be sequenced
+type sendDecision struct {
+	send bool
+	// batches is the amount of batches to include in the sequence
+	batches int
+	// costPerBatch is the estimated L1 cost per batch of the sequence, in wei
+	costPerBatch *big.Int
+	reason       string
+}
+
+// sendPolicy decides when to send a sequence and how many batches to include, weighing the L1 base fee,
+// the cost of the sequence tx amortized among its batches and the time since the last batch was virtualized
+type sendPolicy struct {
+	cfg             SendPolicyConfig
+	maxBatchesForL1 uint64
+}
+
+func newSendPolicy(cfg SendPolicyConfig, maxBatchesForL1 uint64) *sendPolicy {
+	return &sendPolicy{cfg: cfg, maxBatchesForL1: maxBatchesForL1}
+}
+
+// estimateCostPerBatch returns the estimated cost per batch of a sequence tx with the given amount of batches
+func (p *sendPolicy) estimateCostPerBatch(baseFee *big.Int, batches int) *big.Int {
+	if batches <= 0 || baseFee == nil {
+		return big.NewInt(0)
+	}
+	gas := new(big.Int).SetUint64(p.cfg.GasPerBatch)
+	gas.Mul(gas, big.NewInt(int64(batches)))
+	gas.Add(gas, new(big.Int).SetUint64(p.cfg.FixedGasPerSequence))
+	cost := gas.Mul(gas, baseFee)
+	return cost.Div(cost, big.NewInt(int64(batches)))
+}
+
+// batchesForTarget returns the smallest amount of batches whose estimated cost per batch meets TargetCostPerBatch.
+// It returns false if the target can't be met at the given base fee, whatever the amount of batches
+func (p *sendPolicy) batchesForTarget(baseFee *big.Int) (int, bool) {
+	if baseFee == nil || baseFee.Sign() == 0 {
+		return 1, true
+	}
+	// the cost per batch is baseFee * (FixedGasPerSequence / batches + GasPerBatch), so the target is met when
+	// batches >= baseFee * FixedGasPerSequence / (TargetCostPerBatch - baseFee * GasPerBatch)
+	margin := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(p.cfg.GasPerBatch))
+	margin.Sub(new(big.Int).SetUint64(p.cfg.TargetCostPerBatch), margin)
+	if margin.Sign() <= 0 {
+		return 0, false
+	}
+	fixedCost := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(p.cfg.FixedGasPerSequence))
+	batches, rem := new(big.Int).QuoRem(fixedCost, margin, new(big.Int))
+	if rem.Sign() > 0 {
+		batches.Add(batches, big.NewInt(1))
+	}
+	if !batches.IsInt64() {
+		return 0, false
+	}
+	if batches.Sign() == 0 {
+		return 1, true
+	}
+	return int(batches.Int64()), true
+}
+
+// decide evaluates the policy for the batches ready to be sequenced. The deadline is reached when
+// LastBatchVirtualizationTimeMaxWaitPeriod has elapsed since the last virtualized batch
+func (p *sendPolicy) decide(baseFee *big.Int, readyBatches int, deadlineReached bool) sendDecision {
+	batches := readyBatches
+	if p.maxBatchesForL1 > 0 && uint64(batches) > p.maxBatchesForL1 {
+		batches = int(p.maxBatchesForL1)
+	}
+	decision := sendDecision{
+		batches:      batches,
+		costPerBatch: p.estimateCostPerBatch(baseFee, batches),
+	}
+
+	switch {
+	case batches == 0:
+		decision.reason = "no batches ready to be sequenced"
+	case deadlineReached:
+		decision.send = true
+		decision.reason = "max wait period since the last virtualized batch elapsed"
+	case p.cfg.MaxBaseFee != 0 && baseFee != nil && baseFee.Cmp(new(big.Int).SetUint64(p.cfg.MaxBaseFee)) > 0:
+		decision.reason = "L1 base fee is above the maximum"
+	case p.maxBatchesForL1 > 0 && uint64(batches) == p.maxBatchesForL1:
+		decision.send = true
+		decision.reason = "max batches for L1 reached"
+	case p.cfg.TargetCostPerBatch == 0:
+		decision.reason = "no target cost per batch"
+	default:
+		// send as soon as enough batches are ready to meet the target, leaving the rest for the next sequence
+		targetBatches, ok := p.batchesForTarget(baseFee)
+		if !ok || targetBatches > batches {
+			decision.reason = "estimated cost per batch is above the target"
+			return decision
+		}
+		decision.send = true
+		decision.batches = targetBatches
+		decision.costPerBatch = p.estimateCostPerBatch(baseFee, targetBatches)
+		decision.reason = "estimated cost per batch is below the target"
+	}
+	return decision
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/etherman/blob"
 	"github.com/0xPolygonHermez/zkevm-node/etherman/types"
 	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/sequencesender/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jackc/pgx/v4"
@@ -40,21 +43,32 @@ type SequenceSender struct {
 	da                       dataAbilitier
 	state                    stateInterface
 	ethTxManager             ethTxManager
+	storage                  storageInterface
 	etherman                 etherman
 	eventLog                 *event.EventLog
 	lastSequenceInitialBatch uint64
 	lastSequenceEndBatch     uint64
+	recovery                 *sequenceRecovery
+	sendPolicy               *sendPolicy
 }
 
 // New inits sequence sender
-func New(cfg Config, state stateInterface, etherman etherman, manager ethTxManager, eventLog *event.EventLog, da dataAbilitier) (*SequenceSender, error) {
+func New(cfg Config, state stateInterface, etherman etherman, manager ethTxManager, storage storageInterface, eventLog *event.EventLog, da dataAbilitier) (*SequenceSender, error) {
+	if cfg.BatchDataInBlobs && cfg.BlobsForkID == 0 {
+		return nil, fmt.Errorf("BlobsForkID must be set to send the batch data in blobs")
+	}
+
+	metrics.Register()
+
 	return &SequenceSender{
 		cfg:          cfg,
 		state:        state,
 		etherman:     etherman,
 		ethTxManager: manager,
+		storage:      storage,
 		eventLog:     eventLog,
 		da:           da,
+		sendPolicy:   newSendPolicy(cfg.SendPolicy, cfg.MaxBatchesForL1),
 	}, nil
 }
 
@@ -96,6 +110,9 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context) {
 	// process monitored sequences before starting a next cycle
 	s.ethTxManager.ProcessPendingMonitoredTxs(ctx, ethTxManagerOwner, func(result ethtxmanager.MonitoredTxResult, dbTx pgx.Tx) {
 		if result.Status == ethtxmanager.MonitoredTxStatusConfirmed {
+			if err := s.storage.DeletePendingSequence(ctx, result.ID, dbTx); err != nil {
+				log.Errorf("failed to delete pending sequence %s, err: %v", result.ID, err)
+			}
 			if len(result.Txs) > 0 {
 				var txL1BlockNumber uint64
 				var txHash common.Hash
@@ -143,13 +160,13 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context) {
 				if s.lastSequenceEndBatch != 0 && (lastSCBatchNum != s.lastSequenceEndBatch) {
 					s.halt(ctx, fmt.Errorf("last sequenced batch from SC %d doesn't match last sequenced batch sent %d", lastSCBatchNum, s.lastSequenceEndBatch))
 				}
+				s.recovery = nil
 			} else {
 				s.halt(ctx, fmt.Errorf("monitored tx %s for sequence [%d-%d] doesn't have transactions to be checked", result.ID, s.lastSequenceInitialBatch, s.lastSequenceEndBatch))
 			}
 		} else { // Monitored tx is failed
 			retry = true
-			mTxResultLogger := ethtxmanager.CreateMonitoredTxResultLogger(ethTxManagerOwner, result)
-			mTxResultLogger.Error("failed to send sequence, TODO: review this fatal and define what to do in this case")
+			s.handleFailedSequence(ctx, result, dbTx)
 		}
 	}, nil)
 
@@ -167,6 +184,16 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context) {
 		return
 	}
 
+	// Resend the sequences persisted before a restart instead of posting the batches to the DA layer again
+	resent, err := s.resendPendingSequences(ctx)
+	if err != nil {
+		log.Errorf("error resending pending sequences: %v", err)
+		return
+	}
+	if resent {
+		return
+	}
+
 	// Check if should send sequence to L1
 	log.Infof("getting sequences to send")
 	sequences, err := s.getSequencesToSend(ctx)
@@ -199,6 +226,7 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context) {
 	timeMargin := int64(s.cfg.L1BlockTimestampMargin.Seconds())
 
 	// Wait until last L1 block timestamp is timeMargin (L1BlockTimestampMargin) seconds above the timestamp of the last L2 block in the sequence
+	var lastL1BlockTimestamp uint64
 	for {
 		// Get header of the last L1 block
 		lastL1BlockHeader, err := s.etherman.GetLatestBlockHeader(ctx)
@@ -207,6 +235,7 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context) {
 			return
 		}
 
+		lastL1BlockTimestamp = lastL1BlockHeader.Time
 		elapsed, waitTime := s.marginTimeElapsed(lastL2BlockTimestamp, lastL1BlockHeader.Time, timeMargin)
 
 		if !elapsed {
@@ -238,22 +267,60 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context) {
 		}
 	}
 
+	firstSequence := sequences[0]
+	monitoredTxID := fmt.Sprintf(monitoredIDFormat, firstSequence.BatchNumber, lastSequence.BatchNumber)
+	timestampLimit := lastL2BlockTimestamp
+	var dataAvailabilityMessage []byte
+	if s.recovery != nil {
+		if s.recovery.fromBatch != firstSequence.BatchNumber || s.recovery.toBatch != lastSequence.BatchNumber {
+			log.Warnf("sequence [%d-%d] doesn't match the sequence [%d-%d] being recovered, discarding the recovery",
+				firstSequence.BatchNumber, lastSequence.BatchNumber, s.recovery.fromBatch, s.recovery.toBatch)
+			s.recovery = nil
+		} else {
+			monitoredTxID = s.recovery.monitoredTxID()
+			dataAvailabilityMessage = s.recovery.daMessage
+			timestampLimit = s.recovery.timestampLimit(lastL2BlockTimestamp, lastL1BlockTimestamp)
+		}
+	}
+
 	// add sequence to be monitored
-	dataAvailabilityMessage, err := s.da.PostSequence(ctx, sequences)
+	if dataAvailabilityMessage == nil {
+		dataAvailabilityMessage, err = s.da.PostSequence(ctx, sequences)
+		if err != nil {
+			log.Error("error posting sequences to the data availability protocol: ", err)
+			return
+		}
+	}
+
+	to, data, err := s.etherman.BuildSequenceBatchesTxData(s.cfg.SenderAddress, sequences, timestampLimit, firstSequence.BatchNumber-1, s.cfg.L2Coinbase, dataAvailabilityMessage)
 	if err != nil {
-		log.Error("error posting sequences to the data availability protocol: ", err)
+		log.Error("error estimating new sequenceBatches to add to eth tx manager: ", err)
 		return
 	}
 
-	firstSequence := sequences[0]
-	to, data, err := s.etherman.BuildSequenceBatchesTxData(s.cfg.SenderAddress, sequences, uint64(lastSequence.LastL2BLockTimestamp), firstSequence.BatchNumber-1, s.cfg.L2Coinbase, dataAvailabilityMessage)
+	blobData, err := s.batchBlobData(ctx, sequences)
 	if err != nil {
-		log.Error("error estimating new sequenceBatches to add to eth tx manager: ", err)
+		log.Errorf("error encoding the batch data of the sequence in blobs: %v", err)
 		return
 	}
 
-	monitoredTxID := fmt.Sprintf(monitoredIDFormat, firstSequence.BatchNumber, lastSequence.BatchNumber)
-	err = s.ethTxManager.Add(ctx, ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to, nil, data, s.cfg.GasOffset, nil)
+	// persist the signed sequence so it's sent as is if the sequence sender is restarted before it's monitored
+	err = s.storage.AddPendingSequence(ctx, PendingSequence{
+		MonitoredID: monitoredTxID,
+		FromBatch:   firstSequence.BatchNumber,
+		ToBatch:     lastSequence.BatchNumber,
+		From:        s.cfg.SenderAddress,
+		To:          to,
+		DAMessage:   dataAvailabilityMessage,
+		TxData:      data,
+		BlobData:    blobData,
+	}, nil)
+	if err != nil {
+		log.Errorf("error persisting pending sequence %s: %v", monitoredTxID, err)
+		return
+	}
+
+	err = s.addSequenceTx(ctx, monitoredTxID, s.cfg.SenderAddress, to, data, blobData)
 	if err != nil {
 		mTxLogger := ethtxmanager.CreateLogger(ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to)
 		mTxLogger.Errorf("error to add sequences tx to eth tx manager: ", err)
@@ -264,6 +331,52 @@ func (s *SequenceSender) tryToSendSequence(ctx context.Context) {
 	s.lastSequenceEndBatch = lastSequence.BatchNumber
 }
 
+// resendPendingSequences adds to the eth tx manager the persisted sequences that follow the last batch
+// sequenced in the SC, reusing their DA message and tx data. Sequences that were already monitored are
+// skipped by the eth tx manager, and the ones that can't be sequenced anymore are discarded.
+// It returns true if there are sequences pending to be confirmed
+func (s *SequenceSender) resendPendingSequences(ctx context.Context) (bool, error) {
+	pendingSequences, err := s.storage.GetPendingSequences(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get pending sequences, err: %v", err)
+	}
+	if len(pendingSequences) == 0 {
+		return false, nil
+	}
+
+	lastSCBatchNum, err := s.etherman.GetLatestBatchNumber()
+	if err != nil {
+		return false, fmt.Errorf("failed to get from the SC last sequenced batch number, err: %v", err)
+	}
+
+	resent := false
+	nextBatchNum := lastSCBatchNum + 1
+	for _, seq := range pendingSequences {
+		if seq.ToBatch < nextBatchNum || seq.FromBatch != nextBatchNum {
+			log.Warnf("discarding pending sequence %s [%d-%d], next batch to sequence is %d", seq.MonitoredID, seq.FromBatch, seq.ToBatch, nextBatchNum)
+			if err := s.storage.DeletePendingSequence(ctx, seq.MonitoredID, nil); err != nil {
+				return false, fmt.Errorf("failed to delete pending sequence %s, err: %v", seq.MonitoredID, err)
+			}
+			continue
+		}
+
+		err := s.addSequenceTx(ctx, seq.MonitoredID, seq.From, seq.To, seq.TxData, seq.BlobData)
+		if err != nil && !errors.Is(err, ethtxmanager.ErrAlreadyExists) {
+			mTxLogger := ethtxmanager.CreateLogger(ethTxManagerOwner, seq.MonitoredID, seq.From, seq.To)
+			mTxLogger.Errorf("error to add pending sequences tx to eth tx manager: ", err)
+			return false, err
+		} else if err == nil {
+			log.Infof("resent pending sequence %s [%d-%d]", seq.MonitoredID, seq.FromBatch, seq.ToBatch)
+		}
+
+		resent = true
+		nextBatchNum = seq.ToBatch + 1
+		s.lastSequenceInitialBatch = seq.FromBatch
+		s.lastSequenceEndBatch = seq.ToBatch
+	}
+	return resent, nil
+}
+
 // getSequencesToSend generates an array of sequences to be send to L1.
 // If the array is empty, it doesn't necessarily mean that there are no sequences to be sent,
 // it could be that it's not worth it to do so yet.
@@ -279,6 +392,7 @@ func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequen
 
 	sequences := []types.Sequence{}
 	// var estimatedGas uint64
+	blobDataSize := 0
 
 	// Add sequences until too big for a single L1 tx or last batch is reached
 	for {
@@ -346,14 +460,34 @@ func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequen
 			seq.LastL2BLockTimestamp = lastL2Block.ReceivedAt.Unix()
 		}
 
+		// The L2 data of the batches must fit in the blobs of a single tx
+		if s.cfg.BatchDataInBlobs {
+			blobDataSize += blob.BatchEncodedSize(len(seq.BatchL2Data))
+			if blobDataSize > blob.MaxDataSize {
+				if len(sequences) == 0 {
+					return nil, fmt.Errorf("L2 data of batch %d doesn't fit in the blobs of a tx", seq.BatchNumber)
+				}
+				log.Infof("sequence should be sent to L1, because the L2 data of the batches fills the blobs of a tx")
+				return sequences, nil
+			}
+		}
+
 		sequences = append(sequences, seq)
+		// A failed sequence is sent again with the same batches, or fewer if it has been trimmed
+		if s.recovery != nil && currentBatchNumToSequence == s.recovery.toBatch {
+			log.Infof("sequence should be sent to L1, because it's the recovery of the failed sequence [%d-%d]", s.recovery.fromBatch, s.recovery.toBatch)
+			return sequences, nil
+		}
 		// Check if can be send
 		if len(sequences) == int(s.cfg.MaxBatchesForL1) {
-			log.Info(
+			log.Infof(
 				"sequence should be sent to L1, because MaxBatchesForL1 (%d) has been reached",
 				s.cfg.MaxBatchesForL1,
 			)
-			return sequences, nil
+			if !s.cfg.SendPolicy.Enabled {
+				return sequences, nil
+			}
+			return s.applySendPolicy(ctx, sequences, s.virtualizationDeadlineReached(ctx), true), nil
 		}
 
 		// Increase batch num for next iteration
@@ -366,21 +500,64 @@ func (s *SequenceSender) getSequencesToSend(ctx context.Context) ([]types.Sequen
 		return nil, nil
 	}
 
+	if s.virtualizationDeadlineReached(ctx) {
+		log.Info("sequence should be sent to L1, because too long since didn't send anything to L1")
+		return s.applySendPolicy(ctx, sequences, true, true), nil
+	}
+
+	log.Info("not enough time has passed since last batch was virtualized, and the sequence could be bigger")
+	return s.applySendPolicy(ctx, sequences, false, false), nil
+}
+
+// virtualizationDeadlineReached returns true if LastBatchVirtualizationTimeMaxWaitPeriod has elapsed since the last batch was virtualized
+func (s *SequenceSender) virtualizationDeadlineReached(ctx context.Context) bool {
 	lastBatchVirtualizationTime, err := s.state.GetTimeForLatestBatchVirtualization(ctx, nil)
 	if err != nil && !errors.Is(err, state.ErrNotFound) {
 		log.Warnf("failed to get last l1 interaction time, err: %v. Sending sequences as a conservative approach", err)
-		return sequences, nil
+		return true
 	}
-	if lastBatchVirtualizationTime.Before(time.Now().Add(-s.cfg.LastBatchVirtualizationTimeMaxWaitPeriod.Duration)) {
-		// TODO: implement check profitability
-		// if s.checker.IsSendSequencesProfitable(new(big.Int).SetUint64(estimatedGas), sequences) {
-		log.Info("sequence should be sent to L1, because too long since didn't send anything to L1")
-		return sequences, nil
-		//}
+	return lastBatchVirtualizationTime.Before(time.Now().Add(-s.cfg.LastBatchVirtualizationTimeMaxWaitPeriod.Duration))
+}
+
+// applySendPolicy returns the sequences to send according to the send policy. If the policy is disabled, in
+// dry run mode or can't be evaluated, the sequences are sent only if legacySend is true. The dry run mode
+// evaluates and logs the decisions even if the policy is disabled
+func (s *SequenceSender) applySendPolicy(ctx context.Context, sequences []types.Sequence, deadlineReached, legacySend bool) []types.Sequence {
+	var legacySequences []types.Sequence
+	if legacySend {
+		legacySequences = sequences
+	}
+	if !s.cfg.SendPolicy.Enabled && !s.cfg.SendPolicy.DryRun {
+		return legacySequences
 	}
 
-	log.Info("not enough time has passed since last batch was virtualized, and the sequence could be bigger")
-	return nil, nil
+	lastL1BlockHeader, err := s.etherman.GetLatestBlockHeader(ctx)
+	if err != nil {
+		log.Warnf("failed to get last L1 block header to evaluate the send policy, err: %v", err)
+		return legacySequences
+	}
+
+	decision := s.sendPolicy.decide(lastL1BlockHeader.BaseFee, len(sequences), deadlineReached)
+	costPerBatch, _ := new(big.Float).SetInt(decision.costPerBatch).Float64()
+	metrics.EstimatedCostPerBatch(costPerBatch)
+	if decision.send {
+		metrics.SendDecisionTaken(metrics.SendDecisionSend)
+	} else {
+		metrics.SendDecisionTaken(metrics.SendDecisionWait)
+	}
+
+	if s.cfg.SendPolicy.DryRun {
+		log.Infof("send policy (dry run): send %t, batches %d of %d, L1 base fee %v, estimated cost per batch %v wei, reason: %s",
+			decision.send, decision.batches, len(sequences), lastL1BlockHeader.BaseFee, decision.costPerBatch, decision.reason)
+		return legacySequences
+	}
+
+	log.Infof("send policy: send %t, batches %d of %d, L1 base fee %v, estimated cost per batch %v wei, reason: %s",
+		decision.send, decision.batches, len(sequences), lastL1BlockHeader.BaseFee, decision.costPerBatch, decision.reason)
+	if !decision.send {
+		return nil
+	}
+	return sequences[:decision.batches]
 }
 
 func (s *SequenceSender) sanityCheck(ctx context.Context, retries int, waitRetry time.Duration) (bool, error) {
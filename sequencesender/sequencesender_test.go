@@ -1,13 +1,23 @@
 package sequencesender
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
+	ethermanTypes "github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/blob"
+	ethmanTypes "github.com/0xPolygonHermez/zkevm-node/etherman/types"
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
 	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsSynced(t *testing.T) {
@@ -30,7 +40,7 @@ func TestIsSynced(t *testing.T) {
 	stateMock := new(StateMock)
 	ethermanMock := new(EthermanMock)
 	ethTxManagerMock := new(EthTxManagerMock)
-	ssender, err := New(Config{}, stateMock, ethermanMock, ethTxManagerMock, nil, nil)
+	ssender, err := New(Config{}, stateMock, ethermanMock, ethTxManagerMock, nil, nil, nil)
 	assert.NoError(t, err)
 
 	testCases := []IsSyncedTestCase{
@@ -144,3 +154,216 @@ func TestIsSynced(t *testing.T) {
 		})
 	}
 }
+
+func TestResendPendingSequences(t *testing.T) {
+	ctx := context.Background()
+	sender := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	pendingSequences := []PendingSequence{
+		{MonitoredID: "sequence-from-5-to-9", FromBatch: 5, ToBatch: 9, From: sender, To: &to, TxData: []byte{1}},
+		{MonitoredID: "sequence-from-11-to-12", FromBatch: 11, ToBatch: 12, From: sender, To: &to, DAMessage: []byte{2}, TxData: []byte{2}},
+		{MonitoredID: "sequence-from-13-to-15", FromBatch: 13, ToBatch: 15, From: sender, To: &to, DAMessage: []byte{3}, TxData: []byte{3}},
+		{MonitoredID: "sequence-from-17-to-18", FromBatch: 17, ToBatch: 18, From: sender, To: &to, TxData: []byte{4}},
+	}
+
+	ethermanMock := new(EthermanMock)
+	ethTxManagerMock := new(EthTxManagerMock)
+	storageMock := new(StorageMock)
+	ssender, err := New(Config{GasOffset: 80000}, nil, ethermanMock, ethTxManagerMock, storageMock, nil, nil)
+	require.NoError(t, err)
+
+	storageMock.On("GetPendingSequences", ctx, nil).Return(pendingSequences, nil).Once()
+	ethermanMock.On("GetLatestBatchNumber").Return(uint64(10), nil).Once()
+	// already sequenced and not contiguous sequences are discarded
+	storageMock.On("DeletePendingSequence", ctx, "sequence-from-5-to-9", nil).Return(nil).Once()
+	storageMock.On("DeletePendingSequence", ctx, "sequence-from-17-to-18", nil).Return(nil).Once()
+	// the rest are sent with the persisted data, even if they were already monitored
+	ethTxManagerMock.On("Add", ctx, ethTxManagerOwner, "sequence-from-11-to-12", sender, &to, (*big.Int)(nil), []byte{2}, uint64(80000), nil).Return(ethtxmanager.ErrAlreadyExists).Once()
+	ethTxManagerMock.On("Add", ctx, ethTxManagerOwner, "sequence-from-13-to-15", sender, &to, (*big.Int)(nil), []byte{3}, uint64(80000), nil).Return(nil).Once()
+
+	resent, err := ssender.resendPendingSequences(ctx)
+	require.NoError(t, err)
+	assert.True(t, resent)
+	assert.Equal(t, uint64(13), ssender.lastSequenceInitialBatch)
+	assert.Equal(t, uint64(15), ssender.lastSequenceEndBatch)
+
+	storageMock.On("GetPendingSequences", ctx, nil).Return([]PendingSequence{}, nil).Once()
+	resent, err = ssender.resendPendingSequences(ctx)
+	require.NoError(t, err)
+	assert.False(t, resent)
+
+	ethermanMock.AssertExpectations(t)
+	ethTxManagerMock.AssertExpectations(t)
+	storageMock.AssertExpectations(t)
+}
+
+func TestClassifyRevert(t *testing.T) {
+	testCases := []struct {
+		revertMessage string
+		class         revertClass
+		action        recoveryAction
+	}{
+		{"SequencedTimestampInvalid", revertTimestamp, actionRebuild},
+		{"execution reverted: Timestamp must be inside range", revertTimestamp, actionRebuild},
+		{"GlobalExitRootNotExist", revertGlobalExitRoot, actionTrim},
+		{"UnexpectedCommitteeHash", revertDASignature, actionResign},
+		{"ECDSA: invalid signature", revertDASignature, actionResign},
+		{"nonce too low", revertNonce, actionRebuild},
+		{"InitSequencedBatchDoesNotMatch", revertOutOfSync, actionResync},
+		{"OnlyTrustedSequencer", revertUnrecoverable, actionHalt},
+		{"", revertUnknown, actionResign},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.revertMessage, func(t *testing.T) {
+			class := classifyRevert(tc.revertMessage)
+			assert.Equal(t, tc.class, class)
+			assert.Equal(t, tc.action, class.action())
+		})
+	}
+}
+
+func TestHandleFailedSequence(t *testing.T) {
+	ctx := context.Background()
+	failedResult := func(id, revertMessage string) ethtxmanager.MonitoredTxResult {
+		tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+		return ethtxmanager.MonitoredTxResult{
+			ID:     id,
+			Status: ethtxmanager.MonitoredTxStatusFailed,
+			Txs: map[common.Hash]ethtxmanager.TxResult{
+				tx.Hash(): {Tx: tx, Receipt: &types.Receipt{Status: types.ReceiptStatusFailed}, RevertMessage: revertMessage},
+			},
+		}
+	}
+
+	ethermanMock := new(EthermanMock)
+	ethTxManagerMock := new(EthTxManagerMock)
+	storageMock := new(StorageMock)
+	ssender, err := New(Config{}, nil, ethermanMock, ethTxManagerMock, storageMock, nil, nil)
+	require.NoError(t, err)
+
+	// timestamp issue: same batches and DA message, with the timestamp limit taken from L1
+	const id = "sequence-from-3-to-8"
+	storageMock.On("GetPendingSequences", ctx, nil).Return([]PendingSequence{{MonitoredID: id, FromBatch: 3, ToBatch: 8, DAMessage: []byte{1}}}, nil).Once()
+	ethTxManagerMock.On("SetStatusDone", ctx, ethTxManagerOwner, id, nil).Return(nil).Once()
+	storageMock.On("DeletePendingSequence", ctx, id, nil).Return(nil).Once()
+	ssender.handleFailedSequence(ctx, failedResult(id, "SequencedTimestampInvalid"), nil)
+	assert.Equal(t, &sequenceRecovery{fromBatch: 3, toBatch: 8, daMessage: []byte{1}, refreshTimestampLimit: true, attempts: 1}, ssender.recovery)
+	assert.Equal(t, "sequence-from-3-to-8-attempt-1", ssender.recovery.monitoredTxID())
+	assert.Equal(t, uint64(120), ssender.recovery.timestampLimit(100, 120))
+	assert.Equal(t, uint64(100), ssender.recovery.timestampLimit(100, 90))
+
+	// wrong GER: half of the batches, posted again to the DA layer
+	const retryID = "sequence-from-3-to-8-attempt-1"
+	ethTxManagerMock.On("SetStatusDone", ctx, ethTxManagerOwner, retryID, nil).Return(nil).Once()
+	storageMock.On("DeletePendingSequence", ctx, retryID, nil).Return(nil).Once()
+	ssender.handleFailedSequence(ctx, failedResult(retryID, "GlobalExitRootNotExist"), nil)
+	assert.Equal(t, &sequenceRecovery{fromBatch: 3, toBatch: 5, attempts: 2}, ssender.recovery)
+	assert.Equal(t, uint64(100), ssender.recovery.timestampLimit(100, 120))
+
+	// out of sync: the next sequence is built from the SC
+	const trimmedID = "sequence-from-3-to-5-attempt-2"
+	ethTxManagerMock.On("SetStatusDone", ctx, ethTxManagerOwner, trimmedID, nil).Return(nil).Once()
+	storageMock.On("DeletePendingSequence", ctx, trimmedID, nil).Return(nil).Once()
+	ssender.handleFailedSequence(ctx, failedResult(trimmedID, "InitSequencedBatchDoesNotMatch"), nil)
+	assert.Nil(t, ssender.recovery)
+
+	ethermanMock.AssertExpectations(t)
+	ethTxManagerMock.AssertExpectations(t)
+	storageMock.AssertExpectations(t)
+}
+
+func TestSendPolicyDecide(t *testing.T) {
+	policy := newSendPolicy(SendPolicyConfig{
+		MaxBaseFee:          100,
+		TargetCostPerBatch:  2000,
+		FixedGasPerSequence: 1000,
+		GasPerBatch:         10,
+	}, 10)
+
+	testCases := []struct {
+		name            string
+		baseFee         int64
+		readyBatches    int
+		deadlineReached bool
+		send            bool
+		batches         int
+		costPerBatch    int64
+	}{
+		{"no batches", 10, 0, true, false, 0, 0},
+		{"deadline reached with high base fee", 200, 2, true, true, 2, 102000},
+		{"high base fee", 200, 10, false, false, 10, 22000},
+		{"max batches reached", 50, 15, false, true, 10, 5500},
+		{"cost below target", 10, 6, false, true, 6, 1766},
+		{"cost below target with more batches than needed", 10, 8, false, true, 6, 1766},
+		{"cost above target", 10, 4, false, false, 4, 2600},
+		{"target can't be reached below max batches", 90, 9, false, false, 9, 10900},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decision := policy.decide(big.NewInt(tc.baseFee), tc.readyBatches, tc.deadlineReached)
+			assert.Equal(t, tc.send, decision.send, decision.reason)
+			assert.Equal(t, tc.batches, decision.batches)
+			assert.Equal(t, big.NewInt(tc.costPerBatch), decision.costPerBatch)
+		})
+	}
+
+	batches, ok := policy.batchesForTarget(big.NewInt(10))
+	assert.True(t, ok)
+	assert.Equal(t, 6, batches)
+	// the gas per batch alone costs the target
+	_, ok = policy.batchesForTarget(big.NewInt(200))
+	assert.False(t, ok)
+}
+
+func TestBatchDataInBlobs(t *testing.T) {
+	ctx := context.Background()
+	sender := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	sequences := []ethmanTypes.Sequence{
+		{BatchNumber: 3, BatchL2Data: []byte{1, 2, 3}},
+		{BatchNumber: 4, BatchL2Data: []byte{4}},
+	}
+
+	stateMock := new(StateMock)
+	ethermanMock := new(EthermanMock)
+	ethTxManagerMock := new(EthTxManagerMock)
+	_, err := New(Config{BatchDataInBlobs: true}, stateMock, ethermanMock, ethTxManagerMock, nil, nil, nil)
+	require.Error(t, err)
+	ssender, err := New(Config{GasOffset: 80000, BatchDataInBlobs: true, BlobsForkID: 9}, stateMock, ethermanMock, ethTxManagerMock, nil, nil, nil)
+	require.NoError(t, err)
+
+	// the batch data isn't attached to the tx before the blobs fork
+	stateMock.On("GetForkIDByBatchNumber", uint64(3)).Return(uint64(8)).Once()
+	blobData, err := ssender.batchBlobData(ctx, sequences)
+	require.NoError(t, err)
+	assert.Nil(t, blobData)
+
+	// the batch data is attached to the tx when L1 supports blobs
+	stateMock.On("GetForkIDByBatchNumber", uint64(3)).Return(uint64(9)).Twice()
+	ethermanMock.On("SuggestedBlobGasPrice", ctx).Return(big.NewInt(1), nil).Once()
+	blobData, err = ssender.batchBlobData(ctx, sequences)
+	require.NoError(t, err)
+	batches, err := blob.DecodeBatches(blobData)
+	require.NoError(t, err)
+	assert.Equal(t, []blob.Batch{{Number: 3, L2Data: []byte{1, 2, 3}}, {Number: 4, L2Data: []byte{4}}}, batches)
+
+	ethTxManagerMock.On("AddWithBlobs", ctx, ethTxManagerOwner, "sequence-from-3-to-4", sender, &to, (*big.Int)(nil), []byte{5}, uint64(80000),
+		mock.MatchedBy(func(sidecar *types.BlobTxSidecar) bool {
+			data, err := blob.Decode(sidecar.Blobs)
+			return err == nil && bytes.Equal(data, blobData) && len(sidecar.Commitments) == len(sidecar.Blobs)
+		}), nil).Return(nil).Once()
+	require.NoError(t, ssender.addSequenceTx(ctx, "sequence-from-3-to-4", sender, &to, []byte{5}, blobData))
+
+	// the sequence is sent without blobs when L1 doesn't support them
+	ethermanMock.On("SuggestedBlobGasPrice", ctx).Return(nil, ethermanTypes.ErrBlobsNotSupported).Once()
+	blobData, err = ssender.batchBlobData(ctx, sequences)
+	require.NoError(t, err)
+	assert.Nil(t, blobData)
+
+	ethTxManagerMock.On("Add", ctx, ethTxManagerOwner, "sequence-from-3-to-4", sender, &to, (*big.Int)(nil), []byte{5}, uint64(80000), nil).Return(nil).Once()
+	require.NoError(t, ssender.addSequenceTx(ctx, "sequence-from-3-to-4", sender, &to, []byte{5}, blobData))
+
+	stateMock.AssertExpectations(t)
+	ethermanMock.AssertExpectations(t)
+	ethTxManagerMock.AssertExpectations(t)
+}
@@ -67,6 +67,8 @@ var (
 	// ErrMaxNativeBlockHashBlockRangeLimitExceeded returned when the range between block number range
 	// to filter native block hashes is bigger than the configured limit
 	ErrMaxNativeBlockHashBlockRangeLimitExceeded = errors.New("native block hashes are limited to a %v block range")
+	// ErrSequencerLeaseLost is returned when the sequencer lease isn't held anymore by the sequencer instance
+	ErrSequencerLeaseLost = errors.New("sequencer lease lost")
 )
 
 // ConstructErrorFromRevert extracts the reverted reason from the provided returnValue
@@ -983,6 +983,55 @@ func (_c *StorageMock_CheckProofContainsCompleteSequences_Call) RunAndReturn(run
 	return _c
 }
 
+// CheckSequencerLease provides a mock function with given fields: ctx, holder, epoch, dbTx
+func (_m *StorageMock) CheckSequencerLease(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, epoch, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, epoch, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_CheckSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckSequencerLease'
+type StorageMock_CheckSequencerLease_Call struct {
+	*mock.Call
+}
+
+// CheckSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holder string
+//   - epoch uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) CheckSequencerLease(ctx interface{}, holder interface{}, epoch interface{}, dbTx interface{}) *StorageMock_CheckSequencerLease_Call {
+	return &StorageMock_CheckSequencerLease_Call{Call: _e.mock.On("CheckSequencerLease", ctx, holder, epoch, dbTx)}
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) Run(run func(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx)) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) Return(_a0 error) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) RunAndReturn(run func(context.Context, string, uint64, pgx.Tx) error) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanupGeneratedProofs provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) CleanupGeneratedProofs(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -1185,6 +1234,121 @@ func (_c *StorageMock_CloseWIPBatchInStorage_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// CountBatchesToProve provides a mock function with given fields: ctx, lastVerfiedBatchNumber, dbTx
+func (_m *StorageMock) CountBatchesToProve(ctx context.Context, lastVerfiedBatchNumber uint64, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, lastVerfiedBatchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBatchesToProve")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, lastVerfiedBatchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, lastVerfiedBatchNumber, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, lastVerfiedBatchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_CountBatchesToProve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBatchesToProve'
+type StorageMock_CountBatchesToProve_Call struct {
+	*mock.Call
+}
+
+// CountBatchesToProve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lastVerfiedBatchNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) CountBatchesToProve(ctx interface{}, lastVerfiedBatchNumber interface{}, dbTx interface{}) *StorageMock_CountBatchesToProve_Call {
+	return &StorageMock_CountBatchesToProve_Call{Call: _e.mock.On("CountBatchesToProve", ctx, lastVerfiedBatchNumber, dbTx)}
+}
+
+func (_c *StorageMock_CountBatchesToProve_Call) Run(run func(ctx context.Context, lastVerfiedBatchNumber uint64, dbTx pgx.Tx)) *StorageMock_CountBatchesToProve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_CountBatchesToProve_Call) Return(_a0 uint64, _a1 error) *StorageMock_CountBatchesToProve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_CountBatchesToProve_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (uint64, error)) *StorageMock_CountBatchesToProve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountProofsToAggregate provides a mock function with given fields: ctx, dbTx
+func (_m *StorageMock) CountProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountProofsToAggregate")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_CountProofsToAggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountProofsToAggregate'
+type StorageMock_CountProofsToAggregate_Call struct {
+	*mock.Call
+}
+
+// CountProofsToAggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) CountProofsToAggregate(ctx interface{}, dbTx interface{}) *StorageMock_CountProofsToAggregate_Call {
+	return &StorageMock_CountProofsToAggregate_Call{Call: _e.mock.On("CountProofsToAggregate", ctx, dbTx)}
+}
+
+func (_c *StorageMock_CountProofsToAggregate_Call) Run(run func(ctx context.Context, dbTx pgx.Tx)) *StorageMock_CountProofsToAggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_CountProofsToAggregate_Call) Return(_a0 uint64, _a1 error) *StorageMock_CountProofsToAggregate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_CountProofsToAggregate_Call) RunAndReturn(run func(context.Context, pgx.Tx) (uint64, error)) *StorageMock_CountProofsToAggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountReorgs provides a mock function with given fields: ctx, dbTx
 func (_m *StorageMock) CountReorgs(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, dbTx)
@@ -1291,6 +1455,65 @@ func (_c *StorageMock_DeleteGeneratedProofs_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// DeleteProofsContainingBatches provides a mock function with given fields: ctx, batchNumber, batchNumberFinal, dbTx
+func (_m *StorageMock) DeleteProofsContainingBatches(ctx context.Context, batchNumber uint64, batchNumberFinal uint64, dbTx pgx.Tx) (int64, error) {
+	ret := _m.Called(ctx, batchNumber, batchNumberFinal, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProofsContainingBatches")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) (int64, error)); ok {
+		return rf(ctx, batchNumber, batchNumberFinal, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, pgx.Tx) int64); ok {
+		r0 = rf(ctx, batchNumber, batchNumberFinal, dbTx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, batchNumberFinal, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_DeleteProofsContainingBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProofsContainingBatches'
+type StorageMock_DeleteProofsContainingBatches_Call struct {
+	*mock.Call
+}
+
+// DeleteProofsContainingBatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNumber uint64
+//   - batchNumberFinal uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) DeleteProofsContainingBatches(ctx interface{}, batchNumber interface{}, batchNumberFinal interface{}, dbTx interface{}) *StorageMock_DeleteProofsContainingBatches_Call {
+	return &StorageMock_DeleteProofsContainingBatches_Call{Call: _e.mock.On("DeleteProofsContainingBatches", ctx, batchNumber, batchNumberFinal, dbTx)}
+}
+
+func (_c *StorageMock_DeleteProofsContainingBatches_Call) Run(run func(ctx context.Context, batchNumber uint64, batchNumberFinal uint64, dbTx pgx.Tx)) *StorageMock_DeleteProofsContainingBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_DeleteProofsContainingBatches_Call) Return(_a0 int64, _a1 error) *StorageMock_DeleteProofsContainingBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_DeleteProofsContainingBatches_Call) RunAndReturn(run func(context.Context, uint64, uint64, pgx.Tx) (int64, error)) *StorageMock_DeleteProofsContainingBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUngeneratedProofs provides a mock function with given fields: ctx, dbTx
 func (_m *StorageMock) DeleteUngeneratedProofs(ctx context.Context, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, dbTx)
@@ -1827,6 +2050,66 @@ func (_c *StorageMock_GetBatchL2DataByNumbers_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// GetBatchL2DataByNumbersFromBackup provides a mock function with given fields: ctx, batchNumbers, dbTx
+func (_m *StorageMock) GetBatchL2DataByNumbersFromBackup(ctx context.Context, batchNumbers []uint64, dbTx pgx.Tx) (map[uint64][]byte, error) {
+	ret := _m.Called(ctx, batchNumbers, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchL2DataByNumbersFromBackup")
+	}
+
+	var r0 map[uint64][]byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64, pgx.Tx) (map[uint64][]byte, error)); ok {
+		return rf(ctx, batchNumbers, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64, pgx.Tx) map[uint64][]byte); ok {
+		r0 = rf(ctx, batchNumbers, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uint64][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumbers, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetBatchL2DataByNumbersFromBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchL2DataByNumbersFromBackup'
+type StorageMock_GetBatchL2DataByNumbersFromBackup_Call struct {
+	*mock.Call
+}
+
+// GetBatchL2DataByNumbersFromBackup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNumbers []uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetBatchL2DataByNumbersFromBackup(ctx interface{}, batchNumbers interface{}, dbTx interface{}) *StorageMock_GetBatchL2DataByNumbersFromBackup_Call {
+	return &StorageMock_GetBatchL2DataByNumbersFromBackup_Call{Call: _e.mock.On("GetBatchL2DataByNumbersFromBackup", ctx, batchNumbers, dbTx)}
+}
+
+func (_c *StorageMock_GetBatchL2DataByNumbersFromBackup_Call) Run(run func(ctx context.Context, batchNumbers []uint64, dbTx pgx.Tx)) *StorageMock_GetBatchL2DataByNumbersFromBackup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetBatchL2DataByNumbersFromBackup_Call) Return(_a0 map[uint64][]byte, _a1 error) *StorageMock_GetBatchL2DataByNumbersFromBackup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetBatchL2DataByNumbersFromBackup_Call) RunAndReturn(run func(context.Context, []uint64, pgx.Tx) (map[uint64][]byte, error)) *StorageMock_GetBatchL2DataByNumbersFromBackup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBatchNumberOfL2Block provides a mock function with given fields: ctx, blockNumber, dbTx
 func (_m *StorageMock) GetBatchNumberOfL2Block(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
@@ -5909,6 +6192,65 @@ func (_c *StorageMock_GetProofReadyToVerify_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// GetProofs provides a mock function with given fields: ctx, dbTx
+func (_m *StorageMock) GetProofs(ctx context.Context, dbTx pgx.Tx) ([]*state.Proof, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProofs")
+	}
+
+	var r0 []*state.Proof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) ([]*state.Proof, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) []*state.Proof); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*state.Proof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetProofs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProofs'
+type StorageMock_GetProofs_Call struct {
+	*mock.Call
+}
+
+// GetProofs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetProofs(ctx interface{}, dbTx interface{}) *StorageMock_GetProofs_Call {
+	return &StorageMock_GetProofs_Call{Call: _e.mock.On("GetProofs", ctx, dbTx)}
+}
+
+func (_c *StorageMock_GetProofs_Call) Run(run func(ctx context.Context, dbTx pgx.Tx)) *StorageMock_GetProofs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetProofs_Call) Return(_a0 []*state.Proof, _a1 error) *StorageMock_GetProofs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetProofs_Call) RunAndReturn(run func(context.Context, pgx.Tx) ([]*state.Proof, error)) *StorageMock_GetProofs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProofsToAggregate provides a mock function with given fields: ctx, dbTx
 func (_m *StorageMock) GetProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (*state.Proof, *state.Proof, error) {
 	ret := _m.Called(ctx, dbTx)
@@ -8126,6 +8468,105 @@ func (_c *StorageMock_QueryRow_Call) RunAndReturn(run func(context.Context, stri
 	return _c
 }
 
+// ReleaseSequencerLease provides a mock function with given fields: ctx, holder, epoch, dbTx
+func (_m *StorageMock) ReleaseSequencerLease(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, epoch, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, epoch, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_ReleaseSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseSequencerLease'
+type StorageMock_ReleaseSequencerLease_Call struct {
+	*mock.Call
+}
+
+// ReleaseSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holder string
+//   - epoch uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) ReleaseSequencerLease(ctx interface{}, holder interface{}, epoch interface{}, dbTx interface{}) *StorageMock_ReleaseSequencerLease_Call {
+	return &StorageMock_ReleaseSequencerLease_Call{Call: _e.mock.On("ReleaseSequencerLease", ctx, holder, epoch, dbTx)}
+}
+
+func (_c *StorageMock_ReleaseSequencerLease_Call) Run(run func(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx)) *StorageMock_ReleaseSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_ReleaseSequencerLease_Call) Return(_a0 error) *StorageMock_ReleaseSequencerLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_ReleaseSequencerLease_Call) RunAndReturn(run func(context.Context, string, uint64, pgx.Tx) error) *StorageMock_ReleaseSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewSequencerLease provides a mock function with given fields: ctx, holder, epoch, duration, dbTx
+func (_m *StorageMock) RenewSequencerLease(ctx context.Context, holder string, epoch uint64, duration time.Duration, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, epoch, duration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, time.Duration, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, epoch, duration, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_RenewSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewSequencerLease'
+type StorageMock_RenewSequencerLease_Call struct {
+	*mock.Call
+}
+
+// RenewSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holder string
+//   - epoch uint64
+//   - duration time.Duration
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) RenewSequencerLease(ctx interface{}, holder interface{}, epoch interface{}, duration interface{}, dbTx interface{}) *StorageMock_RenewSequencerLease_Call {
+	return &StorageMock_RenewSequencerLease_Call{Call: _e.mock.On("RenewSequencerLease", ctx, holder, epoch, duration, dbTx)}
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) Run(run func(ctx context.Context, holder string, epoch uint64, duration time.Duration, dbTx pgx.Tx)) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(time.Duration), args[4].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) Return(_a0 error) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) RunAndReturn(run func(context.Context, string, uint64, time.Duration, pgx.Tx) error) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResetForkID provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) ResetForkID(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -8416,6 +8857,72 @@ func (_c *StorageMock_StoreGenesisBatch_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// TryAcquireSequencerLease provides a mock function with given fields: ctx, holder, duration, dbTx
+func (_m *StorageMock) TryAcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, bool, error) {
+	ret := _m.Called(ctx, holder, duration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryAcquireSequencerLease")
+	}
+
+	var r0 uint64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) (uint64, bool, error)); ok {
+		return rf(ctx, holder, duration, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, pgx.Tx) bool); ok {
+		r1 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration, pgx.Tx) error); ok {
+		r2 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// StorageMock_TryAcquireSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryAcquireSequencerLease'
+type StorageMock_TryAcquireSequencerLease_Call struct {
+	*mock.Call
+}
+
+// TryAcquireSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holder string
+//   - duration time.Duration
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) TryAcquireSequencerLease(ctx interface{}, holder interface{}, duration interface{}, dbTx interface{}) *StorageMock_TryAcquireSequencerLease_Call {
+	return &StorageMock_TryAcquireSequencerLease_Call{Call: _e.mock.On("TryAcquireSequencerLease", ctx, holder, duration, dbTx)}
+}
+
+func (_c *StorageMock_TryAcquireSequencerLease_Call) Run(run func(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx)) *StorageMock_TryAcquireSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_TryAcquireSequencerLease_Call) Return(_a0 uint64, _a1 bool, _a2 error) *StorageMock_TryAcquireSequencerLease_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *StorageMock_TryAcquireSequencerLease_Call) RunAndReturn(run func(context.Context, string, time.Duration, pgx.Tx) (uint64, bool, error)) *StorageMock_TryAcquireSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateBatchAsChecked provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) UpdateBatchAsChecked(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, dbTx)
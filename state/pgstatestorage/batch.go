@@ -814,6 +814,25 @@ func (p *PostgresStorage) GetVirtualBatchToProve(ctx context.Context, lastVerfie
 	return &batch, nil
 }
 
+// CountBatchesToProve returns the number of virtual batches after the last
+// verified batch that are not included in any proof, generated or generating.
+func (p *PostgresStorage) CountBatchesToProve(ctx context.Context, lastVerfiedBatchNumber uint64, dbTx pgx.Tx) (uint64, error) {
+	const query = `
+		SELECT COUNT(*)
+		FROM state.virtual_batch v
+		WHERE
+			v.batch_num > $1 AND
+			NOT EXISTS (
+				SELECT p.batch_num FROM state.proof p
+				WHERE v.batch_num >= p.batch_num AND v.batch_num <= p.batch_num_final
+			)
+		`
+	var count uint64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, query, lastVerfiedBatchNumber).Scan(&count)
+	return count, err
+}
+
 // AddSequence stores the sequence information to allow the aggregator verify sequences.
 func (p *PostgresStorage) AddSequence(ctx context.Context, sequence state.Sequence, dbTx pgx.Tx) error {
 	const addSequenceSQL = "INSERT INTO state.sequences (from_batch_num, to_batch_num) VALUES($1, $2) ON CONFLICT (from_batch_num) DO UPDATE SET to_batch_num = $2"
@@ -524,6 +524,75 @@ func TestCleanupLockedProofs(t *testing.T) {
 	assert.Contains(proofs, newerProof)
 }
 
+func TestGetProofsAndDeleteProofsContainingBatches(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	initOrResetDB()
+	ctx := context.Background()
+	_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num,wip) VALUES (1, FALSE), (2, FALSE), (3, FALSE), (4, FALSE), (5, FALSE)")
+	require.NoError(err)
+
+	ranges := [][2]uint64{{4, 5}, {1, 2}, {3, 3}}
+	for _, r := range ranges {
+		proofID := fmt.Sprintf("proof-%d-%d", r[0], r[1])
+		err := testState.AddGeneratedProof(ctx, &state.Proof{BatchNumber: r[0], BatchNumberFinal: r[1], ProofID: &proofID}, nil)
+		require.NoError(err)
+	}
+
+	proofs, err := testState.GetProofs(ctx, nil)
+	require.NoError(err)
+	require.Len(proofs, 3)
+	assert.Equal(uint64(1), proofs[0].BatchNumber)
+	assert.Equal(uint64(3), proofs[1].BatchNumber)
+	assert.Equal(uint64(4), proofs[2].BatchNumber)
+	assert.Equal("proof-4-5", *proofs[2].ProofID)
+
+	// the proofs partially including the range are deleted too
+	deleted, err := testState.DeleteProofsContainingBatches(ctx, 2, 3, nil)
+	require.NoError(err)
+	assert.Equal(int64(2), deleted)
+
+	proofs, err = testState.GetProofs(ctx, nil)
+	require.NoError(err)
+	require.Len(proofs, 1)
+	assert.Equal(uint64(4), proofs[0].BatchNumber)
+	assert.Equal(uint64(5), proofs[0].BatchNumberFinal)
+}
+
+func TestCountBatchesToProveAndProofsToAggregate(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	initOrResetDB()
+	ctx := context.Background()
+
+	err := testState.AddBlock(ctx, &state.Block{BlockNumber: 1, ReceivedAt: time.Now()}, nil)
+	require.NoError(err)
+	addr := common.HexToAddress("0x1")
+	for batchNumber := uint64(1); batchNumber <= 6; batchNumber++ {
+		_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num, wip) VALUES ($1, FALSE)", batchNumber)
+		require.NoError(err)
+		err = testState.AddVirtualBatch(ctx, &state.VirtualBatch{BlockNumber: 1, BatchNumber: batchNumber, Coinbase: addr, SequencerAddr: addr}, nil)
+		require.NoError(err)
+	}
+
+	// a generated proof, a proof being generated and a generated proof being aggregated
+	proof, now := "proof", time.Now()
+	require.NoError(testState.AddGeneratedProof(ctx, &state.Proof{BatchNumber: 2, BatchNumberFinal: 3, Proof: proof}, nil))
+	require.NoError(testState.AddGeneratedProof(ctx, &state.Proof{BatchNumber: 4, BatchNumberFinal: 4, GeneratingSince: &now}, nil))
+	require.NoError(testState.AddGeneratedProof(ctx, &state.Proof{BatchNumber: 5, BatchNumberFinal: 5, Proof: proof, GeneratingSince: &now}, nil))
+
+	batches, err := testState.CountBatchesToProve(ctx, 1, nil)
+	require.NoError(err)
+	assert.Equal(uint64(1), batches)
+	batches, err = testState.CountBatchesToProve(ctx, 0, nil)
+	require.NoError(err)
+	assert.Equal(uint64(2), batches)
+
+	proofs, err := testState.CountProofsToAggregate(ctx, nil)
+	require.NoError(err)
+	assert.Equal(uint64(1), proofs)
+}
+
 func TestVirtualBatch(t *testing.T) {
 	initOrResetDB()
 
@@ -1816,3 +1885,32 @@ func TestGetUncheckedBlocks(t *testing.T) {
 	require.Equal(t, uint64(blockNumber+1), blocks[0].BlockNumber)
 	require.Equal(t, uint64(blockNumber+3), blocks[1].BlockNumber)
 }
+
+func TestSequencerLease(t *testing.T) {
+	ctx := context.Background()
+	_, err := testState.Exec(ctx, "DELETE FROM state.sequencer_lease")
+	require.NoError(t, err)
+
+	epoch, acquired, err := testState.TryAcquireSequencerLease(ctx, "sequencer-1", time.Minute, nil)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.Equal(t, uint64(1), epoch)
+
+	// the lease isn't acquired by another instance until it expires
+	_, acquired, err = testState.TryAcquireSequencerLease(ctx, "sequencer-2", time.Minute, nil)
+	require.NoError(t, err)
+	require.False(t, acquired)
+	require.NoError(t, testState.RenewSequencerLease(ctx, "sequencer-1", 1, time.Minute, nil))
+	require.NoError(t, testState.CheckSequencerLease(ctx, "sequencer-1", 1, nil))
+
+	require.NoError(t, testState.ReleaseSequencerLease(ctx, "sequencer-1", 1, nil))
+	epoch, acquired, err = testState.TryAcquireSequencerLease(ctx, "sequencer-2", time.Minute, nil)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.Equal(t, uint64(2), epoch)
+
+	// the previous leader is fenced off
+	require.Equal(t, state.ErrSequencerLeaseLost, testState.CheckSequencerLease(ctx, "sequencer-1", 1, nil))
+	require.Equal(t, state.ErrSequencerLeaseLost, testState.RenewSequencerLease(ctx, "sequencer-1", 1, time.Minute, nil))
+	require.NoError(t, testState.CheckSequencerLease(ctx, "sequencer-2", 2, nil))
+}
@@ -128,6 +128,54 @@ func (p *PostgresStorage) GetProofsToAggregate(ctx context.Context, dbTx pgx.Tx)
 	return proof1, proof2, err
 }
 
+// GetProofs returns all the proofs in the storage ordered by batch number
+func (p *PostgresStorage) GetProofs(ctx context.Context, dbTx pgx.Tx) ([]*state.Proof, error) {
+	const getProofsSQL = `
+		SELECT 
+			p.batch_num, 
+			p.batch_num_final,
+			p.proof,
+			p.proof_id,
+			p.input_prover,
+			p.prover,
+			p.prover_id,
+			p.generating_since,
+			p.created_at,
+			p.updated_at
+		FROM state.proof p
+		ORDER BY p.batch_num, p.batch_num_final
+		`
+
+	e := p.getExecQuerier(dbTx)
+	rows, err := e.Query(ctx, getProofsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	proofs := []*state.Proof{}
+	for rows.Next() {
+		proof := &state.Proof{}
+		err := rows.Scan(&proof.BatchNumber, &proof.BatchNumberFinal, &proof.Proof, &proof.ProofID, &proof.InputProver, &proof.Prover, &proof.ProverID, &proof.GeneratingSince, &proof.CreatedAt, &proof.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, proof)
+	}
+
+	return proofs, rows.Err()
+}
+
+// CountProofsToAggregate returns the number of generated proofs that are not
+// being used to generate another proof.
+func (p *PostgresStorage) CountProofsToAggregate(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	const countProofsSQL = "SELECT COUNT(*) FROM state.proof WHERE proof IS NOT NULL AND generating_since IS NULL"
+	var count uint64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, countProofsSQL).Scan(&count)
+	return count, err
+}
+
 // AddGeneratedProof adds a generated proof to the storage
 func (p *PostgresStorage) AddGeneratedProof(ctx context.Context, proof *state.Proof, dbTx pgx.Tx) error {
 	const addGeneratedProofSQL = "INSERT INTO state.proof (batch_num, batch_num_final, proof, proof_id, input_prover, prover, prover_id, generating_since, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)"
@@ -155,6 +203,18 @@ func (p *PostgresStorage) DeleteGeneratedProofs(ctx context.Context, batchNumber
 	return err
 }
 
+// DeleteProofsContainingBatches deletes from the storage the proofs including
+// any batch of the batch numbers range, even if they include other batches.
+func (p *PostgresStorage) DeleteProofsContainingBatches(ctx context.Context, batchNumber uint64, batchNumberFinal uint64, dbTx pgx.Tx) (int64, error) {
+	const deleteProofsSQL = "DELETE FROM state.proof WHERE batch_num <= $2 AND batch_num_final >= $1"
+	e := p.getExecQuerier(dbTx)
+	ct, err := e.Exec(ctx, deleteProofsSQL, batchNumber, batchNumberFinal)
+	if err != nil {
+		return 0, err
+	}
+	return ct.RowsAffected(), nil
+}
+
 // CleanupGeneratedProofs deletes from the storage the generated proofs up to
 // the specified batch number included.
 func (p *PostgresStorage) CleanupGeneratedProofs(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
@@ -0,0 +1,80 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/jackc/pgx/v4"
+)
+
+// TryAcquireSequencerLease acquires the sequencer lease for the holder if it has expired or it's already held by
+// the holder, increasing its epoch. It returns false if the lease is held by another holder
+func (p *PostgresStorage) TryAcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, bool, error) {
+	const acquireSQL = `
+		INSERT INTO state.sequencer_lease (id, holder, epoch, expires_at) VALUES (1, $1, 1, NOW() + $2 * INTERVAL '1 millisecond')
+		ON CONFLICT (id) DO UPDATE SET holder = EXCLUDED.holder, epoch = state.sequencer_lease.epoch + 1, expires_at = EXCLUDED.expires_at
+		WHERE state.sequencer_lease.expires_at < NOW() OR state.sequencer_lease.holder = EXCLUDED.holder
+		RETURNING epoch`
+
+	var epoch int64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, acquireSQL, holder, duration.Milliseconds()).Scan(&epoch)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	return uint64(epoch), true, nil
+}
+
+// RenewSequencerLease extends the sequencer lease held by the holder with the epoch, returning
+// ErrSequencerLeaseLost if the lease has been acquired by another holder
+func (p *PostgresStorage) RenewSequencerLease(ctx context.Context, holder string, epoch uint64, duration time.Duration, dbTx pgx.Tx) error {
+	const renewSQL = `
+		UPDATE state.sequencer_lease SET expires_at = NOW() + $3 * INTERVAL '1 millisecond'
+		WHERE id = 1 AND holder = $1 AND epoch = $2`
+
+	e := p.getExecQuerier(dbTx)
+	cmdTag, err := e.Exec(ctx, renewSQL, holder, int64(epoch), duration.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return state.ErrSequencerLeaseLost
+	}
+	return nil
+}
+
+// CheckSequencerLease returns ErrSequencerLeaseLost if the sequencer lease isn't held by the holder with the epoch
+// or it has expired. The lease is locked until the end of the db tx, so it can't be acquired by another holder
+// while the db tx writes as leader
+func (p *PostgresStorage) CheckSequencerLease(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx) error {
+	const checkSQL = `
+		SELECT holder = $1 AND epoch = $2 AND expires_at >= NOW() FROM state.sequencer_lease
+		WHERE id = 1 FOR SHARE`
+
+	var held bool
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, checkSQL, holder, int64(epoch)).Scan(&held)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return state.ErrSequencerLeaseLost
+	} else if err != nil {
+		return err
+	}
+	if !held {
+		return state.ErrSequencerLeaseLost
+	}
+	return nil
+}
+
+// ReleaseSequencerLease expires the sequencer lease held by the holder with the epoch, so another holder can
+// acquire it without waiting for it to expire
+func (p *PostgresStorage) ReleaseSequencerLease(ctx context.Context, holder string, epoch uint64, dbTx pgx.Tx) error {
+	const releaseSQL = "UPDATE state.sequencer_lease SET expires_at = NOW() WHERE id = 1 AND holder = $1 AND epoch = $2"
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, releaseSQL, holder, int64(epoch))
+	return err
+}
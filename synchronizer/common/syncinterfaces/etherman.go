@@ -20,6 +20,7 @@ type EthermanFullInterface interface {
 
 	EthermanGetLatestBatchNumber
 	GetFinalizedBlockNumber(ctx context.Context) (uint64, error)
+	ResetDataAvailability(fromBlock uint64)
 }
 
 type EthermanGetLatestBatchNumber interface {
@@ -436,6 +436,39 @@ func (_c *EthermanFullInterface_HeaderByNumber_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// ResetDataAvailability provides a mock function with given fields: fromBlock
+func (_m *EthermanFullInterface) ResetDataAvailability(fromBlock uint64) {
+	_m.Called(fromBlock)
+}
+
+// EthermanFullInterface_ResetDataAvailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetDataAvailability'
+type EthermanFullInterface_ResetDataAvailability_Call struct {
+	*mock.Call
+}
+
+// ResetDataAvailability is a helper method to define mock.On call
+//   - fromBlock uint64
+func (_e *EthermanFullInterface_Expecter) ResetDataAvailability(fromBlock interface{}) *EthermanFullInterface_ResetDataAvailability_Call {
+	return &EthermanFullInterface_ResetDataAvailability_Call{Call: _e.mock.On("ResetDataAvailability", fromBlock)}
+}
+
+func (_c *EthermanFullInterface_ResetDataAvailability_Call) Run(run func(fromBlock uint64)) *EthermanFullInterface_ResetDataAvailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *EthermanFullInterface_ResetDataAvailability_Call) Return() *EthermanFullInterface_ResetDataAvailability_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *EthermanFullInterface_ResetDataAvailability_Call) RunAndReturn(run func(uint64)) *EthermanFullInterface_ResetDataAvailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // VerifyGenBlockNumber provides a mock function with given fields: ctx, genBlockNumber
 func (_m *EthermanFullInterface) VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error) {
 	ret := _m.Called(ctx, genBlockNumber)
@@ -87,6 +87,62 @@ func (_c *ethermanMock_EthBlockByNumber_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetFinalizedBlockNumber provides a mock function with given fields: ctx
+func (_m *ethermanMock) GetFinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFinalizedBlockNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ethermanMock_GetFinalizedBlockNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFinalizedBlockNumber'
+type ethermanMock_GetFinalizedBlockNumber_Call struct {
+	*mock.Call
+}
+
+// GetFinalizedBlockNumber is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ethermanMock_Expecter) GetFinalizedBlockNumber(ctx interface{}) *ethermanMock_GetFinalizedBlockNumber_Call {
+	return &ethermanMock_GetFinalizedBlockNumber_Call{Call: _e.mock.On("GetFinalizedBlockNumber", ctx)}
+}
+
+func (_c *ethermanMock_GetFinalizedBlockNumber_Call) Run(run func(ctx context.Context)) *ethermanMock_GetFinalizedBlockNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ethermanMock_GetFinalizedBlockNumber_Call) Return(_a0 uint64, _a1 error) *ethermanMock_GetFinalizedBlockNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ethermanMock_GetFinalizedBlockNumber_Call) RunAndReturn(run func(context.Context) (uint64, error)) *ethermanMock_GetFinalizedBlockNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLatestBatchNumber provides a mock function with given fields:
 func (_m *ethermanMock) GetLatestBatchNumber() (uint64, error) {
 	ret := _m.Called()
@@ -380,6 +436,39 @@ func (_c *ethermanMock_HeaderByNumber_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// ResetDataAvailability provides a mock function with given fields: fromBlock
+func (_m *ethermanMock) ResetDataAvailability(fromBlock uint64) {
+	_m.Called(fromBlock)
+}
+
+// ethermanMock_ResetDataAvailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetDataAvailability'
+type ethermanMock_ResetDataAvailability_Call struct {
+	*mock.Call
+}
+
+// ResetDataAvailability is a helper method to define mock.On call
+//   - fromBlock uint64
+func (_e *ethermanMock_Expecter) ResetDataAvailability(fromBlock interface{}) *ethermanMock_ResetDataAvailability_Call {
+	return &ethermanMock_ResetDataAvailability_Call{Call: _e.mock.On("ResetDataAvailability", fromBlock)}
+}
+
+func (_c *ethermanMock_ResetDataAvailability_Call) Run(run func(fromBlock uint64)) *ethermanMock_ResetDataAvailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *ethermanMock_ResetDataAvailability_Call) Return() *ethermanMock_ResetDataAvailability_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ethermanMock_ResetDataAvailability_Call) RunAndReturn(run func(uint64)) *ethermanMock_ResetDataAvailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // VerifyGenBlockNumber provides a mock function with given fields: ctx, genBlockNumber
 func (_m *ethermanMock) VerifyGenBlockNumber(ctx context.Context, genBlockNumber uint64) (bool, error) {
 	ret := _m.Called(ctx, genBlockNumber)
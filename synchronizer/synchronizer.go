@@ -849,6 +849,11 @@ func (s *ClientSynchronizer) resetState(blockNumber uint64) error {
 		log.Error("error committing the resetted state. Error: ", err)
 		return err
 	}
+	// the data availability protocol changes done in the reorged blocks are scanned again
+	s.etherMan.ResetDataAvailability(blockNumber + 1)
+	for _, etherMan := range s.etherManForL1 {
+		etherMan.ResetDataAvailability(blockNumber + 1)
+	}
 	if s.asyncL1BlockChecker != nil {
 		s.asyncL1BlockChecker.OnResetState(s.ctx)
 	}
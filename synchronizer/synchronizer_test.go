@@ -1125,6 +1125,10 @@ func TestReorg(t *testing.T) {
 				Return(nil).
 				Once()
 
+			m.Etherman.
+				On("ResetDataAvailability", ethBlock0.NumberU64()+1).
+				Twice()
+
 			m.Etherman.
 				On("EthBlockByNumber", ctx, lastBlock0.BlockNumber).
 				Return(ethBlock0, nil).
@@ -1419,6 +1423,10 @@ func TestLatestSyncedBlockEmpty(t *testing.T) {
 				Return(nil).
 				Once()
 
+			m.Etherman.
+				On("ResetDataAvailability", ethBlock0.NumberU64()+1).
+				Twice()
+
 			m.Etherman.
 				On("EthBlockByNumber", ctx, lastBlock0.BlockNumber).
 				Return(ethBlock0, nil).
@@ -1637,6 +1645,10 @@ func TestRegularReorg(t *testing.T) {
 				Return(nil).
 				Once()
 
+			m.Etherman.
+				On("ResetDataAvailability", ethBlock0.NumberU64()+1).
+				Twice()
+
 			m.Etherman.
 				On("EthBlockByNumber", ctx, lastBlock0.BlockNumber).
 				Return(ethBlock0, nil).
@@ -1950,6 +1962,10 @@ func TestLatestSyncedBlockEmptyWithExtraReorg(t *testing.T) {
 				Return(nil).
 				Once()
 
+			m.Etherman.
+				On("ResetDataAvailability", ethBlock0.NumberU64()+1).
+				Twice()
+
 			m.Etherman.
 				On("EthBlockByNumber", ctx, lastBlock0.BlockNumber).
 				Return(ethBlock0, nil).
@@ -2231,6 +2247,10 @@ func TestCallFromEmptyBlockAndReorg(t *testing.T) {
 				Return(nil).
 				Once()
 
+			m.Etherman.
+				On("ResetDataAvailability", ethBlock0.NumberU64()+1).
+				Twice()
+
 			m.Etherman.
 				On("EthBlockByNumber", ctx, lastBlock0.BlockNumber).
 				Return(ethBlock0, nil).
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee/localdac"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagHost          = "host"
+	flagPort          = "port"
+	flagPrivateKey    = "private-key"
+	flagSequencerAddr = "sequencer-addr"
+	flagDrop          = "drop"
+	flagDelay         = "delay"
+	flagBadSignature  = "bad-signature"
+	flagWrongData     = "wrong-data"
+
+	defaultPort = 8444
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "LocalDAC"
+	app.Version = "v0.0.1"
+	app.Usage = "Runs an in-memory DAC node for tests and devnets"
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{Name: flagHost, Usage: "host to listen on", Value: "0.0.0.0"},
+		&cli.IntFlag{Name: flagPort, Usage: "port to listen on", Value: defaultPort},
+		&cli.StringFlag{Name: flagPrivateKey, Usage: "hex encoded private key of the committee member. A random one is used if empty"},
+		&cli.StringFlag{Name: flagSequencerAddr, Usage: "address of the trusted sequencer. Any signer is accepted if empty"},
+		&cli.BoolFlag{Name: flagDrop, Usage: "fail every request"},
+		&cli.DurationFlag{Name: flagDelay, Usage: "delay before answering every request"},
+		&cli.BoolFlag{Name: flagBadSignature, Usage: "sign sequences with a key that is not the member's"},
+		&cli.BoolFlag{Name: flagWrongData, Usage: "return data that doesn't match the requested hash"},
+	}
+	app.Action = run
+	if err := app.Run(os.Args); err != nil {
+		log.Errorf("\nError: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(cliCtx *cli.Context) error {
+	log.Init(log.Config{
+		Level:   "debug",
+		Outputs: []string{"stderr"},
+	})
+
+	var (
+		pk  *ecdsa.PrivateKey
+		err error
+	)
+	if privKey := cliCtx.String(flagPrivateKey); privKey != "" {
+		pk, err = crypto.HexToECDSA(strings.TrimPrefix(privKey, "0x"))
+	} else {
+		pk, err = crypto.GenerateKey()
+	}
+	if err != nil {
+		return fmt.Errorf("error loading private key: %w", err)
+	}
+
+	node := localdac.New(pk, common.HexToAddress(cliCtx.String(flagSequencerAddr)), nil)
+	node.SetFaults(localdac.Faults{
+		Drop:         cliCtx.Bool(flagDrop),
+		Delay:        cliCtx.Duration(flagDelay),
+		BadSignature: cliCtx.Bool(flagBadSignature),
+		WrongData:    cliCtx.Bool(flagWrongData),
+	})
+
+	address := fmt.Sprintf("%s:%d", cliCtx.String(flagHost), cliCtx.Int(flagPort))
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Handler:           node,
+		ReadHeaderTimeout: time.Minute,
+	}
+	log.Infof("local DAC node %s listening on %s", node.Addr().Hex(), address)
+	return srv.Serve(lis)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover"
+	"github.com/0xPolygonHermez/zkevm-node/aggregator/prover/mockprover"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	flagAggregator        = "aggregator"
+	flagName              = "name"
+	flagID                = "id"
+	flagForkID            = "fork-id"
+	flagLatency           = "latency"
+	flagFailureRate       = "failure-rate"
+	flagSeed              = "seed"
+	flagReconnectInterval = "reconnect-interval"
+
+	defaultForkID = 9
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "MockProver"
+	app.Version = "v0.0.1"
+	app.Usage = "Connects to the aggregator and answers its requests with fake proofs"
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{Name: flagAggregator, Usage: "address of the aggregator gRPC server", Value: "localhost:50081"},
+		&cli.StringFlag{Name: flagName, Usage: "prover name reported to the aggregator", Value: "mock-prover"},
+		&cli.StringFlag{Name: flagID, Usage: "prover id reported to the aggregator", Value: "mock-prover-1"},
+		&cli.Uint64Flag{Name: flagForkID, Usage: "fork id reported to the aggregator", Value: defaultForkID},
+		&cli.DurationFlag{Name: flagLatency, Usage: "time it takes to generate a proof", Value: time.Second},
+		&cli.Float64Flag{Name: flagFailureRate, Usage: "probability, between 0 and 1, of a proof failing"},
+		&cli.Int64Flag{Name: flagSeed, Usage: "seed deciding which proofs fail"},
+		&cli.DurationFlag{Name: flagReconnectInterval, Usage: "time to wait before reconnecting to the aggregator", Value: 5 * time.Second},
+	}
+	app.Action = run
+	if err := app.Run(os.Args); err != nil {
+		log.Errorf("\nError: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(cliCtx *cli.Context) error {
+	log.Init(log.Config{
+		Level:   "debug",
+		Outputs: []string{"stderr"},
+	})
+
+	failureRate := cliCtx.Float64(flagFailureRate)
+	if failureRate < 0 || failureRate > 1 {
+		return fmt.Errorf("invalid failure rate %v, it must be between 0 and 1", failureRate)
+	}
+	p := mockprover.New(mockprover.Config{
+		Name:        cliCtx.String(flagName),
+		ID:          cliCtx.String(flagID),
+		ForkID:      cliCtx.Uint64(flagForkID),
+		Latency:     cliCtx.Duration(flagLatency),
+		FailureRate: failureRate,
+		Seed:        cliCtx.Int64(flagSeed),
+	})
+
+	ctx, stop := signal.NotifyContext(cliCtx.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	addr := cliCtx.String(flagAggregator)
+	for {
+		if err := connect(ctx, p, addr); err != nil {
+			log.Errorf("Mock prover disconnected from the aggregator: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(cliCtx.Duration(flagReconnectInterval)):
+		}
+	}
+}
+
+// connect opens a channel with the aggregator and serves it until it's closed
+func connect(ctx context.Context, p *mockprover.Prover, addr string) error {
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := prover.NewAggregatorServiceClient(conn).Channel(ctx)
+	if err != nil {
+		return err
+	}
+	log.Infof("Mock prover connected to the aggregator at %s", addr)
+	return p.Run(ctx, stream)
+}
@@ -68,6 +68,12 @@ var (
 		Usage:    "Instaed of using the state_root from previous batch use the stateRoot from previous execution (default:false)",
 		Required: false,
 	}
+	batchNumberFlag = cli.Uint64Flag{
+		Name:     "batch_number",
+		Aliases:  []string{"batch"},
+		Usage:    "Batch number to replay",
+		Required: true,
+	}
 )
 
 func main() {
@@ -90,6 +96,13 @@ func main() {
 			Flags: []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag, &configChainIDFlag, &firstBatchNumberFlag,
 				&lastBatchNumberFlag, &writeOnHashDBFlag, &dontStopOnErrorFlag, &preferExecutionStateRootFlag},
 		},
+		{
+			Name:    "replay",
+			Aliases: []string{},
+			Usage:   "replay a batch L2 block by L2 block as the sequencer does and diff it against the state",
+			Action:  replayCmd,
+			Flags:   []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag, &configChainIDFlag, &batchNumberFlag},
+		},
 	}
 	err := app.Run(os.Args)
 	if err != nil {
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	stateMetrics "github.com/0xPolygonHermez/zkevm-node/state/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// replayAction re-executes a batch L2 block by L2 block, rebuilding the same ProcessRequest inputs used by the
+// finalizer, and diffs the results against the data stored in the state
+type replayAction struct {
+	batchNumber uint64
+	st          *state.State
+	ctx         context.Context
+}
+
+// replayDivergence is the first difference found between the replayed batch and the stored one
+type replayDivergence struct {
+	blockNumber uint64
+	txIndex     int
+	txHash      common.Hash
+	diffs       []string
+}
+
+func (r *replayAction) start() (*replayDivergence, error) {
+	prevBatch, err := r.st.GetBatchByNumber(r.ctx, r.batchNumber-1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting previous batch %d, error: %w", r.batchNumber-1, err)
+	}
+	batch, err := r.st.GetBatchByNumber(r.ctx, r.batchNumber, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting batch %d, error: %w", r.batchNumber, err)
+	}
+	forkID := r.st.GetForkIDByBatchNumber(r.batchNumber)
+	if forkID < state.FORKID_ETROG {
+		return nil, fmt.Errorf("batch %d has forkID %d, only batches with forkID >= %d are executed by L2 block", r.batchNumber, forkID, state.FORKID_ETROG)
+	}
+	l2Blocks, err := r.st.GetL2BlocksByBatchNumber(r.ctx, r.batchNumber, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting L2 blocks of batch %d, error: %w", r.batchNumber, err)
+	}
+
+	requests, err := r.buildRequests(batch, prevBatch.StateRoot, l2Blocks, forkID)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("REPLAY: batch %d forkID: %d forced: %v l2Blocks: %d oldStateRoot: %s\n", batch.BatchNumber, forkID, batch.ForcedBatchNum != nil, len(l2Blocks), prevBatch.StateRoot)
+
+	usedZKCounters := state.ZKCounters{}
+	blockIdx := 0
+	for _, request := range requests {
+		response, contextId, err := r.st.ProcessBatchV2(r.ctx, request, false)
+		if err != nil {
+			return nil, fmt.Errorf("error executing batch %d, error: %w", r.batchNumber, err)
+		}
+		if response.ExecutorError != nil {
+			return nil, fmt.Errorf("executor error executing batch %d, contextId: %s, error: %w", r.batchNumber, contextId, response.ExecutorError)
+		}
+		usedZKCounters.SumUp(response.UsedZkCounters)
+
+		for _, blockResponse := range response.BlockResponses {
+			if blockIdx >= len(l2Blocks) {
+				return &replayDivergence{blockNumber: blockResponse.BlockNumber, txIndex: -1, diffs: []string{
+					fmt.Sprintf("executor returned more L2 blocks than the %d stored", len(l2Blocks)),
+				}}, nil
+			}
+			divergence, err := r.diffL2Block(&l2Blocks[blockIdx], blockResponse, forkID)
+			if err != nil || divergence != nil {
+				return divergence, err
+			}
+			fmt.Printf("  block %d: txs: %d stateRoot: %s [OK]\n", l2Blocks[blockIdx].NumberU64(), len(blockResponse.TransactionResponses), l2Blocks[blockIdx].Root())
+			blockIdx++
+		}
+	}
+	if blockIdx != len(l2Blocks) {
+		return &replayDivergence{blockNumber: l2Blocks[blockIdx].NumberU64(), txIndex: -1, diffs: []string{
+			fmt.Sprintf("executor returned %d L2 blocks, stored: %d", blockIdx, len(l2Blocks)),
+		}}, nil
+	}
+
+	// The stored counters of a closed batch are the used counters computed by the finalizer while executing it
+	if !batch.WIP {
+		if diffs := diffZKCounters(batch.Resources.ZKCounters, usedZKCounters); len(diffs) > 0 {
+			fmt.Printf("ZK counters mismatch for batch %d:\n", batch.BatchNumber)
+			for _, diff := range diffs {
+				fmt.Printf("  %s\n", diff)
+			}
+		} else {
+			fmt.Printf("ZK counters of batch %d match [OK]\n", batch.BatchNumber)
+		}
+	}
+
+	return nil, nil
+}
+
+// buildRequests returns the requests used by the finalizer to execute the batch. A forced batch is executed in a
+// single request, while a regular batch is executed in a request per L2 block
+func (r *replayAction) buildRequests(batch *state.Batch, oldStateRoot common.Hash, l2Blocks []state.L2Block, forkID uint64) ([]state.ProcessRequest, error) {
+	if batch.ForcedBatchNum != nil {
+		forcedBatch, err := r.st.GetForcedBatch(r.ctx, *batch.ForcedBatchNum, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting forced batch %d, error: %w", *batch.ForcedBatchNum, err)
+		}
+		fbL1Block, err := r.st.GetBlockByNumber(r.ctx, forcedBatch.BlockNumber, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting L1 block %d of forced batch %d, error: %w", forcedBatch.BlockNumber, forcedBatch.ForcedBatchNumber, err)
+		}
+		return []state.ProcessRequest{{
+			BatchNumber:             batch.BatchNumber,
+			L1InfoRoot_V2:           forcedBatch.GlobalExitRoot,
+			ForcedBlockHashL1:       fbL1Block.ParentHash,
+			OldStateRoot:            oldStateRoot,
+			Transactions:            forcedBatch.RawTxsData,
+			Coinbase:                batch.Coinbase,
+			TimestampLimit_V2:       uint64(forcedBatch.ForcedAt.Unix()),
+			ForkID:                  forkID,
+			SkipVerifyL1InfoRoot_V2: true,
+			Caller:                  stateMetrics.DiscardCallerLabel,
+		}}, nil
+	}
+
+	rawBatch, err := state.DecodeBatchV2(batch.BatchL2Data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding BatchL2Data of batch %d, error: %w", batch.BatchNumber, err)
+	}
+	if len(rawBatch.Blocks) != len(l2Blocks) {
+		return nil, fmt.Errorf("batch %d has %d L2 blocks in BatchL2Data but %d stored", batch.BatchNumber, len(rawBatch.Blocks), len(l2Blocks))
+	}
+
+	requests := make([]state.ProcessRequest, 0, len(l2Blocks))
+	for i, rawBlock := range rawBatch.Blocks {
+		// BatchL2Data of the L2 block, with the changeL2Block and the txs with their effective gas percentage
+		blockL2Data, err := state.EncodeBatchV2(&state.BatchRawV2{Blocks: []state.L2BlockRaw{rawBlock}})
+		if err != nil {
+			return nil, fmt.Errorf("error encoding L2 block %d, error: %w", l2Blocks[i].NumberU64(), err)
+		}
+		l1InfoTreeData, _, _, err := r.st.GetL1InfoTreeDataFromBatchL2Data(r.ctx, blockL2Data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting L1InfoTreeData of L2 block %d, error: %w", l2Blocks[i].NumberU64(), err)
+		}
+
+		requests = append(requests, state.ProcessRequest{
+			BatchNumber:               batch.BatchNumber,
+			OldStateRoot:              oldStateRoot,
+			Coinbase:                  batch.Coinbase,
+			L1InfoRoot_V2:             state.GetMockL1InfoRoot(),
+			TimestampLimit_V2:         l2Blocks[i].Time(),
+			Transactions:              blockL2Data,
+			SkipFirstChangeL2Block_V2: false,
+			SkipWriteBlockInfoRoot_V2: false,
+			Caller:                    stateMetrics.DiscardCallerLabel,
+			ForkID:                    forkID,
+			SkipVerifyL1InfoRoot_V2:   true,
+			L1InfoTreeData_V2:         l1InfoTreeData,
+			ExecutionMode:             executor.ExecutionMode0,
+		})
+		// Each L2 block is executed over the stored state root of the previous one
+		oldStateRoot = l2Blocks[i].Root()
+	}
+
+	return requests, nil
+}
+
+// diffL2Block diffs the txs and the state root of the replayed L2 block against the stored one, returning the first
+// divergent tx, or the L2 block if the divergence isn't in its txs
+func (r *replayAction) diffL2Block(l2Block *state.L2Block, blockResponse *state.ProcessBlockResponse, forkID uint64) (*replayDivergence, error) {
+	storedTxs := l2Block.Transactions()
+	txIndex := 0
+	for _, txResponse := range blockResponse.TransactionResponses {
+		// The txs with an intrinsic error aren't stored, as they don't change the state
+		romErrorCode := executor.RomErrorCode(txResponse.RomError)
+		if executor.IsIntrinsicError(romErrorCode) || executor.IsInvalidL2Block(romErrorCode) {
+			log.Infof("tx %s of L2 block %d not stored, rom error: %v", txResponse.TxHash, l2Block.NumberU64(), txResponse.RomError)
+			continue
+		}
+
+		if txIndex >= len(storedTxs) {
+			return &replayDivergence{blockNumber: l2Block.NumberU64(), txIndex: txIndex, txHash: txResponse.TxHash, diffs: []string{
+				fmt.Sprintf("tx not stored, the L2 block has %d txs stored", len(storedTxs)),
+			}}, nil
+		}
+		if txResponse.TxHash != storedTxs[txIndex].Hash() {
+			return &replayDivergence{blockNumber: l2Block.NumberU64(), txIndex: txIndex, txHash: storedTxs[txIndex].Hash(), diffs: []string{
+				fmt.Sprintf("tx hash: stored: %s, replayed: %s", storedTxs[txIndex].Hash(), txResponse.TxHash),
+			}}, nil
+		}
+
+		storedReceipt, err := r.st.GetTransactionReceipt(r.ctx, txResponse.TxHash, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting receipt of tx %s, error: %w", txResponse.TxHash, err)
+		}
+		replayedReceipt := state.GenerateReceipt(nil, txResponse, uint(txIndex), forkID)
+		if diffs := diffReceipts(storedReceipt, replayedReceipt); len(diffs) > 0 {
+			if txResponse.RomError != nil {
+				diffs = append(diffs, fmt.Sprintf("replayed rom error: %v", txResponse.RomError))
+			}
+			return &replayDivergence{blockNumber: l2Block.NumberU64(), txIndex: txIndex, txHash: txResponse.TxHash, diffs: diffs}, nil
+		}
+		txIndex++
+	}
+	if txIndex != len(storedTxs) {
+		return &replayDivergence{blockNumber: l2Block.NumberU64(), txIndex: txIndex, txHash: storedTxs[txIndex].Hash(), diffs: []string{
+			fmt.Sprintf("txs: stored: %d, replayed: %d", len(storedTxs), txIndex),
+		}}, nil
+	}
+
+	// The block hash returned by the executor is the state root of the L2 block
+	var diffs []string
+	if blockResponse.BlockHash != l2Block.Root() {
+		diffs = append(diffs, fmt.Sprintf("state root: stored: %s, replayed: %s", l2Block.Root(), blockResponse.BlockHash))
+	}
+	if blockResponse.BlockInfoRoot != l2Block.BlockInfoRoot() {
+		diffs = append(diffs, fmt.Sprintf("block info root: stored: %s, replayed: %s", l2Block.BlockInfoRoot(), blockResponse.BlockInfoRoot))
+	}
+	if blockResponse.Timestamp != l2Block.Time() {
+		diffs = append(diffs, fmt.Sprintf("timestamp: stored: %d, replayed: %d", l2Block.Time(), blockResponse.Timestamp))
+	}
+	if len(diffs) > 0 {
+		return &replayDivergence{blockNumber: l2Block.NumberU64(), txIndex: -1, diffs: diffs}, nil
+	}
+
+	return nil, nil
+}
+
+// diffReceipts returns the fields of the replayed receipt that differ from the stored one
+func diffReceipts(stored, replayed *types.Receipt) []string {
+	var diffs []string
+	if stored.Status != replayed.Status {
+		diffs = append(diffs, fmt.Sprintf("status: stored: %d, replayed: %d", stored.Status, replayed.Status))
+	}
+	if !bytes.Equal(stored.PostState, replayed.PostState) {
+		diffs = append(diffs, fmt.Sprintf("state root: stored: %s, replayed: %s", common.BytesToHash(stored.PostState), common.BytesToHash(replayed.PostState)))
+	}
+	if stored.GasUsed != replayed.GasUsed {
+		diffs = append(diffs, fmt.Sprintf("gas used: stored: %d, replayed: %d", stored.GasUsed, replayed.GasUsed))
+	}
+	if stored.CumulativeGasUsed != replayed.CumulativeGasUsed {
+		diffs = append(diffs, fmt.Sprintf("cumulative gas used: stored: %d, replayed: %d", stored.CumulativeGasUsed, replayed.CumulativeGasUsed))
+	}
+	if stored.ContractAddress != replayed.ContractAddress {
+		diffs = append(diffs, fmt.Sprintf("contract address: stored: %s, replayed: %s", stored.ContractAddress, replayed.ContractAddress))
+	}
+	if stored.EffectiveGasPrice != nil && replayed.EffectiveGasPrice != nil && stored.EffectiveGasPrice.Cmp(replayed.EffectiveGasPrice) != 0 {
+		diffs = append(diffs, fmt.Sprintf("effective gas price: stored: %s, replayed: %s", stored.EffectiveGasPrice, replayed.EffectiveGasPrice))
+	}
+	if len(stored.Logs) != len(replayed.Logs) {
+		diffs = append(diffs, fmt.Sprintf("logs: stored: %d, replayed: %d", len(stored.Logs), len(replayed.Logs)))
+		return diffs
+	}
+	for i := range stored.Logs {
+		if stored.Logs[i].Address != replayed.Logs[i].Address || !bytes.Equal(stored.Logs[i].Data, replayed.Logs[i].Data) || !equalTopics(stored.Logs[i].Topics, replayed.Logs[i].Topics) {
+			diffs = append(diffs, fmt.Sprintf("log %d: stored: {address: %s, topics: %v, data: %x}, replayed: {address: %s, topics: %v, data: %x}",
+				i, stored.Logs[i].Address, stored.Logs[i].Topics, stored.Logs[i].Data, replayed.Logs[i].Address, replayed.Logs[i].Topics, replayed.Logs[i].Data))
+		}
+	}
+	return diffs
+}
+
+func equalTopics(a, b []common.Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// diffZKCounters returns the counters of the replayed batch that differ from the stored ones
+func diffZKCounters(stored, replayed state.ZKCounters) []string {
+	var diffs []string
+	addDiff := func(name string, stored, replayed uint64) {
+		if stored != replayed {
+			diffs = append(diffs, fmt.Sprintf("%s: stored: %d, replayed: %d", name, stored, replayed))
+		}
+	}
+	addDiff("gasUsed", stored.GasUsed, replayed.GasUsed)
+	addDiff("keccakHashes", uint64(stored.KeccakHashes), uint64(replayed.KeccakHashes))
+	addDiff("poseidonHashes", uint64(stored.PoseidonHashes), uint64(replayed.PoseidonHashes))
+	addDiff("poseidonPaddings", uint64(stored.PoseidonPaddings), uint64(replayed.PoseidonPaddings))
+	addDiff("memAligns", uint64(stored.MemAligns), uint64(replayed.MemAligns))
+	addDiff("arithmetics", uint64(stored.Arithmetics), uint64(replayed.Arithmetics))
+	addDiff("binaries", uint64(stored.Binaries), uint64(replayed.Binaries))
+	addDiff("steps", uint64(stored.Steps), uint64(replayed.Steps))
+	addDiff("sha256Hashes", uint64(stored.Sha256Hashes_V2), uint64(replayed.Sha256Hashes_V2))
+	return diffs
+}
+
+func (d *replayDivergence) print(batchNumber uint64) {
+	if d.txIndex < 0 {
+		fmt.Printf("DIVERGENCE: batch %d block %d\n", batchNumber, d.blockNumber)
+	} else {
+		fmt.Printf("DIVERGENCE: batch %d block %d tx[%d] %s\n", batchNumber, d.blockNumber, d.txIndex, d.txHash)
+	}
+	for _, diff := range d.diffs {
+		fmt.Printf("  %s\n", diff)
+	}
+	log.Debugf("divergence of batch %d: %+v", batchNumber, d)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/urfave/cli/v2"
+)
+
+func replayCmd(cliCtx *cli.Context) error {
+	cfg, err := config.Load(cliCtx, isNetworkConfigNeeded(cliCtx))
+	if err != nil {
+		return err
+	}
+	log.Init(cfg.Log)
+	stateSqlDB, err := db.NewSQLDB(cfg.State.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	l2ChainID := getL2ChainID(cliCtx, cfg)
+	needsExecutor := true
+	needsStateTree := false
+
+	st := newState(cliCtx.Context, cfg, l2ChainID, []state.ForkIDInterval{}, stateSqlDB, nil, needsExecutor, needsStateTree)
+
+	forksIdIntervals, err := getforkIDIntervalsFromDB(context.Background(), st)
+	if err != nil {
+		log.Errorf("error getting forkIDs from db. Error: %v", err)
+		return err
+	}
+	st.UpdateForkIDIntervalsInMemory(forksIdIntervals)
+
+	batchNumber := cliCtx.Uint64(batchNumberFlag.Name)
+	if batchNumber == 0 {
+		return fmt.Errorf("batch 0 can't be replayed, set a batch number with --%s", batchNumberFlag.Name)
+	}
+
+	action := replayAction{
+		batchNumber: batchNumber,
+		st:          st,
+		ctx:         cliCtx.Context,
+	}
+	divergence, err := action.start()
+	if err != nil {
+		log.Errorf("error replaying batch %d. Error: %v", batchNumber, err)
+		return err
+	}
+	if divergence != nil {
+		divergence.print(batchNumber)
+		return fmt.Errorf("batch %d diverges from the state", batchNumber)
+	}
+	fmt.Printf("batch %d matches the state [OK]\n", batchNumber)
+	return nil
+}